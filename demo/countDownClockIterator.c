@@ -33,6 +33,9 @@ main() {
     ctx:= animGraphic.New720p().Scale(1/3.0,1/3.0)
 
     try( encoder := render.New( "test.mp4", frameRate ) ) {
+        // Attach the context so each frame is begun before it is drawn
+        encoder.Attach(ctx)
+
         //encoder.TimeCode().Set("09:25:30")
 
         // The end frame number - here we want startTime seconds + a buffer at the end
@@ -50,7 +53,7 @@ main() {
             // have an extra second on the end showing the clock stopped
             demoCountdown(ctx, math.Max(0,startTime - (math.Float(frameNum)/frameRate)) )
 
-            encoder.WriteImage(ctx.Image())
+            encoder.WriteContext(ctx)
         }
     }
 
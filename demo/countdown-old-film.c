@@ -16,12 +16,15 @@ main() {
 
     ctx:= animGraphic.NewContext()
 
+    // The layer the swipe is drawn into, created once and reused for every frame
+    layer := ctx.NewLayer("swipe")
+
     try( encoder := ffmpeg.New( "test.mp4", frameRate ) ) {
         for second:=duration; second>=0; second=second-1 {
             drawBackground(ctx)
 
             for frame:=0; frame<frameRate; frame=frame+1 {
-            drawSwipe(ctx,frame,frameRate)
+            drawSwipe(ctx,layer,frame,frameRate)
             drawCircles(ctx,circleColour)
             drawCounter(ctx,second,numberColour)
                 encoder.WriteImage(ctx.Image())
@@ -35,7 +38,10 @@ drawBackground(ctx) {
     // Hint, you could place a static image here instead of a fill
 }
 
-drawSwipe(ctx,frame,frameRate) {
+drawSwipe(ctx,layer,frame,frameRate) {
+    // Clear what was drawn into the layer by the previous frame
+    image.Fill(layer,colour.Colour("transparent"))
+
     bounds := ctx.Bounds()
     center := ctx.Center()
     radius := math.Sqrt( (center[0]*center[0])+(center[1]*center[1]))
@@ -44,36 +50,28 @@ drawSwipe(ctx,frame,frameRate) {
     theta:= 2.0*math.Pi*math.Float(frame)/math.Float(frameRate)
     deg := math.Deg(theta)
     if theta > 0.00001 {
-        try( ctx ) {
-            gc := ctx.Gc()
-
-            // Save the underlying image then create a mask to apply
-            image := ctx.Image()
-            ctx.NewImage()
+        // Draw the swipe into its own layer then darken the frame with it
+        gc := layer.Gc()
 
-            col := colour.Colour("#40404040")
-            println(col)
-            gc.SetFillColor(col)
-            gc.BeginPath()
-            gc.MoveTo(center[0],center[1])
-            gc.LineTo(center[0],0)
-
-            if deg >= 45.0 { gc.LineTo(bounds.X2,0)}
-            if deg >= 135.0 { gc.LineTo(bounds.X2, bounds.Y2)}
-            if deg >= 225.0 { gc.LineTo(bounds.X1,bounds.Y2)}
-            if deg >= 315.0 { gc.LineTo(bounds.X1,bounds.Y1)}
+        gc.SetFillColor(colour.Colour("#404040"))
+        gc.BeginPath()
+        gc.MoveTo(center[0],center[1])
+        gc.LineTo(center[0],0)
 
-            sc := math.Sincos(theta)
-            x := radius * sc[0]
-            y := radius * sc[1]
-            gc.LineTo(center[0]+x,center[1]-y)
+        if deg >= 45.0 { gc.LineTo(bounds.X2,0)}
+        if deg >= 135.0 { gc.LineTo(bounds.X2, bounds.Y2)}
+        if deg >= 225.0 { gc.LineTo(bounds.X1,bounds.Y2)}
+        if deg >= 315.0 { gc.LineTo(bounds.X1,bounds.Y1)}
 
-            gc.LineTo(center[0],center[1])
-            gc.Fill()
+        sc := math.Sincos(theta)
+        x := radius * sc[0]
+        y := radius * sc[1]
+        gc.LineTo(center[0]+x,center[1]-y)
 
-            // Now overlay the two images
+        gc.LineTo(center[0],center[1])
+        gc.Fill()
 
-        }
+        animGraphic.CompositeLayer(ctx, "swipe", 0.25, "multiply")
     }
 }
 
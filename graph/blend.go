@@ -0,0 +1,167 @@
+package graph
+
+import (
+	"github.com/peter-mount/go-anim/util/goexr/exr"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"strings"
+)
+
+// BlendMode is how the colours of an image are combined with those it is drawn over
+type BlendMode int
+
+const (
+	BlendNormal     BlendMode = iota // The image is drawn over
+	BlendMultiply                    // Darkens, multiplying the colours
+	BlendScreen                      // Lightens, the inverse of multiplying the inverted colours
+	BlendOverlay                     // Multiply or screen depending on the colour drawn over
+	BlendDarken                      // The darker of the two colours
+	BlendLighten                     // The lighter of the two colours
+	BlendAdd                         // Adds the colours, also called linear dodge
+	BlendDifference                  // The difference between the colours
+)
+
+var blendModes = map[string]BlendMode{
+	"normal":     BlendNormal,
+	"multiply":   BlendMultiply,
+	"screen":     BlendScreen,
+	"overlay":    BlendOverlay,
+	"darken":     BlendDarken,
+	"lighten":    BlendLighten,
+	"add":        BlendAdd,
+	"difference": BlendDifference,
+}
+
+// ParseBlendMode returns the BlendMode of a name, e.g. "multiply", defaulting to BlendNormal
+func ParseBlendMode(s string) BlendMode {
+	return blendModes[strings.ToLower(strings.TrimSpace(s))]
+}
+
+// blend returns the colour from blending source colour s over backdrop b, both not premultiplied
+func (m BlendMode) blend(b, s float64) float64 {
+	switch m {
+	case BlendMultiply:
+		return b * s
+	case BlendScreen:
+		return b + s - b*s
+	case BlendOverlay:
+		if b <= 0.5 {
+			return 2 * b * s
+		}
+		return BlendScreen.blend(2*b-1, s)
+	case BlendDarken:
+		return math.Min(b, s)
+	case BlendLighten:
+		return math.Max(b, s)
+	case BlendAdd:
+		return math.Min(1, b+s)
+	case BlendDifference:
+		return math.Abs(b - s)
+	default:
+		return s
+	}
+}
+
+// Composite draws src over dst with the blend mode, using the same coordinates for both.
+// opacity scales the alpha of src, and when mask is not nil its alpha limits where src is drawn.
+func Composite(dst draw.Image, src image.Image, mask image.Image, opacity float64, mode BlendMode) {
+	opacity = math.Max(0, math.Min(1, opacity))
+	if opacity == 0 {
+		return
+	}
+
+	r := dst.Bounds().Intersect(src.Bounds())
+	if mask != nil {
+		r = r.Intersect(mask.Bounds())
+	}
+
+	// Floating point images are linear, so sRGB sources are linearised to match
+	srcAt := floatAt
+	if _, ok := dst.(*exr.RGBAImage); ok {
+		srcAt = linearAt
+	}
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			s := srcAt(src, x, y)
+			sa := s.a * opacity
+			if mask != nil {
+				sa *= floatAt(mask, x, y).a
+			}
+			if sa == 0 {
+				continue
+			}
+
+			b := floatAt(dst, x, y)
+			setFloat(dst, x, y, floatColour{
+				r: mode.channel(b.r, b.a, s.r, s.a, sa),
+				g: mode.channel(b.g, b.a, s.g, s.a, sa),
+				b: mode.channel(b.b, b.a, s.b, s.a, sa),
+				a: sa + b.a*(1-sa),
+			})
+		}
+	}
+}
+
+// channel returns the premultiplied result of compositing one channel, as in the W3C compositing specification.
+// bc and sc are premultiplied by their alpha ba and sAlpha, with sa being the effective alpha of the source.
+func (m BlendMode) channel(bc, ba, sc, sAlpha, sa float64) float64 {
+	// Unpremultiplied colours
+	cb, cs := 0.0, 0.0
+	if ba > 0 {
+		cb = bc / ba
+	}
+	if sAlpha > 0 {
+		cs = sc / sAlpha
+	}
+	return sa*(1-ba)*cs + sa*ba*m.blend(cb, cs) + (1-sa)*ba*cb
+}
+
+// floatColour is a premultiplied colour, with white being 1 unless from an exr.RGBAImage which can be brighter
+type floatColour struct {
+	r, g, b, a float64
+}
+
+// floatAt returns the colour of a pixel, keeping the precision of floating point images
+// and avoiding allocation for images which support it
+func floatAt(img image.Image, x, y int) floatColour {
+	switch i := img.(type) {
+	case *exr.RGBAImage:
+		c := i.At(x, y).(exr.RGBAColor)
+		return floatColour{r: float64(c.R), g: float64(c.G), b: float64(c.B), a: float64(c.A)}
+	case image.RGBA64Image:
+		c := i.RGBA64At(x, y)
+		return floatColour{r: float64(c.R) / 0xffff, g: float64(c.G) / 0xffff, b: float64(c.B) / 0xffff, a: float64(c.A) / 0xffff}
+	default:
+		r, g, b, a := img.At(x, y).RGBA()
+		return floatColour{r: float64(r) / 0xffff, g: float64(g) / 0xffff, b: float64(b) / 0xffff, a: float64(a) / 0xffff}
+	}
+}
+
+// linearAt is floatAt but with images other than exr.RGBAImage converted from sRGB to linear space
+func linearAt(img image.Image, x, y int) floatColour {
+	if _, ok := img.(*exr.RGBAImage); ok {
+		return floatAt(img, x, y)
+	}
+	c := exr.RGBAModel.Convert(img.At(x, y)).(exr.RGBAColor)
+	return floatColour{r: float64(c.R), g: float64(c.G), b: float64(c.B), a: float64(c.A)}
+}
+
+// setFloat sets a pixel to a premultiplied colour
+func setFloat(img draw.Image, x, y int, c floatColour) {
+	switch i := img.(type) {
+	case *exr.RGBAImage:
+		i.Set(x, y, exr.RGBAColor{R: float32(c.r), G: float32(c.g), B: float32(c.b), A: float32(c.a)})
+	case draw.RGBA64Image:
+		i.SetRGBA64(x, y, color.RGBA64{R: to16(c.r), G: to16(c.g), B: to16(c.b), A: to16(c.a)})
+	default:
+		img.Set(x, y, color.RGBA64{R: to16(c.r), G: to16(c.g), B: to16(c.b), A: to16(c.a)})
+	}
+}
+
+// to16 converts a channel to 16 bits, clamping it to [0,1]
+func to16(v float64) uint16 {
+	return uint16(math.Round(math.Max(0, math.Min(1, v)) * 0xffff))
+}
@@ -1,11 +1,11 @@
 package graph
 
 import (
-	"github.com/llgcode/draw2d/draw2dimg"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
 	"github.com/peter-mount/go-anim/util/font"
 )
 
-func SetFont(gc *draw2dimg.GraphicContext, s string) error {
+func SetFont(gc draw2d2.GraphicContext, s string) error {
 	f, err := font.ParseFont(s)
 	if err != nil {
 		return err
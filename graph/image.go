@@ -3,6 +3,8 @@ package graph
 
 import (
 	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/goexr/exr"
 	"image"
 	"image/color"
 	"image/draw"
@@ -30,6 +32,29 @@ func DuplicateImage(img image.Image) Image {
 	return dst
 }
 
+// NewImageOf creates a new mutable image with the same precision as img,
+// so 16-bit and floating point images are not reduced to 8 bits
+func NewImageOf(img image.Image, bounds image.Rectangle) Image {
+	return draw2d2.NewImageOf(img, bounds)
+}
+
+// CopyImage creates a new copy of an image which is also mutable, keeping its precision
+func CopyImage(img image.Image) Image {
+	b := img.Bounds()
+	dst := NewImageOf(img, b)
+	if _, ok := img.(*exr.RGBAImage); ok {
+		// draw.Draw goes through color.RGBA64 which would lose anything brighter than white
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				dst.Set(x, y, img.At(x, y))
+			}
+		}
+		return dst
+	}
+	draw.Draw(dst, b, img, b.Min, draw.Src)
+	return dst
+}
+
 type wrapper struct {
 	img image.Image
 }
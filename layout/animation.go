@@ -0,0 +1,352 @@
+package layout
+
+import (
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"image"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Easing maps the linear progress of a Transition, from 0 to 1, to the progress of the animated property
+type Easing func(float64) float64
+
+// Standard easing functions
+var (
+	Linear    Easing = func(t float64) float64 { return t }
+	EaseIn    Easing = func(t float64) float64 { return t * t * t }
+	EaseOut   Easing = func(t float64) float64 { return 1 - math.Pow(1-t, 3) }
+	EaseInOut Easing = func(t float64) float64 {
+		if t < 0.5 {
+			return 4 * t * t * t
+		}
+		return 1 - math.Pow(-2*t+2, 3)/2
+	}
+)
+
+// ParseEasing returns the Easing for "linear", "ease-in", "ease-out" or "ease-in-out", defaulting to Linear
+func ParseEasing(s string) Easing {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "ease-in", "in":
+		return EaseIn
+	case "ease-out", "out":
+		return EaseOut
+	case "ease-in-out", "in-out", "ease":
+		return EaseInOut
+	default:
+		return Linear
+	}
+}
+
+// Transition defines how long an animated change takes and its easing
+type Transition struct {
+	Duration time.Duration
+	Easing   Easing // nil for Linear
+}
+
+// progress returns the eased progress at now of a transition which started at start, 1 once complete
+func (t Transition) progress(start, now time.Time) float64 {
+	if t.Duration <= 0 || start.IsZero() {
+		return 1
+	}
+	if !now.After(start) {
+		return 0
+	}
+	p := float64(now.Sub(start)) / float64(t.Duration)
+	if p >= 1 {
+		return 1
+	}
+	if t.Easing != nil {
+		return t.Easing(p)
+	}
+	return p
+}
+
+// active returns true if a transition which started at start is still running at now
+func (t Transition) active(start, now time.Time) bool {
+	return t.Duration > 0 && !start.IsZero() && now.Before(start.Add(t.Duration))
+}
+
+// ShowEffect is how a component appears when shown and disappears when hidden
+type ShowEffect uint8
+
+const (
+	Fade       ShowEffect = 1 << iota // Fade in and out
+	SlideLeft                         // Slide in from the left
+	SlideRight                        // Slide in from the right
+	SlideUp                           // Slide up from below
+	SlideDown                         // Slide down from above
+)
+
+// ParseShowEffect parses a space separated list of "fade", "slide-left", "slide-right", "slide-up" and "slide-down"
+func ParseShowEffect(s string) ShowEffect {
+	var e ShowEffect
+	for _, f := range strings.Fields(strings.ToLower(s)) {
+		switch f {
+		case "fade":
+			e |= Fade
+		case "slide-left":
+			e |= SlideLeft
+		case "slide-right":
+			e |= SlideRight
+		case "slide-up":
+			e |= SlideUp
+		case "slide-down":
+			e |= SlideDown
+		}
+	}
+	return e
+}
+
+// Animated is implemented by components which support animated transitions, which is any embedding BaseComponent
+type Animated interface {
+	AnimateValues(Transition)
+	AnimateShow(ShowEffect, Transition)
+	AnimateMove(Transition)
+	Show()
+	Hide()
+	IsShown() bool
+}
+
+// animation is the animated state of a BaseComponent.
+// Components are only animated once they have a clock, set with SetTime.
+type animation struct {
+	now       time.Time       // Time of the frame being rendered
+	values    Transition      // Transition of numeric args
+	show      Transition      // Transition when shown or hidden
+	effect    ShowEffect      // Effect when shown or hidden
+	move      Transition      // Transition when moved by the layout
+	drawn     bool            // true once drawn, so the initial layout is not animated
+	argsFrom  []any           // Args being animated from
+	argsStart time.Time       // Time the args changed
+	hidden    bool            // true if hidden
+	showFrom  float64         // Visibility, 0 hidden to 1 shown, when it last changed
+	showStart time.Time       // Time the visibility last changed
+	moveFrom  image.Rectangle // Bounds being moved from
+	moveStart time.Time       // Time the bounds changed
+}
+
+// AnimateValues animates numeric args, so changes count up or down to their new value
+func (c *BaseComponent) AnimateValues(t Transition) {
+	c.anim.values = t
+}
+
+// AnimateShow animates the component when it is shown or hidden
+func (c *BaseComponent) AnimateShow(effect ShowEffect, t Transition) {
+	c.anim.effect = effect
+	c.anim.show = t
+}
+
+// AnimateMove animates the component moving to a new position, and resizing to a new size, when its layout changes.
+func (c *BaseComponent) AnimateMove(t Transition) {
+	c.anim.move = t
+}
+
+// Show makes the component visible, animated if AnimateShow has been called
+func (c *BaseComponent) Show() {
+	c.setHidden(false)
+}
+
+// Hide hides the component, animated if AnimateShow has been called
+func (c *BaseComponent) Hide() {
+	c.setHidden(true)
+}
+
+// IsShown returns true unless Hide has been called.
+// A hidden component keeps its place in the layout until it has finished disappearing.
+func (c *BaseComponent) IsShown() bool {
+	return !c.anim.hidden
+}
+
+func (c *BaseComponent) setHidden(hidden bool) {
+	a := &c.anim
+	if a.hidden == hidden {
+		return
+	}
+	a.showFrom = a.visibility()
+	if a.drawn {
+		a.showStart = a.now
+	} else {
+		// Not yet drawn so change immediately
+		a.showStart = time.Time{}
+	}
+	a.hidden = hidden
+	c.updateRequired = true
+}
+
+// visibility returns how visible the component is at the current time, 0 hidden to 1 shown
+func (a *animation) visibility() float64 {
+	target := 1.0
+	if a.hidden {
+		target = 0
+	}
+	p := a.show.progress(a.showStart, a.now)
+	return a.showFrom + (target-a.showFrom)*p
+}
+
+// animateArgs records the args currently displayed so a change to args is animated from them
+func (c *BaseComponent) animateArgs(current []any) {
+	a := &c.anim
+	if a.values.Duration > 0 && a.drawn {
+		a.argsFrom = c.currentArgs(current)
+		a.argsStart = a.now
+	}
+}
+
+// currentArgs returns args with numeric values interpolated if they are being animated
+func (c *BaseComponent) currentArgs(args []any) []any {
+	a := &c.anim
+	if !a.values.active(a.argsStart, a.now) || len(a.argsFrom) != len(args) {
+		return args
+	}
+	p := a.values.progress(a.argsStart, a.now)
+	r := make([]any, len(args))
+	for i, to := range args {
+		r[i] = interpolate(a.argsFrom[i], to, p)
+	}
+	return r
+}
+
+// interpolate returns a value between numeric values, of the same type as to. Other values return to.
+func interpolate(from, to any, p float64) any {
+	fv, tv := reflect.ValueOf(from), reflect.ValueOf(to)
+	f, fok := toFloat(fv)
+	t, tok := toFloat(tv)
+	if !fok || !tok {
+		return to
+	}
+
+	v := f + (t-f)*p
+	r := reflect.New(tv.Type()).Elem()
+	switch tv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		r.SetFloat(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		r.SetInt(int64(math.Round(v)))
+	default:
+		r.SetUint(uint64(math.Round(v)))
+	}
+	return r.Interface()
+}
+
+func toFloat(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
+// animateMove starts a move animation when the position or size of the component changes
+func (c *BaseComponent) animateMove(to image.Rectangle) {
+	a := &c.anim
+	if a.move.Duration <= 0 || !a.drawn || to == c.bounds {
+		return
+	}
+	// Layout may set the bounds several times within a frame so animate from where it was last drawn
+	if !a.moveStart.Equal(a.now) {
+		a.moveFrom = c.moveBounds()
+		a.moveStart = a.now
+	}
+}
+
+// moveBounds returns the bounds the component is drawn at whilst moving
+func (c *BaseComponent) moveBounds() image.Rectangle {
+	a := &c.anim
+	if !a.move.active(a.moveStart, a.now) {
+		return c.bounds
+	}
+	p := a.move.progress(a.moveStart, a.now)
+	lerp := func(from, to int) int {
+		return from + int(math.Round(float64(to-from)*p))
+	}
+	f, t := a.moveFrom, c.bounds
+	return image.Rect(lerp(f.Min.X, t.Min.X), lerp(f.Min.Y, t.Min.Y), lerp(f.Max.X, t.Max.X), lerp(f.Max.Y, t.Max.Y))
+}
+
+// drawState returns the opacity and offset to draw the component with at the current time
+func (c *BaseComponent) drawState() (float64, image.Point) {
+	a := &c.anim
+	offset := c.moveBounds().Min.Sub(c.bounds.Min)
+
+	v := a.visibility()
+	if v <= 0 || v >= 1 {
+		return v, offset
+	}
+
+	// Fade when no effect was given
+	opacity := 1.0
+	if a.effect == 0 || a.effect&Fade != 0 {
+		opacity = v
+	}
+	d := 1 - v
+	w, h := float64(c.bounds.Dx()), float64(c.bounds.Dy())
+	switch {
+	case a.effect&SlideLeft != 0:
+		offset.X -= int(w * d)
+	case a.effect&SlideRight != 0:
+		offset.X += int(w * d)
+	case a.effect&SlideUp != 0:
+		offset.Y += int(h * d)
+	case a.effect&SlideDown != 0:
+		offset.Y -= int(h * d)
+	}
+	return opacity, offset
+}
+
+// animating returns true if any transition is running
+func (a *animation) animating() bool {
+	return a.values.active(a.argsStart, a.now) ||
+		a.show.active(a.showStart, a.now) ||
+		a.move.active(a.moveStart, a.now)
+}
+
+// SetTime sets the time of the frame being rendered on a Component and all of its children,
+// driving any animations and calling SetTime on those implementing TimeSetter.
+// This is usually the Time of the Frame being rendered.
+func SetTime(c Component, t time.Time) {
+	if b := baseOf(c); b != nil {
+		// Include the frame an animation ends on, so a hidden component then leaves the layout
+		animating := b.anim.animating()
+		b.anim.now = t
+		if animating || b.anim.animating() {
+			b.updateRequired = true
+		}
+	}
+	if ts, ok := c.(TimeSetter); ok {
+		ts.SetTime(t)
+	}
+	if p, ok := c.(interface{ children() []Component }); ok {
+		for _, child := range p.children() {
+			SetTime(child, t)
+		}
+	}
+}
+
+// overflow is the space around a component included when it is drawn off-screen,
+// so that text effects or strokes extending outside its bounds are not clipped
+const overflow = 32
+
+// drawWithOpacity draws bounds using paint into an off-screen image, then composites it into gc with opacity
+func drawWithOpacity(gc draw2d2.GraphicContext, bounds image.Rectangle, opacity float64, paint Painter) {
+	ogc, img, origin := draw2d2.Offscreen(gc, bounds, overflow)
+	if img == nil {
+		return
+	}
+
+	paint(ogc)
+
+	draw2d2.ScaleAlpha(img, opacity)
+
+	gc.Save()
+	defer gc.Restore()
+	gc.SetMatrixTransform(draw2d.NewTranslationMatrix(float64(origin.X), float64(origin.Y)))
+	gc.DrawImage(img)
+}
@@ -0,0 +1,151 @@
+package layout
+
+import (
+	"image"
+	"testing"
+	"time"
+)
+
+func TestAnimation_Values(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := testContext()
+
+	// Strings are not animated so change immediately
+	v := NewText("%d %.1f %s", 0, 0.0, "a")
+	v.AnimateValues(Transition{Duration: time.Second})
+	SetTime(v, start)
+	v.Draw(ctx)
+
+	v.Args(100, 10.0, "b")
+	tests := []struct {
+		at   time.Duration
+		want string
+	}{
+		{at: 0, want: "0 0.0 b"},
+		{at: 250 * time.Millisecond, want: "25 2.5 b"},
+		{at: 500 * time.Millisecond, want: "50 5.0 b"},
+		{at: time.Second, want: "100 10.0 b"},
+	}
+	for _, tt := range tests {
+		SetTime(v, start.Add(tt.at))
+		if got := v.String(); got != tt.want {
+			t.Errorf("%v got %q want %q", tt.at, got, tt.want)
+		}
+	}
+
+	// Changing during an animation continues from the displayed value
+	SetTime(v, start.Add(2*time.Second))
+	v.Args(200, 10.0, "b")
+	SetTime(v, start.Add(2500*time.Millisecond))
+	v.Args(0, 10.0, "b")
+	SetTime(v, start.Add(3*time.Second))
+	if got, want := v.String(), "75 10.0 b"; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+}
+
+func TestAnimation_Show(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := testContext()
+
+	b := newBox(10, 10)
+	b.SetBounds(image.Rect(0, 0, 100, 20))
+	b.AnimateShow(Fade|SlideUp, Transition{Duration: time.Second, Easing: EaseInOut})
+
+	// Hidden before being drawn takes effect immediately
+	b.Hide()
+	SetTime(b, start)
+	b.Draw(ctx)
+	if o, _ := b.drawState(); o != 0 {
+		t.Errorf("got opacity %f want 0", o)
+	}
+
+	b.Show()
+	SetTime(b, start.Add(500*time.Millisecond))
+	if !b.IsUpdateRequired() {
+		t.Error("update not required whilst animating")
+	}
+	if o, off := b.drawState(); o != 0.5 || off != image.Pt(0, 10) {
+		t.Errorf("got %f %v want 0.5 (0,10)", o, off)
+	}
+
+	SetTime(b, start.Add(time.Second))
+	if o, off := b.drawState(); o != 1 || off != (image.Point{}) {
+		t.Errorf("got %f %v want 1 (0,0)", o, off)
+	}
+}
+
+func TestAnimation_Move(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := testContext()
+
+	b := newBox(10, 10)
+	b.SetBounds(image.Rect(0, 0, 10, 10))
+	b.AnimateMove(Transition{Duration: time.Second})
+	SetTime(b, start)
+	b.Draw(ctx)
+
+	// Several layout passes within a frame animate from where it was drawn
+	b.SetBounds(image.Rect(50, 0, 60, 10))
+	b.SetBounds(image.Rect(100, 0, 110, 10))
+	SetTime(b, start.Add(250*time.Millisecond))
+	if _, off := b.drawState(); off != image.Pt(-75, 0) {
+		t.Errorf("got %v want (-75,0)", off)
+	}
+
+	// Size changes are animated too
+	SetTime(b, start.Add(2*time.Second))
+	b.Draw(ctx)
+	b.SetBounds(image.Rect(100, 0, 150, 30))
+	SetTime(b, start.Add(2500*time.Millisecond))
+	if got, want := b.moveBounds(), image.Rect(100, 0, 130, 20); got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestAnimation_HideInLayout(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := testContext()
+
+	a, b := newBox(10, 10), newBox(10, 10)
+	a.AnimateShow(Fade, Transition{Duration: time.Second})
+	row := FlexRow()
+	row.Add(a).Add(b)
+	row.SetBounds(image.Rect(0, 0, 100, 10))
+	SetTime(row, start)
+	LayoutTree(ctx, row)
+	row.Draw(ctx)
+
+	// A hidden component keeps its place until it has faded out
+	a.Hide()
+	SetTime(row, start.Add(500*time.Millisecond))
+	LayoutTree(ctx, row)
+	if got := b.Bounds().Min.X; got != 10 {
+		t.Errorf("moved to %d whilst fading", got)
+	}
+
+	SetTime(row, start.Add(time.Second))
+	if !row.IsUpdateRequired() {
+		t.Error("update not required once faded out")
+	}
+	LayoutTree(ctx, row)
+	if got := b.Bounds().Min.X; got != 0 {
+		t.Errorf("at %d once faded out", got)
+	}
+}
+
+func TestSetTime_TimeSetter(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewTrackMap()
+	g := NewGridContainer()
+	g.Add(m)
+
+	// Components implementing TimeSetter within a container follow the time
+	SetTime(g, start)
+	if !m.time.Equal(start) {
+		t.Errorf("got %v want %v", m.time, start)
+	}
+	if !m.IsUpdateRequired() {
+		t.Error("update not required after the time changed")
+	}
+}
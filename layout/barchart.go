@@ -0,0 +1,98 @@
+package layout
+
+import (
+	"github.com/llgcode/draw2d/draw2dkit"
+	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"math"
+)
+
+// BarChart plots a set of values as vertical bars with optional labels below each bar
+type BarChart struct {
+	chart
+	values []float64
+	labels []string
+	gap    float64 // Gap between bars as a proportion of the bar width
+}
+
+func NewBarChart() *BarChart {
+	c := &BarChart{
+		chart: newChart("BarChart", 0.5),
+		gap:   0.2,
+	}
+	c.BaseComponent.painter = c.paint
+	return c
+}
+
+// SetValues sets the values of each bar
+func (c *BarChart) SetValues(v ...float64) {
+	c.values = v
+	c.updateRequired = true
+}
+
+// SetArgs implements ArgsSetter, setting the values of each bar
+func (c *BarChart) SetArgs(args ...any) {
+	var v []float64
+	for _, a := range args {
+		if f, ok := a.(float64); ok {
+			v = append(v, f)
+		}
+	}
+	c.SetValues(v...)
+}
+
+// Labels sets the labels shown below each bar
+func (c *BarChart) Labels(l ...string) {
+	c.labels = l
+	c.updateRequired = true
+}
+
+// Gap sets the gap between bars as a proportion of the bar width
+func (c *BarChart) Gap(g float64) {
+	c.gap = math.Max(0, math.Min(0.9, g))
+	c.updateRequired = true
+}
+
+func (c *BarChart) paint(gc draw2d2.GraphicContext) {
+	if len(c.values) == 0 {
+		return
+	}
+
+	// Bars always start from 0
+	lo, hi := 0.0, 0.0
+	for _, v := range c.values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+
+	w, h := c.innerSize()
+	ys := c.scale(lo, hi)
+
+	lh := 0.0
+	if len(c.labels) > 0 {
+		lh = labelHeight(gc) + c.labelSpan
+	}
+
+	px := c.maxLabelWidth(gc, ys) + c.tickSize + c.labelSpan
+	pw := w - px
+	ph := h - lh
+
+	c.valueAxis(gc, ys, px, 0, ph, pw)
+
+	bw := pw / float64(len(c.values))
+	gap := bw * c.gap / 2
+	zero := ph - ys.Pos(math.Max(ys.Min, math.Min(ys.Max, 0)), ph)
+
+	for i, v := range c.values {
+		x := px + float64(i)*bw
+		y := ph - ys.Pos(v, ph)
+		gc.BeginPath()
+		draw2dkit.Rectangle(gc, x+gap, math.Min(y, zero), x+bw-gap, math.Max(y, zero))
+		gc.Fill()
+
+		if i < len(c.labels) {
+			drawLabel(gc, c.labels[i], x+bw/2, ph+c.labelSpan+lh/2, util.CenterAlignment)
+		}
+	}
+
+	line(gc, px, zero, px+pw, zero)
+}
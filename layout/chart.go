@@ -0,0 +1,160 @@
+package layout
+
+import (
+	"fmt"
+	"github.com/llgcode/draw2d"
+	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/font"
+	"math"
+)
+
+// chart is the common base of all chart components.
+// A chart has no natural size, so it takes its width from its container and
+// its height from its aspect ratio.
+type chart struct {
+	BaseComponent
+	aspect    float64 // Height as a proportion of the width, 0 to leave the height alone
+	fixed     bool    // true if the value axis has a fixed range
+	min, max  float64 // Range of the value axis when fixed
+	ticks     int     // Approximate number of ticks on the value axis
+	format    string  // Format of tick labels
+	showGrid  bool    // true to draw grid lines
+	tickSize  float64 // Length of ticks
+	labelSpan float64 // Gap between ticks and their labels
+}
+
+func newChart(t string, aspect float64) chart {
+	return chart{
+		BaseComponent: BaseComponent{Type: t},
+		aspect:        aspect,
+		ticks:         5,
+		format:        "%v",
+		tickSize:      5,
+		labelSpan:     3,
+	}
+}
+
+// Aspect sets the height of the chart as a proportion of its width.
+// Setting 0 leaves the height as set by the container.
+func (c *chart) Aspect(a float64) {
+	c.aspect = math.Max(0, a)
+	c.updateRequired = true
+}
+
+// Range fixes the range of the value axis, disabling auto-scaling
+func (c *chart) Range(min, max float64) {
+	c.fixed, c.min, c.max = true, min, max
+	c.updateRequired = true
+}
+
+// AutoScale enables auto-scaling of the value axis. This is the default.
+func (c *chart) AutoScale() {
+	c.fixed = false
+	c.updateRequired = true
+}
+
+// Ticks sets the approximate number of ticks on the value axis
+func (c *chart) Ticks(n int) {
+	c.ticks = max(1, n)
+	c.updateRequired = true
+}
+
+// Format sets the fmt format used for tick labels
+func (c *chart) Format(f string) {
+	c.format = f
+	c.updateRequired = true
+}
+
+// Grid enables grid lines at each tick
+func (c *chart) Grid(b bool) {
+	c.showGrid = b
+	c.updateRequired = true
+}
+
+func (c *chart) Layout(_ draw2d.GraphicContext) bool {
+	changed := c.layoutAspect(c.aspect)
+	c.updateRequired = false
+	return changed
+}
+
+// innerSize returns the size of the chart within its insets
+func (c *chart) innerSize() (float64, float64) {
+	b := c.InsetBounds()
+	return float64(b.Dx()), float64(b.Dy())
+}
+
+// scale returns the Scale for the value axis, auto-scaling to lo..hi unless the range is fixed
+func (c *chart) scale(lo, hi float64) Scale {
+	if c.fixed {
+		s := NiceScale(c.min, c.max, c.ticks)
+		s.Min, s.Max = c.min, c.max
+		return s
+	}
+	return NiceScale(lo, hi, c.ticks)
+}
+
+func (c *chart) label(v float64) string {
+	return fmt.Sprintf(c.format, v)
+}
+
+// maxLabelWidth returns the width of the widest label on a Scale
+func (c *chart) maxLabelWidth(gc draw2d2.GraphicContext, s Scale) float64 {
+	w := 0.0
+	for _, v := range s.Ticks() {
+		l, _, r, _ := font.StringBounds(gc, c.label(v))
+		w = math.Max(w, r-l)
+	}
+	return w
+}
+
+// labelHeight returns the height of a line of text in the current font
+func labelHeight(gc draw2d2.GraphicContext) float64 {
+	_, t, _, b := font.StringBounds(gc, "0")
+	return b - t
+}
+
+// drawLabel draws a string aligned horizontally about x and vertically centered on y
+func drawLabel(gc draw2d2.GraphicContext, s string, x, y float64, a util.Alignment) {
+	l, t, r, b := font.StringBounds(gc, s)
+	w := r - l
+	switch a {
+	case util.CenterAlignment:
+		x = x - w/2
+	case util.RightAlignment:
+		x = x - w
+	}
+	font.FillStringAt(gc, s, x-l, y-(t+b)/2)
+}
+
+// line draws a single line
+func line(gc draw2d2.GraphicContext, x0, y0, x1, y1 float64) {
+	gc.BeginPath()
+	gc.MoveTo(x0, y0)
+	gc.LineTo(x1, y1)
+	gc.Stroke()
+}
+
+// valueAxis draws a vertical value axis with its labels to the left of x between y0 (top) and y1 (bottom).
+// If gridWidth > 0 and grid lines are enabled, they are drawn to the right of the axis
+func (c *chart) valueAxis(gc draw2d2.GraphicContext, s Scale, x, y0, y1, gridWidth float64) {
+	line(gc, x, y0, x, y1)
+	h := y1 - y0
+	for _, v := range s.Ticks() {
+		y := y1 - s.Pos(v, h)
+		line(gc, x-c.tickSize, y, x, y)
+		if c.showGrid && gridWidth > 0 {
+			gc.Save()
+			gc.SetLineWidth(0.5)
+			line(gc, x, y, x+gridWidth, y)
+			gc.Restore()
+		}
+		drawLabel(gc, c.label(v), x-c.tickSize-c.labelSpan, y, util.RightAlignment)
+	}
+}
+
+// compassPoint returns the coordinates of a point at a compass angle in degrees and radius from cx,cy
+func compassPoint(cx, cy, angle, r float64) (float64, float64) {
+	s, co := math.Sincos(angle * util.ToRad)
+	return cx + r*s, cy - r*co
+}
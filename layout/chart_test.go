@@ -0,0 +1,43 @@
+package layout
+
+import (
+	"image"
+	"testing"
+)
+
+func TestChart_Layout(t *testing.T) {
+	c := NewLineChart()
+	c.Aspect(0.5)
+	c.SetBounds(image.Rect(0, 0, 200, 10))
+
+	if !c.Layout(testContext()) {
+		t.Error("first layout reported no change")
+	}
+	if got, want := c.Bounds(), image.Rect(0, 0, 200, 100); got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+	// Nothing has changed so a second pass is not required
+	if c.Layout(testContext()) {
+		t.Error("second layout reported a change")
+	}
+}
+
+func TestLineChart_SetArgs(t *testing.T) {
+	c := NewSparkline()
+	c.SetBounds(image.Rect(0, 0, 100, 25))
+	c.Layout(testContext())
+
+	c.Limit(3)
+	for _, v := range []float64{1, 2, 3, 4, 5} {
+		c.Layout(testContext())
+		c.SetArgs(v)
+		if !c.IsUpdateRequired() {
+			t.Errorf("%v did not require an update", v)
+		}
+	}
+
+	// Only the latest values are kept
+	if len(c.values) != 3 || c.values[0] != 3 || c.values[2] != 5 {
+		t.Errorf("got %v", c.values)
+	}
+}
@@ -2,7 +2,8 @@ package layout
 
 import (
 	"github.com/llgcode/draw2d"
-	"github.com/llgcode/draw2d/draw2dimg"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"image"
 )
 
 // ColScaleContainer lays out it's components based on specific scaling horizontally
@@ -22,46 +23,36 @@ type colScaleContainer struct {
 	scales []float64
 }
 
+// Layout places each component in its column at the height of the tallest from the last layout,
+// laying each out once in place, then fits the height of the container to them.
 func (c *colScaleContainer) Layout(ctx draw2d.GraphicContext) bool {
-	//if !c.container.Layout(ctx) {
-	//	return false
-	//}
-
-	//fmt.Printf("\nLayout %q\t%v\n", c.Type, c.scales)
+	before := treeBounds(c)
 
 	// Get max height of this row
 	c.FitToHeight()
-	//fmt.Println("csc", c.Bounds())
 
 	bounds := c.Bounds()
-	c.BaseComponent.paint(ctx.(*draw2dimg.GraphicContext), func(gc *draw2dimg.GraphicContext) {
+	c.BaseComponent.paint(draw2d2.Wrap(ctx), func(gc draw2d2.GraphicContext) {
 		// Now update the widths of this row
 		width := float64(bounds.Dx())
 		for i, scale := range c.scales {
-			if i < len(c.components) {
+			// Hidden components are skipped, moving the following columns left
+			if i < len(c.components) && isShown(c.components[i]) {
 				comp := c.components[i]
+				cb := image.Rect(bounds.Min.X, 0, bounds.Min.X+int(width*scale), bounds.Dy())
 
-				comp.Layout(gc)
-
-				cb := comp.Bounds()
-
-				cb.Min.X = bounds.Min.X
-				cb.Min.Y = 0
-				cb.Max.X = cb.Min.X + int(width*scale)
-				cb.Max.Y = bounds.Dy()
-
+				resolveUnits(gc, comp, cb.Size())
 				comp.SetBounds(cb)
-				//fmt.Println("csc", comp.GetType(), comp.Bounds())
+				comp.Layout(gc)
 
 				// Move to next component
 				bounds.Min.X = cb.Max.X
 			}
 		}
-
-		c.container.Layout(gc)
 	})
 
 	c.FitToHeight()
+	c.updateRequired = false
 
-	return true
+	return changedSince(c, before)
 }
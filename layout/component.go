@@ -2,15 +2,16 @@ package layout
 
 import (
 	"github.com/llgcode/draw2d"
-	"github.com/llgcode/draw2d/draw2dimg"
 	"github.com/peter-mount/go-anim/graph"
 	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/unit"
 	"image"
 	"image/color"
-	"strings"
+	"time"
 )
 
-type Painter func(*draw2dimg.GraphicContext)
+type Painter func(draw2d2.GraphicContext)
 
 // Component is an entity within a frame
 type Component interface {
@@ -32,6 +33,27 @@ type Component interface {
 	LineWidth(float64)
 }
 
+// Sizer is implemented by components supporting unit-aware sizes, margins, padding and font sizes.
+// All components based on BaseComponent implement it.
+type Sizer interface {
+	Size(width, height unit.Value)
+	Margin(unit.Dimension)
+	Padding(unit.Dimension)
+	FontSize(unit.Value)
+}
+
+// ArgsSetter is implemented by components whose output is formatted from a set of arguments,
+// allowing them to be updated from bound data.
+type ArgsSetter interface {
+	SetArgs(args ...any)
+}
+
+// TimeSetter is implemented by components showing data at a point in time, like TrackMap,
+// allowing SetTime to advance them with each frame.
+type TimeSetter interface {
+	SetTime(t time.Time)
+}
+
 type BaseComponent struct {
 	Type           string
 	bounds         image.Rectangle // bounds of this container
@@ -42,9 +64,24 @@ type BaseComponent struct {
 	fill           color.Color
 	stroke         color.Color
 	lineWidth      float64
-	insetX         int // Inset on x-axis
-	insetMinY      int // Inset on y-axis at the top, can differ to insetMaxY when dealing with titles
-	insetMaxY      int // Inset on y-axis at the bottom
+	insetMinX      int               // Inset on x-axis at the left
+	insetMaxX      int               // Inset on x-axis at the right
+	insetMinY      int               // Inset on y-axis at the top, can differ to insetMaxY when dealing with titles
+	insetMaxY      int               // Inset on y-axis at the bottom
+	width, height  unit.Value        // Preferred size, zero to size to the content
+	margin         unit.Dimension    // Space around the component
+	padding        unit.Dimension    // Space within the component, replaces the insets when set
+	fontSize       unit.Value        // Font size, replaces the size in font when set
+	marginMin      image.Point       // Resolved margin at the left and top
+	marginMax      image.Point       // Resolved margin at the right and bottom
+	parentSize     image.Point       // Space available from the parent when last measured
+	viewport       image.Point       // Size of the viewport, for viewport relative units
+	effects        *util.TextEffects // Effects applied to text, nil for none
+	anim           animation         // Animated transitions
+	zIndex         int               // Order drawn within the container
+	transparency   float64           // 1 - opacity, so the zero value is opaque
+	background     draw2d2.Paint     // Paint filling the bounds before drawing, nil for none
+	fillPaint      draw2d2.Paint     // Paint replacing the colours drawn, nil for none
 }
 
 func (c *BaseComponent) SetPainter(painter Painter) {
@@ -64,7 +101,8 @@ func (c *BaseComponent) GetType() string {
 }
 
 func (c *BaseComponent) Inset(inset int) {
-	c.insetX = inset
+	c.insetMinX = inset
+	c.insetMaxX = inset
 	c.insetMinY = inset
 	c.insetMaxY = inset
 }
@@ -90,27 +128,48 @@ func (c *BaseComponent) LineWidth(f float64) {
 	c.updateRequired = true
 }
 
+// Font sets the font. The size can include a unit, e.g. "luxi 3vh mono", in which case it is set as
+// if FontSize had been called.
 func (c *BaseComponent) Font(font string) {
-	c.font = font
+	f, size, ok := splitFontSize(font)
+	c.font = f
+	if ok {
+		c.fontSize = size
+	}
 	c.updateRequired = true
 }
 
+// TextEffects returns the effects applied to text drawn by this component, creating them if required.
+// Effects are drawn outside the bounds of the component, so use Padding to reserve space for them.
+func (c *BaseComponent) TextEffects() *util.TextEffects {
+	if c.effects == nil {
+		c.effects = util.NewTextEffects()
+	}
+	c.updateRequired = true
+	return c.effects
+}
+
 func (c *BaseComponent) Bounds() image.Rectangle {
 	return c.bounds
 }
 
 func (c *BaseComponent) InsetBounds() image.Rectangle {
-	return image.Rect(c.bounds.Min.X+c.insetX, c.bounds.Min.Y+c.insetMinY, c.bounds.Max.X-c.insetX, c.bounds.Max.Y-c.insetMaxY)
+	return image.Rect(c.bounds.Min.X+c.insetMinX, c.bounds.Min.Y+c.insetMinY, c.bounds.Max.X-c.insetMaxX, c.bounds.Max.Y-c.insetMaxY)
+}
+
+// LocalBounds returns the area within the insets relative to the origin used when painting
+func (c *BaseComponent) LocalBounds() image.Rectangle {
+	return image.Rect(0, 0, c.bounds.Dx()-c.insetMinX-c.insetMaxX, c.bounds.Dy()-c.insetMinY-c.insetMaxY)
 }
 
 func (c *BaseComponent) GetInsets() (int, int, int, int) {
-	return c.insetX, c.insetMinY, c.insetX, c.insetMaxY
+	return c.insetMinX, c.insetMinY, c.insetMaxX, c.insetMaxY
 }
 
 func (c *BaseComponent) SetBounds(b image.Rectangle) {
+	c.animateMove(b)
 	c.bounds = b
 	c.updateRequired = true
-	//fmt.Printf("SetBounds %q %v\n", c.Type, c.bounds)
 }
 
 func (c *BaseComponent) Width() int {
@@ -127,20 +186,67 @@ func (c *BaseComponent) Layout(_ draw2d.GraphicContext) bool {
 	return false
 }
 
+// layoutAspect sets the height of the component from its width so the area within the insets
+// has the aspect ratio, height as a proportion of width. Returns true if the bounds changed.
+func (c *BaseComponent) layoutAspect(aspect float64) bool {
+	b := c.Bounds()
+	if aspect <= 0 || b.Dx() <= 0 {
+		return false
+	}
+	w := b.Dx() - c.insetMinX - c.insetMaxX
+	b.Max.Y = b.Min.Y + int(float64(w)*aspect) + c.insetMinY + c.insetMaxY
+	if b == c.bounds {
+		return false
+	}
+	c.SetBounds(b)
+	return true
+}
+
 func (c *BaseComponent) Draw(ctx draw2d.GraphicContext) {
-	c.paint(ctx.(*draw2dimg.GraphicContext), c.painter)
+	gc := draw2d2.Wrap(ctx)
+
+	c.anim.drawn = true
+	opacity, offset := c.drawState()
+	opacity *= 1 - c.transparency
+	if opacity <= 0 {
+		return
+	}
+
+	if offset != (image.Point{}) {
+		gc.Save()
+		defer gc.Restore()
+		gc.Translate(float64(offset.X), float64(offset.Y))
+	}
+
+	// Whilst resizing draw at the size reached so far
+	if size := c.moveBounds().Size(); size != c.bounds.Size() {
+		bounds := c.bounds
+		c.bounds.Max = bounds.Min.Add(size)
+		defer func() {
+			c.bounds = bounds
+		}()
+	}
+
+	if opacity < 1 {
+		drawWithOpacity(gc, c.bounds, opacity, func(gc draw2d2.GraphicContext) {
+			c.drawContent(gc)
+		})
+		return
+	}
+
+	c.drawContent(gc)
 }
 
-func (c *BaseComponent) paint(gc *draw2dimg.GraphicContext, painter Painter) {
+func (c *BaseComponent) paint(gc draw2d2.GraphicContext, painter Painter) {
 	if c.painter != nil {
 		gc.Save()
 		defer gc.Restore()
-		//fmt.Printf("translate %q\t%d, %d\n", c.Type, c.bounds.Min.X+c.inset, c.bounds.Min.Y+c.inset)
-		gc.Translate(float64(c.bounds.Min.X+c.insetX), float64(c.bounds.Min.Y+c.insetMinY))
+		gc.Translate(float64(c.bounds.Min.X+c.insetMinX), float64(c.bounds.Min.Y+c.insetMinY))
 
 		if c.font != "" {
 			_ = graph.SetFont(gc, c.font)
 		}
+		c.applyFontSize(gc)
 
 		if c.stroke != nil {
 			gc.SetStrokeColor(c.stroke)
@@ -159,14 +265,5 @@ func (c *BaseComponent) paint(gc *draw2dimg.GraphicContext, painter Painter) {
 }
 
 func (c *BaseComponent) Align(s string) {
-	switch strings.ToLower(strings.TrimSpace(s)) {
-	case "left":
-		c.alignment = util.LeftAlignment
-	case "right":
-		c.alignment = util.RightAlignment
-	case "center":
-		c.alignment = util.CenterAlignment
-	default:
-		c.alignment = util.LeftAlignment
-	}
+	c.alignment = util.ParseAlignment(s)
 }
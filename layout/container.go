@@ -2,7 +2,7 @@ package layout
 
 import (
 	"github.com/llgcode/draw2d"
-	"github.com/llgcode/draw2d/draw2dimg"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
 )
 
 type Container interface {
@@ -19,7 +19,9 @@ type container struct {
 func (c *container) Width() int {
 	w := 0
 	for _, component := range c.components {
-		w += component.Width()
+		if isShown(component) {
+			w += component.Width()
+		}
 	}
 	return w
 }
@@ -27,17 +29,36 @@ func (c *container) Width() int {
 func (c *container) Height() int {
 	h := 0
 	for _, component := range c.components {
-		h += component.Height()
+		if isShown(component) {
+			h += component.Height()
+		}
 	}
 	return h
 }
 
+// IsUpdateRequired returns true if the container or any of its components require an update
+func (c *container) IsUpdateRequired() bool {
+	if c.updateRequired {
+		return true
+	}
+	for _, comp := range c.components {
+		if comp.IsUpdateRequired() {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *container) Add(comp Component) Container {
 	c.components = append(c.components, comp)
 	c.updateRequired = true
 	return c
 }
 
+func (c *container) children() []Component {
+	return c.components
+}
+
 func (c *container) IsEmpty() bool {
 	return len(c.components) == 0
 }
@@ -52,8 +73,8 @@ func (c *container) Layout(ctx draw2d.GraphicContext) bool {
 	return update
 }
 
-func (c *container) paint(gc *draw2dimg.GraphicContext) {
-	for _, comp := range c.components {
+func (c *container) paint(gc draw2d2.GraphicContext) {
+	for _, comp := range c.drawOrder() {
 		comp.Draw(gc)
 	}
 }
@@ -61,6 +82,9 @@ func (c *container) paint(gc *draw2dimg.GraphicContext) {
 func (c *container) FitToHeight() int {
 	height := 0
 	for _, comp := range c.components {
+		if !isShown(comp) {
+			continue
+		}
 		cb := comp.Bounds()
 		if cb.Dy() > height {
 			height = cb.Dy()
@@ -75,6 +99,9 @@ func (c *container) FitToHeight() int {
 func (c *container) FitToWidth() int {
 	width := 0
 	for _, comp := range c.components {
+		if !isShown(comp) {
+			continue
+		}
 		cb := comp.Bounds()
 		if cb.Dx() > width {
 			width = cb.Dx()
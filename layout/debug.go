@@ -0,0 +1,136 @@
+package layout
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/llgcode/draw2d/draw2dkit"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/font"
+	"image"
+	"image/color"
+	"strings"
+)
+
+// Node describes a Component and its children, with the bounds computed by the last Layout
+type Node struct {
+	Type     string          `json:"type"`
+	Bounds   image.Rectangle `json:"bounds"`             // Bounds relative to the parent's content
+	Absolute image.Rectangle `json:"absolute"`           // Bounds within the frame
+	Content  image.Rectangle `json:"content"`            // Area within the insets, within the frame
+	Hidden   bool            `json:"hidden,omitempty"`   // true if hidden
+	Children []*Node         `json:"children,omitempty"` // Child components of a container
+}
+
+// Inspect returns the tree of components starting at c, with their computed bounds
+func Inspect(c Component) *Node {
+	return inspect(c, image.Point{})
+}
+
+func inspect(c Component, origin image.Point) *Node {
+	n := &Node{
+		Type:   c.GetType(),
+		Bounds: c.Bounds(),
+		Hidden: !isShown(c),
+	}
+	n.Absolute = n.Bounds.Add(origin)
+	n.Content = n.Absolute
+
+	if b := baseOf(c); b != nil {
+		n.Content = b.InsetBounds().Add(origin)
+	}
+
+	// Children are positioned relative to the content of their parent
+	if p, ok := c.(interface{ children() []Component }); ok {
+		for _, child := range p.children() {
+			n.Children = append(n.Children, inspect(child, n.Content.Min))
+		}
+	}
+	return n
+}
+
+// Find returns the first Node of the given type, nil if not found
+func (n *Node) Find(t string) *Node {
+	if n.Type == t {
+		return n
+	}
+	for _, c := range n.Children {
+		if r := c.Find(t); r != nil {
+			return r
+		}
+	}
+	return nil
+}
+
+// String returns the tree as indented text, one component per line
+func (n *Node) String() string {
+	var sb strings.Builder
+	n.write(&sb, 0)
+	return sb.String()
+}
+
+func (n *Node) write(sb *strings.Builder, depth int) {
+	_, _ = fmt.Fprintf(sb, "%s%s %v abs %v content %v", strings.Repeat("  ", depth), n.Type, n.Bounds, n.Absolute, n.Content)
+	if n.Hidden {
+		sb.WriteString(" hidden")
+	}
+	sb.WriteByte('\n')
+	for _, c := range n.Children {
+		c.write(sb, depth+1)
+	}
+}
+
+// Dump returns the tree of components starting at c as "text" or "json"
+func Dump(c Component, format string) (string, error) {
+	n := Inspect(c)
+	switch strings.ToLower(format) {
+	case "", "text":
+		return n.String(), nil
+	case "json":
+		b, err := json.MarshalIndent(n, "", "  ")
+		return string(b), err
+	default:
+		return "", fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// Colours used by DrawDebug
+var (
+	debugBounds  = color.RGBA{R: 255, B: 255, A: 255}
+	debugContent = color.RGBA{G: 255, B: 255, A: 255}
+)
+
+// DrawDebug draws the bounds, content area within the insets and type of c and its children
+// over the frame. Hidden components are not drawn.
+func DrawDebug(gc draw2d2.GraphicContext, c Component) {
+	gc.Save()
+	defer gc.Restore()
+	gc.SetLineWidth(1)
+	gc.SetFontSize(8)
+	drawDebug(gc, Inspect(c))
+}
+
+func drawDebug(gc draw2d2.GraphicContext, n *Node) {
+	if n.Hidden {
+		return
+	}
+
+	if n.Content != n.Absolute {
+		debugRect(gc, n.Content, debugContent)
+	}
+	debugRect(gc, n.Absolute, debugBounds)
+
+	gc.SetFillColor(debugBounds)
+	_, top, _, _ := font.StringBounds(gc, n.Type)
+	font.FillStringAt(gc, n.Type, float64(n.Absolute.Min.X)+2, float64(n.Absolute.Min.Y)+2-top)
+
+	for _, c := range n.Children {
+		drawDebug(gc, c)
+	}
+}
+
+func debugRect(gc draw2d2.GraphicContext, r image.Rectangle, c color.Color) {
+	gc.SetStrokeColor(c)
+	gc.BeginPath()
+	draw2dkit.Rectangle(gc, float64(r.Min.X)+0.5, float64(r.Min.Y)+0.5, float64(r.Max.X)-0.5, float64(r.Max.Y)-0.5)
+	gc.Stroke()
+}
@@ -0,0 +1,86 @@
+package layout
+
+import (
+	"encoding/json"
+	"image"
+	"strings"
+	"testing"
+)
+
+func debugTree() Container {
+	a, b := newBox(10, 10), newBox(20, 10)
+	b.SetType("b")
+	b.Hide()
+
+	row := FlexRow().AlignItems(AlignStart)
+	row.SetType("row")
+	row.Inset(5)
+	row.Add(a)
+	row.Add(b)
+
+	root := FixedContainer(image.Rect(0, 0, 100, 50))
+	root.SetType("root")
+	root.Add(row)
+	root.Layout(testContext())
+	return root
+}
+
+func TestInspect(t *testing.T) {
+	root := debugTree()
+	n := Inspect(root)
+
+	row := n.Find("row")
+	if row == nil {
+		t.Fatal("row not found")
+	}
+	if got, want := row.Content, image.Rect(5, 5, 95, 45); got != want {
+		t.Errorf("row content got %v want %v", got, want)
+	}
+
+	a := row.Children[0]
+	if got, want := a.Bounds, image.Rect(0, 0, 10, 10); got != want {
+		t.Errorf("a bounds got %v want %v", got, want)
+	}
+	if got, want := a.Absolute, image.Rect(5, 5, 15, 15); got != want {
+		t.Errorf("a absolute got %v want %v", got, want)
+	}
+
+	if b := n.Find("b"); b == nil || !b.Hidden {
+		t.Errorf("b got %v", b)
+	}
+}
+
+func TestDump(t *testing.T) {
+	root := debugTree()
+
+	s, err := Dump(root, "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines\n%s", len(lines), s)
+	}
+	if want := "    box (0,0)-(10,10) abs (5,5)-(15,15) content (5,5)-(15,15)"; lines[2] != want {
+		t.Errorf("got %q want %q", lines[2], want)
+	}
+	if !strings.HasSuffix(lines[3], " hidden") {
+		t.Errorf("got %q want hidden", lines[3])
+	}
+
+	s, err = Dump(root, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n Node
+	if err := json.Unmarshal([]byte(s), &n); err != nil {
+		t.Fatal(err)
+	}
+	if n.Find("row") == nil {
+		t.Errorf("row not in %s", s)
+	}
+
+	if _, err := Dump(root, "xml"); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -0,0 +1,612 @@
+package layout
+
+import (
+	"encoding/json"
+	"fmt"
+	color2 "github.com/peter-mount/go-anim/util/color"
+	"github.com/peter-mount/go-anim/util/unit"
+	"gopkg.in/yaml.v2"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Definition describes a Component declaratively so a layout can be loaded from a YAML or JSON file.
+//
+// Type is the component type, e.g. "RowContainer", "Text" or "Gauge". The remaining fields are
+// optional. Fields which do not apply to the Type, or to the container the component is within,
+// are rejected by Build.
+type Definition struct {
+	Type       string         `json:"type" yaml:"type"`                                 // Type of component
+	Name       string         `json:"name,omitempty" yaml:"name,omitempty"`             // Name used to retrieve the component
+	Title      string         `json:"title,omitempty" yaml:"title,omitempty"`           // Title of a TitledContainer
+	TitleFont  string         `json:"titleFont,omitempty" yaml:"titleFont,omitempty"`   // Font of the title of a TitledContainer or header of a Table
+	Label      string         `json:"label,omitempty" yaml:"label,omitempty"`           // Label of a Value
+	Format     string         `json:"format,omitempty" yaml:"format,omitempty"`         // Format of Text, Value or chart tick labels
+	Args       []any          `json:"args,omitempty" yaml:"args,omitempty"`             // Initial args for Format
+	LineHeight float64        `json:"lineHeight,omitempty" yaml:"lineHeight,omitempty"` // Line height of RichText
+	MaxLines   int            `json:"maxLines,omitempty" yaml:"maxLines,omitempty"`     // Maximum lines of RichText
+	Scales     []float64      `json:"scales,omitempty" yaml:"scales,omitempty"`         // Column scales of a ColScaleContainer
+	Labels     []string       `json:"labels,omitempty" yaml:"labels,omitempty"`         // Bar labels of a BarChart
+	Width      unit.Value     `json:"width,omitempty" yaml:"width,omitempty"`           // Width of the component, in pixels for a FixedContainer
+	Height     unit.Value     `json:"height,omitempty" yaml:"height,omitempty"`         // Height of the component, in pixels for a FixedContainer
+	Min        *float64       `json:"min,omitempty" yaml:"min,omitempty"`               // Minimum value of a Gauge or chart, with Max unless a Gauge
+	Max        *float64       `json:"max,omitempty" yaml:"max,omitempty"`               // Maximum value of a Gauge or chart, with Min unless a Gauge
+	Ticks      int            `json:"ticks,omitempty" yaml:"ticks,omitempty"`           // Approximate number of ticks on a chart
+	Grid       bool           `json:"grid,omitempty" yaml:"grid,omitempty"`             // Show grid lines on a chart
+	Aspect     *float64       `json:"aspect,omitempty" yaml:"aspect,omitempty"`         // Height as a proportion of the width of a chart or map
+	Src        string         `json:"src,omitempty" yaml:"src,omitempty"`               // Image file of an Image or SvgImage
+	Font       string         `json:"font,omitempty" yaml:"font,omitempty"`             // Font of the component
+	Fill       string         `json:"fill,omitempty" yaml:"fill,omitempty"`             // Fill colour
+	Stroke     string         `json:"stroke,omitempty" yaml:"stroke,omitempty"`         // Stroke colour
+	LineWidth  float64        `json:"lineWidth,omitempty" yaml:"lineWidth,omitempty"`   // Line width
+	Inset      *int           `json:"inset,omitempty" yaml:"inset,omitempty"`           // Inset of the component
+	Margin     unit.Dimension `json:"margin,omitempty" yaml:"margin,omitempty"`         // Space around the component, e.g. "1em 2%"
+	Padding    unit.Dimension `json:"padding,omitempty" yaml:"padding,omitempty"`       // Space within the component, replacing Inset
+	FontSize   unit.Value     `json:"fontSize,omitempty" yaml:"fontSize,omitempty"`     // Font size, e.g. "3vh"
+	Align      string         `json:"align,omitempty" yaml:"align,omitempty"`           // Text alignment, left, center or right, also justify for RichText
+	Gap        int            `json:"gap,omitempty" yaml:"gap,omitempty"`               // Gap between components of a flex or grid container
+	Justify    string         `json:"justify,omitempty" yaml:"justify,omitempty"`       // Main axis alignment of a flex container
+	AlignItems string         `json:"alignItems,omitempty" yaml:"alignItems,omitempty"` // Cross axis alignment of a flex container
+	Columns    string         `json:"columns,omitempty" yaml:"columns,omitempty"`       // Column tracks of a GridContainer, e.g. "1fr 2fr"
+	Rows       string         `json:"rows,omitempty" yaml:"rows,omitempty"`             // Row tracks of a GridContainer
+	Grow       float64        `json:"grow,omitempty" yaml:"grow,omitempty"`             // Flex grow factor within a flex container
+	Shrink     *float64       `json:"shrink,omitempty" yaml:"shrink,omitempty"`         // Flex shrink factor within a flex container
+	Basis      int            `json:"basis,omitempty" yaml:"basis,omitempty"`           // Flex basis within a flex container
+	AlignSelf  string         `json:"alignSelf,omitempty" yaml:"alignSelf,omitempty"`   // Cross axis alignment within a flex container
+	Column     *int           `json:"column,omitempty" yaml:"column,omitempty"`         // Column within a GridContainer, from 0, with Row
+	Row        *int           `json:"row,omitempty" yaml:"row,omitempty"`               // Row within a GridContainer, from 0, with Column
+	ColSpan    int            `json:"colSpan,omitempty" yaml:"colSpan,omitempty"`       // Columns spanned within a GridContainer
+	RowSpan    int            `json:"rowSpan,omitempty" yaml:"rowSpan,omitempty"`       // Rows spanned within a GridContainer
+	Hidden     bool           `json:"hidden,omitempty" yaml:"hidden,omitempty"`         // Initially hidden, taking no space in the layout
+	ZIndex     int            `json:"zIndex,omitempty" yaml:"zIndex,omitempty"`         // Order drawn within the container, higher over lower
+	Opacity    *float64       `json:"opacity,omitempty" yaml:"opacity,omitempty"`       // Opacity from 0 transparent to 1 opaque
+	Components []Definition   `json:"components,omitempty" yaml:"components,omitempty"` // Child components of a container
+	// Properties of a Table
+	TableColumns []TableColumnDefinition `json:"tableColumns,omitempty" yaml:"tableColumns,omitempty"` // Columns of a Table
+	TableRows    [][]any                 `json:"tableRows,omitempty" yaml:"tableRows,omitempty"`       // Initial rows of a Table
+	HeaderFill   string                  `json:"headerFill,omitempty" yaml:"headerFill,omitempty"`     // Background of the header of a Table
+	Stripe       string                  `json:"stripe,omitempty" yaml:"stripe,omitempty"`             // Background of alternate rows of a Table
+	Border       string                  `json:"border,omitempty" yaml:"border,omitempty"`             // Colour of the borders of a Table
+	// Properties of an Image
+	Fit           string `json:"fit,omitempty" yaml:"fit,omitempty"`                     // How the image is scaled, contain, cover, fill or none
+	Interpolation string `json:"interpolation,omitempty" yaml:"interpolation,omitempty"` // Scaling, e.g. "nearest", "bilinear" or "lanczos3"
+	Anchor        string `json:"anchor,omitempty" yaml:"anchor,omitempty"`               // Position when not filling the component, e.g. "top left"
+	// Gradient or pattern paints
+	Background *PaintDefinition `json:"background,omitempty" yaml:"background,omitempty"` // Fills the bounds of the component
+	FillPaint  *PaintDefinition `json:"fillPaint,omitempty" yaml:"fillPaint,omitempty"`   // Paints what the component draws, such as text
+}
+
+// TableColumnDefinition describes a column of a Table
+type TableColumnDefinition struct {
+	Title  string `json:"title,omitempty" yaml:"title,omitempty"`   // Title shown in the header
+	Width  string `json:"width,omitempty" yaml:"width,omitempty"`   // Width, e.g. "auto", "1fr", "120" or "8em"
+	Align  string `json:"align,omitempty" yaml:"align,omitempty"`   // Alignment, left, center or right
+	Format string `json:"format,omitempty" yaml:"format,omitempty"` // Format of each value, default "%v"
+}
+
+// LoadDefinition loads a Definition from a file. The format is determined from the file extension,
+// ".yaml" or ".yml" for YAML, otherwise JSON.
+//
+// Relative Src paths within the Definition are resolved against the directory of the file.
+func LoadDefinition(fileName string) (*Definition, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	format := "json"
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".yaml", ".yml":
+		format = "yaml"
+	}
+
+	d, err := ReadDefinition(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	d.resolve(filepath.Dir(fileName))
+	return d, nil
+}
+
+// ReadDefinition reads a Definition in either "json" or "yaml" format
+func ReadDefinition(r io.Reader, format string) (*Definition, error) {
+	d := &Definition{}
+	switch format {
+	case "json":
+		if err := json.NewDecoder(r).Decode(d); err != nil {
+			return nil, err
+		}
+	case "yaml":
+		if err := yaml.NewDecoder(r).Decode(d); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	return d, nil
+}
+
+// resolve makes relative Src paths relative to dir
+func (d *Definition) resolve(dir string) {
+	if d.Src != "" && !filepath.IsAbs(d.Src) {
+		d.Src = filepath.Join(dir, d.Src)
+	}
+	d.Background.resolve(dir)
+	d.FillPaint.resolve(dir)
+	for i := range d.Components {
+		d.Components[i].resolve(dir)
+	}
+}
+
+// Build creates the Component described by this Definition, including any children.
+//
+// register is called for every Component created, with its Name, before it is added to its parent.
+// This allows the caller to keep track of named components.
+func (d *Definition) Build(register func(string, Component) error) (Component, error) {
+	if err := d.checkFields(); err != nil {
+		return nil, err
+	}
+
+	c, err := d.newComponent()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Apply(c); err != nil {
+		return nil, err
+	}
+
+	if err := register(d.Name, c); err != nil {
+		return nil, err
+	}
+
+	if len(d.Components) > 0 {
+		cont, ok := c.(Container)
+		if !ok {
+			return nil, fmt.Errorf("%s %q cannot contain components", d.Type, d.Name)
+		}
+
+		if err := d.BuildChildren(cont, register); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// BuildRoot builds the Definition into root, the top level Container of a layout.
+//
+// A Definition without a Type, or of type FixedContainer, describes root itself so its properties and children
+// are applied to it. Any other Type is built as a child of root, filling it.
+func (d *Definition) BuildRoot(root Container, register func(string, Component) error) error {
+	if d.Type != "" && !strings.EqualFold(d.Type, "fixedcontainer") {
+		c, err := d.Build(register)
+		if err != nil {
+			return err
+		}
+		root.Add(c)
+		return nil
+	}
+
+	if err := d.checkType("fixedcontainer"); err != nil {
+		return err
+	}
+	if err := d.Apply(root); err != nil {
+		return err
+	}
+	return d.BuildChildren(root, register)
+}
+
+// BuildChildren builds each child Definition, adding them to a Container
+func (d *Definition) BuildChildren(cont Container, register func(string, Component) error) error {
+	for i := range d.Components {
+		child, err := d.Components[i].Build(register)
+		if err != nil {
+			return err
+		}
+		cont.Add(child)
+		if err := d.Components[i].applyItem(cont, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyItem applies the properties of a component specific to the type of container it is in
+func (d *Definition) applyItem(cont Container, c Component) error {
+	var allowed []string
+	switch cont.(type) {
+	case *FlexContainer:
+		allowed = flexItemFields
+	case *GridContainer:
+		allowed = gridItemFields
+	}
+	for _, f := range d.setFields() {
+		if (slices.Contains(flexItemFields, f) || slices.Contains(gridItemFields, f)) && !slices.Contains(allowed, f) {
+			return fmt.Errorf("%s %q: field %q does not apply within a %s", d.Type, d.Name, f, cont.GetType())
+		}
+	}
+
+	switch cont := cont.(type) {
+	case *FlexContainer:
+		item := cont.Item(c).Grow(d.Grow).Basis(d.Basis)
+		if d.Shrink != nil {
+			item.Shrink(*d.Shrink)
+		}
+		if d.AlignSelf != "" {
+			item.AlignSelf(ParseFlexAlign(d.AlignSelf))
+		}
+
+	case *GridContainer:
+		if (d.Column == nil) != (d.Row == nil) {
+			return fmt.Errorf("%s %q: column and row must be set together", d.Type, d.Name)
+		}
+		cell := cont.Cell(c).Span(d.ColSpan, d.RowSpan)
+		if d.Column != nil {
+			cell.Place(*d.Column, *d.Row)
+		}
+	}
+	return nil
+}
+
+var (
+	// commonFields apply to every type of component
+	commonFields = []string{
+		"type", "name", "font", "fill", "stroke", "lineWidth", "inset", "margin", "padding", "fontSize", "align",
+		"width", "height", "hidden", "zIndex", "opacity", "background", "fillPaint", "components",
+	}
+	// flexItemFields apply to a component within a flex container
+	flexItemFields = []string{"grow", "shrink", "basis", "alignSelf"}
+	// gridItemFields apply to a component within a GridContainer
+	gridItemFields = []string{"column", "row", "colSpan", "rowSpan"}
+	// chartFields apply to every type of chart
+	chartFields = []string{"min", "max", "ticks", "grid", "aspect", "format"}
+	// typeFields are the fields specific to each type of component, keyed by the lower case type
+	typeFields = map[string][]string{
+		"rowcontainer":      nil,
+		"colscalecontainer": {"scales"},
+		"fixedcontainer":    nil,
+		"titledcontainer":   {"title", "titleFont"},
+		"flexrow":           {"gap", "justify", "alignItems"},
+		"flexcolumn":        {"gap", "justify", "alignItems"},
+		"gridcontainer":     {"gap", "columns", "rows"},
+		"grid":              {"gap", "columns", "rows"},
+		"text":              {"format", "args"},
+		"richtext":          {"format", "args", "lineHeight", "maxLines"},
+		"value":             {"label", "format", "args"},
+		"table":             {"tableColumns", "tableRows", "headerFill", "stripe", "border", "titleFont"},
+		"image":             {"src", "fit", "interpolation", "anchor"},
+		"svgimage":          {"src"},
+		"svg":               {"src"},
+		"linechart":         chartFields,
+		"sparkline":         chartFields,
+		"barchart":          append([]string{"labels"}, chartFields...),
+		"gauge":             chartFields,
+		"windrose":          chartFields,
+		"polarplot":         chartFields,
+		"map":               {"aspect"},
+		"trackmap":          {"aspect"},
+	}
+)
+
+// setFields returns the json names of the fields which have been set
+func (d *Definition) setFields() []string {
+	v := reflect.ValueOf(d).Elem()
+	var names []string
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsZero() {
+			names = append(names, strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0])
+		}
+	}
+	return names
+}
+
+// checkFields returns an error if a field has been set which does not apply to the type of component
+func (d *Definition) checkFields() error {
+	return d.checkType(strings.ToLower(d.Type))
+}
+
+// checkType returns an error if a field has been set which does not apply to a lower case type of component
+func (d *Definition) checkType(t string) error {
+	specific, ok := typeFields[t]
+	if !ok {
+		// newComponent reports the type as unsupported
+		return nil
+	}
+	for _, f := range d.setFields() {
+		if !slices.Contains(commonFields, f) && !slices.Contains(specific, f) &&
+			!slices.Contains(flexItemFields, f) && !slices.Contains(gridItemFields, f) {
+			return fmt.Errorf("%s %q: field %q does not apply to this type", d.Type, d.Name, f)
+		}
+	}
+	return nil
+}
+
+func (d *Definition) newComponent() (Component, error) {
+	switch strings.ToLower(d.Type) {
+	case "rowcontainer":
+		return RowContainer(), nil
+
+	case "colscalecontainer":
+		return ColScaleContainer(d.Scales...), nil
+
+	case "fixedcontainer":
+		return FixedContainer(image.Rect(0, 0, d.PixelWidth(), d.PixelHeight())), nil
+
+	case "titledcontainer":
+		c := TitledContainer(d.Title).(*titledContainer)
+		if d.TitleFont != "" {
+			c.TitleFont(d.TitleFont)
+		}
+		return c, nil
+
+	case "flexrow", "flexcolumn":
+		c := FlexRow()
+		if strings.ToLower(d.Type) == "flexcolumn" {
+			c = FlexColumn()
+		}
+		c.Gap(d.Gap).Justify(ParseFlexAlign(d.Justify))
+		if d.AlignItems != "" {
+			c.AlignItems(ParseFlexAlign(d.AlignItems))
+		}
+		return c, nil
+
+	case "gridcontainer", "grid":
+		cols, err := ParseTracks(d.Columns)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := ParseTracks(d.Rows)
+		if err != nil {
+			return nil, err
+		}
+		return NewGridContainer(cols...).Rows(rows...).Gap(d.Gap, d.Gap), nil
+
+	case "text":
+		return NewText(d.Format, d.Args...), nil
+
+	case "richtext":
+		return NewRichText(d.Format, d.Args...).LineHeight(d.LineHeight).MaxLines(d.MaxLines), nil
+
+	case "value":
+		return NewValue(d.Label, d.Format, d.Args...), nil
+
+	case "table":
+		return d.newTable()
+
+	case "image":
+		c := NewImage().
+			Fit(ParseImageFit(d.Fit)).
+			Interpolation(ParseInterpolation(d.Interpolation)).
+			Anchor(d.Anchor)
+		if d.Src != "" {
+			img, err := loadImage(d.Src)
+			if err != nil {
+				return nil, err
+			}
+			c.SetImage(img)
+		}
+		return c, nil
+
+	case "svgimage", "svg":
+		c := NewSvgImage()
+		if d.Src != "" {
+			if err := c.Load(d.Src); err != nil {
+				return nil, err
+			}
+		}
+		return c, nil
+
+	case "linechart":
+		return NewLineChart(), nil
+
+	case "sparkline":
+		return NewSparkline(), nil
+
+	case "barchart":
+		c := NewBarChart()
+		c.Labels(d.Labels...)
+		return c, nil
+
+	case "gauge":
+		lo, hi := 0.0, 100.0
+		if d.Min != nil {
+			lo = *d.Min
+		}
+		if d.Max != nil {
+			hi = *d.Max
+		}
+		return NewGauge(lo, hi), nil
+
+	case "windrose":
+		return NewWindRose(), nil
+
+	case "polarplot":
+		return NewPolarPlot(), nil
+
+	case "map", "trackmap":
+		return NewTrackMap(), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported component type %q", d.Type)
+	}
+}
+
+func (d *Definition) newTable() (*Table, error) {
+	t := NewTable()
+	for _, cd := range d.TableColumns {
+		c, err := NewTableColumn(cd.Title).Align(cd.Align).Format(cd.Format).Width(cd.Width)
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %w", cd.Title, err)
+		}
+		t.AddColumn(c)
+	}
+	t.SetRows(d.TableRows)
+
+	for _, c := range []struct {
+		s   string
+		set func(color.Color) *Table
+	}{
+		{s: d.HeaderFill, set: t.HeaderFill},
+		{s: d.Stripe, set: t.Stripe},
+		{s: d.Border, set: t.Border},
+	} {
+		if c.s != "" {
+			col, err := color2.ParseColour(c.s)
+			if err != nil {
+				return nil, err
+			}
+			c.set(col)
+		}
+	}
+
+	if d.TitleFont != "" {
+		t.HeaderFont(d.TitleFont)
+	}
+	return t, nil
+}
+
+// Apply the common properties in this Definition to a Component
+func (d *Definition) Apply(c Component) error {
+	if d.Font != "" {
+		c.Font(d.Font)
+	}
+
+	if d.Fill != "" {
+		col, err := color2.ParseColour(d.Fill)
+		if err != nil {
+			return fmt.Errorf("fill %q: %w", d.Fill, err)
+		}
+		c.Fill(col)
+	}
+
+	if d.Stroke != "" {
+		col, err := color2.ParseColour(d.Stroke)
+		if err != nil {
+			return fmt.Errorf("stroke %q: %w", d.Stroke, err)
+		}
+		c.Stroke(col)
+	}
+
+	if d.LineWidth > 0 {
+		c.LineWidth(d.LineWidth)
+	}
+
+	if d.Inset != nil {
+		c.Inset(*d.Inset)
+	}
+
+	if d.Align != "" {
+		c.Align(d.Align)
+	}
+
+	if s, ok := c.(Sizer); ok {
+		if !d.Margin.IsZero() {
+			s.Margin(d.Margin)
+		}
+
+		if !d.Padding.IsZero() {
+			s.Padding(d.Padding)
+		}
+
+		if !d.FontSize.IsZero() {
+			s.FontSize(d.FontSize)
+		}
+
+		// A FixedContainer already has its size
+		if _, fixed := c.(*fixedContainer); !fixed && !(d.Width.IsZero() && d.Height.IsZero()) {
+			s.Size(d.Width, d.Height)
+		}
+	} else if !(d.Margin.IsZero() && d.Padding.IsZero() && d.FontSize.IsZero() && d.Width.IsZero() && d.Height.IsZero()) {
+		return fmt.Errorf("%s does not support sizes, margins or padding", d.Type)
+	}
+
+	// Properties common to charts
+	if ch, ok := c.(interface{ Ticks(int) }); ok && d.Ticks > 0 {
+		ch.Ticks(d.Ticks)
+	}
+	if ch, ok := c.(interface{ Grid(bool) }); ok && d.Grid {
+		ch.Grid(d.Grid)
+	}
+	if ch, ok := c.(interface{ Aspect(float64) }); ok && d.Aspect != nil {
+		ch.Aspect(*d.Aspect)
+	}
+	if ch, ok := c.(interface{ Range(float64, float64) }); ok {
+		switch {
+		case d.Min != nil && d.Max != nil:
+			ch.Range(*d.Min, *d.Max)
+		case d.Min != nil || d.Max != nil:
+			// A Gauge has already defaulted the other when created
+			if _, gauge := c.(*Gauge); !gauge {
+				return fmt.Errorf("%s %q: min and max must be set together", d.Type, d.Name)
+			}
+		}
+	}
+	if ch, ok := c.(interface{ Format(string) }); ok && d.Format != "" {
+		ch.Format(d.Format)
+	}
+
+	if b := baseOf(c); b != nil {
+		if d.Hidden {
+			b.Hide()
+		}
+		if d.ZIndex != 0 {
+			b.ZIndex(d.ZIndex)
+		}
+		if d.Opacity != nil {
+			b.Opacity(*d.Opacity)
+		}
+		if d.Background != nil {
+			p, err := d.Background.Paint()
+			if err != nil {
+				return fmt.Errorf("background: %w", err)
+			}
+			b.Background(p)
+		}
+		if d.FillPaint != nil {
+			p, err := d.FillPaint.Paint()
+			if err != nil {
+				return fmt.Errorf("fillPaint: %w", err)
+			}
+			b.FillPaint(p)
+		}
+	}
+
+	return nil
+}
+
+// PixelWidth returns Width in pixels, 0 if it is relative to the layout
+func (d *Definition) PixelWidth() int {
+	if d.Width.U.IsRelative() {
+		return 0
+	}
+	return int(d.Width.Pixels(nil))
+}
+
+// PixelHeight returns Height in pixels, 0 if it is relative to the layout
+func (d *Definition) PixelHeight() int {
+	if d.Height.U.IsRelative() {
+		return 0
+	}
+	return int(d.Height.Pixels(nil))
+}
+
+func loadImage(fileName string) (image.Image, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	return img, err
+}
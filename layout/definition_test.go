@@ -0,0 +1,132 @@
+package layout
+
+import (
+	"image"
+	"strings"
+	"testing"
+)
+
+const testYaml = `
+width: 1920
+height: 1080
+font: luxi 20
+components:
+  - type: RowContainer
+    inset: 5
+    components:
+      - type: TitledContainer
+        title: Weather
+        components:
+          - type: Value
+            name: temp
+            label: Temperature
+            format: "%.1f"
+            args: [12.5]
+          - type: Gauge
+            name: wind
+            min: 0
+            max: 50
+      - type: Text
+        name: title
+        format: Hello
+        align: center
+        fill: "#ffffff"
+`
+
+const testJson = `{
+  "type": "ColScaleContainer",
+  "scales": [0.5, 0.5],
+  "components": [
+    {"type": "Text", "name": "left", "format": "L"},
+    {"type": "LineChart", "name": "right", "ticks": 4, "grid": true}
+  ]
+}`
+
+func TestDefinition_Build(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		src    string
+		want   []string
+	}{
+		{name: "yaml", format: "yaml", src: testYaml, want: []string{"temp", "wind", "title"}},
+		{name: "json", format: "json", src: testJson, want: []string{"left", "right"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ReadDefinition(strings.NewReader(tt.src), tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := map[string]Component{}
+			register := func(n string, c Component) error {
+				if n != "" {
+					names[n] = c
+				}
+				return nil
+			}
+
+			root := FixedContainer(image.Rect(0, 0, 1920, 1080))
+			if err := d.BuildRoot(root, register); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(names) != len(tt.want) {
+				t.Errorf("got %d named components, want %d", len(names), len(tt.want))
+			}
+			for _, n := range tt.want {
+				if names[n] == nil {
+					t.Errorf("component %q not registered", n)
+				}
+			}
+		})
+	}
+}
+
+func TestDefinition_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{name: "unknown type", src: `{"type": "Unknown"}`},
+		{name: "not container", src: `{"type": "Text", "components": [{"type": "Text"}]}`},
+		{name: "bad colour", src: `{"type": "Text", "fill": "notAColour"}`},
+		{name: "field of other type", src: `{"type": "Text", "label": "Temperature"}`},
+		{name: "chart field", src: `{"type": "Image", "ticks": 5}`},
+		{name: "flex item in row", src: `{"type": "RowContainer", "components": [{"type": "Text", "grow": 1}]}`},
+		{name: "grid item in flex", src: `{"type": "FlexRow", "components": [{"type": "Text", "colSpan": 2}]}`},
+		{name: "column without row", src: `{"type": "Grid", "components": [{"type": "Text", "column": 1}]}`},
+		{name: "min without max", src: `{"type": "LineChart", "min": 0}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := ReadDefinition(strings.NewReader(tt.src), "json")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := d.Build(func(string, Component) error { return nil }); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestDefinition_BuildRoot(t *testing.T) {
+	// The root is validated like any other component
+	for _, src := range []string{
+		`{"label": "Temperature"}`,
+		`{"type": "FixedContainer", "ticks": 5}`,
+		`{"type": "Text", "label": "Temperature"}`,
+		`{"type": "Unknown"}`,
+	} {
+		d, err := ReadDefinition(strings.NewReader(src), "json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		root := FixedContainer(image.Rect(0, 0, 100, 100))
+		if err := d.BuildRoot(root, func(string, Component) error { return nil }); err == nil {
+			t.Errorf("%s expected error", src)
+		}
+	}
+}
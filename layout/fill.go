@@ -0,0 +1,123 @@
+package layout
+
+import (
+	"fmt"
+	color2 "github.com/peter-mount/go-anim/util/color"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Background fills the bounds of the component, including any insets, with p before drawing it.
+// p uses coordinates relative to the top left of the component. nil removes the background.
+func (c *BaseComponent) Background(p draw2d2.Paint) {
+	c.background = p
+}
+
+// FillPaint paints what the component draws, such as text, with p instead of its colours.
+// p uses coordinates relative to the top left of the area within the insets. nil removes the paint.
+func (c *BaseComponent) FillPaint(p draw2d2.Paint) {
+	c.fillPaint = p
+}
+
+// drawContent draws the background, then the component with any fill paint
+func (c *BaseComponent) drawContent(gc draw2d2.GraphicContext) {
+	if c.painter == nil {
+		return
+	}
+
+	if c.background != nil {
+		gc.Save()
+		gc.Translate(float64(c.bounds.Min.X), float64(c.bounds.Min.Y))
+		gc.BeginPath()
+		draw2d2.Rectangle(gc, 0, 0, float64(c.bounds.Dx()), float64(c.bounds.Dy()))
+		draw2d2.FillPaint(gc, c.background)
+		gc.Restore()
+	}
+
+	if c.fillPaint == nil {
+		c.paint(gc, c.painter)
+		return
+	}
+
+	c.paint(gc, func(gc draw2d2.GraphicContext) {
+		draw2d2.DrawPaint(gc, c.LocalBounds().Inset(-overflow), c.fillPaint, c.painter)
+	})
+}
+
+// PaintDefinition describes a gradient or pattern used as a Paint
+type PaintDefinition struct {
+	Type   string    `json:"type" yaml:"type"`                         // "linear", "radial", "conic" or "pattern"
+	Stops  []string  `json:"stops,omitempty" yaml:"stops,omitempty"`   // Colour stops of a gradient, e.g. "#ff0000 0.5"
+	Spread string    `json:"spread,omitempty" yaml:"spread,omitempty"` // Spread of a gradient, pad, repeat or reflect
+	From   []float64 `json:"from,omitempty" yaml:"from,omitempty"`     // Start of a linear gradient, center of others, offset of a pattern
+	To     []float64 `json:"to,omitempty" yaml:"to,omitempty"`         // End of a linear gradient
+	Radius float64   `json:"radius,omitempty" yaml:"radius,omitempty"` // Radius of a radial gradient
+	Angle  float64   `json:"angle,omitempty" yaml:"angle,omitempty"`   // Start angle in degrees of a conic gradient
+	Src    string    `json:"src,omitempty" yaml:"src,omitempty"`       // Image file of a pattern
+	Repeat bool      `json:"repeat,omitempty" yaml:"repeat,omitempty"` // Tile a pattern
+}
+
+// resolve makes a relative Src path relative to dir
+func (d *PaintDefinition) resolve(dir string) {
+	if d != nil && d.Src != "" && !filepath.IsAbs(d.Src) {
+		d.Src = filepath.Join(dir, d.Src)
+	}
+}
+
+// Paint creates the Paint described by this PaintDefinition
+func (d *PaintDefinition) Paint() (draw2d2.Paint, error) {
+	point := func(v []float64) (float64, float64) {
+		if len(v) < 2 {
+			return 0, 0
+		}
+		return v[0], v[1]
+	}
+	x1, y1 := point(d.From)
+	x2, y2 := point(d.To)
+
+	if strings.ToLower(d.Type) == "pattern" {
+		img, err := loadImage(d.Src)
+		if err != nil {
+			return nil, err
+		}
+		return draw2d2.NewPattern(img, d.Repeat).Offset(x1, y1), nil
+	}
+
+	g := draw2d2.NewGradient().Spread(draw2d2.ParseSpread(d.Spread))
+	for i, s := range d.Stops {
+		f := strings.Fields(s)
+		if len(f) == 0 {
+			continue
+		}
+		col, err := color2.ParseColour(f[0])
+		if err != nil {
+			return nil, fmt.Errorf("stop %q: %w", s, err)
+		}
+
+		// Without an offset stops are spaced evenly
+		offset := 0.0
+		if len(d.Stops) > 1 {
+			offset = float64(i) / float64(len(d.Stops)-1)
+		}
+		if len(f) > 1 {
+			offset, err = strconv.ParseFloat(f[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("stop %q: %w", s, err)
+			}
+		}
+		g.Stop(offset, col)
+	}
+
+	switch strings.ToLower(d.Type) {
+	case "linear":
+		return g.Linear(x1, y1, x2, y2), nil
+	case "radial":
+		return g.Radial(x1, y1, d.Radius), nil
+	case "conic":
+		return g.Conic(x1, y1, d.Angle), nil
+	default:
+		return nil, fmt.Errorf("unsupported paint type %q", d.Type)
+	}
+}
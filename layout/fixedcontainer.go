@@ -28,15 +28,32 @@ func (c *fixedContainer) forceBounds() {
 }
 
 func (c *fixedContainer) Layout(ctx draw2d.GraphicContext) bool {
-	//fmt.Println(c.GetType(), c.Bounds())
+	before := treeBounds(c)
+
+	// The outermost FixedContainer defines the viewport for viewport relative units
+	viewport := c.viewport
+	if viewport == (image.Point{}) {
+		viewport = c.bounds.Size()
+	}
+	setViewport(c, viewport)
+
 	if c.updateRequired {
 		c.forceBounds()
 	}
-
-	if !c.container.Layout(ctx) {
-		return false
+	for _, comp := range c.components {
+		resolveUnits(ctx, comp, c.bounds.Size())
 	}
 
 	c.container.Layout(ctx)
-	return true
+	return changedSince(c, before)
+}
+
+// Measure returns the size of the container, which is fixed
+func (c *fixedContainer) Measure(_ draw2d.GraphicContext, _ image.Point) image.Point {
+	return c.bounds.Size()
+}
+
+// Arrange lays out the components within the container, whose bounds are fixed
+func (c *fixedContainer) Arrange(ctx draw2d.GraphicContext, _ image.Rectangle) {
+	c.Layout(ctx)
 }
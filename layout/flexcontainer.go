@@ -0,0 +1,294 @@
+package layout
+
+import (
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"image"
+)
+
+// FlexContainer lays out its components in a single row or column, like a CSS flexbox.
+//
+// Each component has a basis, its size along the main axis before any free space is distributed,
+// defaulting to its measured size. Free space is then shared between components by their grow factor,
+// or removed by their shrink factor if they overflow.
+type FlexContainer struct {
+	container
+	column  bool        // true to lay out vertically
+	gap     int         // Gap between components
+	justify FlexAlign   // Alignment along the main axis
+	align   FlexAlign   // Alignment along the cross axis
+	items   []*FlexItem // Flex properties of each component
+}
+
+// FlexItem holds the flex properties of a component within a FlexContainer
+type FlexItem struct {
+	grow      float64
+	shrink    float64
+	basis     int
+	alignSelf *FlexAlign
+}
+
+// Grow sets the proportion of free space this component receives, default 0
+func (i *FlexItem) Grow(g float64) *FlexItem {
+	i.grow = g
+	return i
+}
+
+// Shrink sets the proportion of overflow this component absorbs, relative to its basis. Default 1
+func (i *FlexItem) Shrink(s float64) *FlexItem {
+	i.shrink = s
+	return i
+}
+
+// Basis sets the size of this component along the main axis. 0, the default, uses its measured size
+func (i *FlexItem) Basis(b int) *FlexItem {
+	i.basis = b
+	return i
+}
+
+// AlignSelf overrides the cross-axis alignment of the container for this component
+func (i *FlexItem) AlignSelf(a FlexAlign) *FlexItem {
+	i.alignSelf = &a
+	return i
+}
+
+// FlexRow creates a FlexContainer which lays out its components horizontally
+func FlexRow() *FlexContainer {
+	return newFlexContainer("FlexRow", false)
+}
+
+// FlexColumn creates a FlexContainer which lays out its components vertically
+func FlexColumn() *FlexContainer {
+	return newFlexContainer("FlexColumn", true)
+}
+
+func newFlexContainer(t string, column bool) *FlexContainer {
+	c := &FlexContainer{
+		container: container{
+			BaseComponent: BaseComponent{Type: t},
+		},
+		column: column,
+		align:  AlignStretch,
+	}
+	c.BaseComponent.painter = c.paint
+	return c
+}
+
+func (c *FlexContainer) Add(comp Component) Container {
+	c.container.Add(comp)
+	c.items = append(c.items, &FlexItem{shrink: 1})
+	return c
+}
+
+// Item returns the FlexItem of a component within this container, nil if it is not present
+func (c *FlexContainer) Item(comp Component) *FlexItem {
+	for i, e := range c.components {
+		if e == comp {
+			return c.items[i]
+		}
+	}
+	return nil
+}
+
+// Gap sets the space between components
+func (c *FlexContainer) Gap(gap int) *FlexContainer {
+	c.gap = gap
+	c.updateRequired = true
+	return c
+}
+
+// Justify sets how components are positioned along the main axis
+func (c *FlexContainer) Justify(a FlexAlign) *FlexContainer {
+	c.justify = a
+	c.updateRequired = true
+	return c
+}
+
+// AlignItems sets how components are positioned along the cross axis, default AlignStretch
+func (c *FlexContainer) AlignItems(a FlexAlign) *FlexContainer {
+	c.align = a
+	c.updateRequired = true
+	return c
+}
+
+// axes splits a point into its main and cross axis components
+func (c *FlexContainer) axes(p image.Point) (int, int) {
+	if c.column {
+		return p.Y, p.X
+	}
+	return p.X, p.Y
+}
+
+// point creates a point from its main and cross axis components
+func (c *FlexContainer) point(main, cross int) image.Point {
+	if c.column {
+		return image.Pt(cross, main)
+	}
+	return image.Pt(main, cross)
+}
+
+func (c *FlexContainer) Measure(ctx draw2d.GraphicContext, available image.Point) image.Point {
+	var size image.Point
+	c.BaseComponent.paint(draw2d2.Wrap(ctx), func(gc draw2d2.GraphicContext) {
+		size, _ = c.plan(gc, c.contentSize(available))
+	})
+	return c.withInsets(size)
+}
+
+func (c *FlexContainer) Arrange(ctx draw2d.GraphicContext, bounds image.Rectangle) {
+	c.BaseComponent.paint(draw2d2.Wrap(ctx), func(gc draw2d2.GraphicContext) {
+		size, rects := c.plan(gc, c.contentSize(bounds.Size()))
+		c.SetBounds(resolveBounds(bounds, c.withInsets(size)))
+		for i, comp := range c.components {
+			if isShown(comp) {
+				Arrange(gc, comp, rects[i])
+			}
+		}
+	})
+	c.updateRequired = false
+}
+
+func (c *FlexContainer) Layout(ctx draw2d.GraphicContext) bool {
+	before := treeBounds(c)
+	c.Arrange(ctx, c.Bounds())
+	return changedSince(c, before)
+}
+
+// plan calculates the bounds of each component within the available content space,
+// returning the size of the content and the bounds relative to the content origin.
+// Hidden components take no space, once they have finished disappearing, and have empty bounds.
+func (c *FlexContainer) plan(ctx draw2d.GraphicContext, available image.Point) (image.Point, []image.Rectangle) {
+	shown := c.shown()
+	n := len(shown)
+	if n == 0 {
+		return image.Point{}, make([]image.Rectangle, len(c.components))
+	}
+
+	components := make([]Component, n)
+	items := make([]*FlexItem, n)
+	for i, j := range shown {
+		components[i], items[i] = c.components[j], c.items[j]
+	}
+
+	availMain, availCross := c.axes(available)
+	gaps := c.gap * (n - 1)
+
+	// The basis of each component along the main axis
+	sizes := make([]int, n)
+	total := gaps
+	for i, comp := range components {
+		item := items[i]
+		if item.basis > 0 {
+			sizes[i] = item.basis
+		} else {
+			sizes[i], _ = c.axes(measureIn(ctx, comp, c.point(0, availCross), available))
+		}
+		total += sizes[i]
+	}
+
+	// Distribute free space by grow, or remove overflow by shrink weighted by basis
+	if availMain > 0 && total != availMain {
+		free := availMain - total
+		weights := make([]float64, n)
+		sum := 0.0
+		for i, item := range items {
+			if free > 0 {
+				weights[i] = item.grow
+			} else {
+				weights[i] = item.shrink * float64(sizes[i])
+			}
+			sum += weights[i]
+		}
+
+		if sum > 0 {
+			remaining, last := free, -1
+			for i, w := range weights {
+				if w > 0 {
+					d := int(float64(free) * w / sum)
+					sizes[i] += d
+					remaining -= d
+					last = i
+				}
+			}
+			// Give any rounding error to the last component so the space is filled exactly
+			if last >= 0 {
+				sizes[last] += remaining
+			}
+			for i := range sizes {
+				sizes[i] = max(sizes[i], 0)
+			}
+		}
+	}
+
+	used := gaps
+	for _, s := range sizes {
+		used += s
+	}
+
+	// Size along the cross axis, now that the main axis size is known
+	aligns := make([]FlexAlign, n)
+	crossSizes := make([]int, n)
+	lineCross := availCross
+	for i, comp := range components {
+		aligns[i] = c.align
+		if a := items[i].alignSelf; a != nil {
+			aligns[i] = *a
+		}
+
+		if aligns[i] == AlignStretch && availCross > 0 {
+			crossSizes[i] = availCross
+		} else if c.column {
+			// Natural width unless stretching
+			w := 0
+			if aligns[i] == AlignStretch {
+				w = availCross
+			}
+			_, crossSizes[i] = c.axes(measureIn(ctx, comp, image.Pt(w, sizes[i]), available))
+		} else {
+			_, crossSizes[i] = c.axes(measureIn(ctx, comp, image.Pt(sizes[i], 0), available))
+		}
+
+		if availCross == 0 {
+			lineCross = max(lineCross, crossSizes[i])
+		}
+	}
+
+	// Position along the main axis
+	pos, spacing := 0, 0
+	if free := availMain - used; availMain > 0 && free > 0 {
+		switch c.justify {
+		case AlignEnd:
+			pos = free
+		case AlignCenter:
+			pos = free / 2
+		case SpaceBetween:
+			if n > 1 {
+				spacing = free / (n - 1)
+			}
+		case SpaceAround:
+			spacing = free / n
+			pos = spacing / 2
+		case SpaceEvenly:
+			spacing = free / (n + 1)
+			pos = spacing
+		}
+	}
+
+	rects := make([]image.Rectangle, len(c.components))
+	for i, j := range shown {
+		cs := crossSizes[i]
+		if aligns[i] == AlignStretch {
+			cs = lineCross
+		}
+		cs = min(cs, lineCross)
+
+		off := aligns[i].align(cs, lineCross)
+		rects[j] = image.Rectangle{
+			Min: c.point(pos, off),
+			Max: c.point(pos+sizes[i], off+cs),
+		}
+		pos += sizes[i] + c.gap + spacing
+	}
+
+	return c.point(max(availMain, used), lineCross), rects
+}
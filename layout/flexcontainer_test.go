@@ -0,0 +1,116 @@
+package layout
+
+import (
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dsvg"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"image"
+	"testing"
+)
+
+// box is a Component with a fixed preferred size
+type box struct {
+	BaseComponent
+	size image.Point
+}
+
+func newBox(w, h int) *box {
+	return &box{BaseComponent: BaseComponent{Type: "box"}, size: image.Pt(w, h)}
+}
+
+func (b *box) Measure(_ draw2d.GraphicContext, _ image.Point) image.Point {
+	return b.size
+}
+
+func (b *box) Arrange(_ draw2d.GraphicContext, bounds image.Rectangle) {
+	b.SetBounds(resolveBounds(bounds, b.size))
+}
+
+func testContext() draw2d2.GraphicContext {
+	return draw2d2.NewImageGraphicContext(image.NewRGBA(image.Rect(0, 0, 100, 100)))
+}
+
+func TestFlexContainer(t *testing.T) {
+	tests := []struct {
+		name   string
+		build  func() (*FlexContainer, []*box)
+		bounds image.Rectangle
+		want   []image.Rectangle
+	}{
+		{
+			name: "row start",
+			build: func() (*FlexContainer, []*box) {
+				a, b := newBox(10, 5), newBox(20, 8)
+				c := FlexRow().Gap(2).AlignItems(AlignStart)
+				c.Add(a)
+				c.Add(b)
+				return c, []*box{a, b}
+			},
+			bounds: image.Rect(0, 0, 100, 0),
+			want:   []image.Rectangle{image.Rect(0, 0, 10, 5), image.Rect(12, 0, 32, 8)},
+		},
+		{
+			name: "row grow stretch",
+			build: func() (*FlexContainer, []*box) {
+				a, b := newBox(10, 5), newBox(20, 8)
+				c := FlexRow()
+				c.Add(a)
+				c.Add(b)
+				c.Item(a).Grow(1)
+				c.Item(b).Grow(3)
+				return c, []*box{a, b}
+			},
+			bounds: image.Rect(0, 0, 70, 0),
+			want:   []image.Rectangle{image.Rect(0, 0, 20, 8), image.Rect(20, 0, 70, 8)},
+		},
+		{
+			name: "row shrink",
+			build: func() (*FlexContainer, []*box) {
+				a, b := newBox(40, 5), newBox(40, 5)
+				c := FlexRow()
+				c.Add(a)
+				c.Add(b)
+				c.Item(b).Shrink(0)
+				return c, []*box{a, b}
+			},
+			bounds: image.Rect(0, 0, 60, 5),
+			want:   []image.Rectangle{image.Rect(0, 0, 20, 5), image.Rect(20, 0, 60, 5)},
+		},
+		{
+			name: "column space between center",
+			build: func() (*FlexContainer, []*box) {
+				a, b := newBox(10, 10), newBox(20, 10)
+				c := FlexColumn().Justify(SpaceBetween).AlignItems(AlignCenter)
+				c.Add(a)
+				c.Add(b)
+				return c, []*box{a, b}
+			},
+			bounds: image.Rect(0, 0, 40, 50),
+			want:   []image.Rectangle{image.Rect(15, 0, 25, 10), image.Rect(10, 40, 30, 50)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, boxes := tt.build()
+			c.Arrange(testContext(), tt.bounds)
+			for i, b := range boxes {
+				if got := b.Bounds(); got != tt.want[i] {
+					t.Errorf("box %d got %v want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFlexContainer_PlainContext(t *testing.T) {
+	// A plain draw2d GraphicContext, like one drawing SVG, is laid out rather than panicking
+	a, b := newBox(10, 5), newBox(20, 8)
+	c := FlexRow().Gap(2)
+	c.Add(a)
+	c.Add(b)
+
+	Arrange(draw2dsvg.NewGraphicContext(draw2dsvg.NewSvg()), c, image.Rect(0, 0, 100, 0))
+	if got, want := b.Bounds(), image.Rect(12, 0, 32, 8); got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,106 @@
+package layout
+
+import (
+	"fmt"
+	"github.com/llgcode/draw2d/draw2dkit"
+	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"math"
+)
+
+// Gauge shows a single value as a needle on a dial
+type Gauge struct {
+	chart
+	value       float64
+	valueFormat string  // Format of the value shown below the needle
+	startAngle  float64 // Compass angle of the start of the dial
+	sweep       float64 // Angle covered by the dial
+}
+
+func NewGauge(min, max float64) *Gauge {
+	c := &Gauge{
+		chart:       newChart("Gauge", 1),
+		valueFormat: "%.1f",
+		startAngle:  225,
+		sweep:       270,
+	}
+	c.Range(min, max)
+	c.BaseComponent.painter = c.paint
+	return c
+}
+
+// SetValue sets the value shown by the Gauge
+func (c *Gauge) SetValue(v float64) {
+	c.value = v
+	c.updateRequired = true
+}
+
+// SetArgs implements ArgsSetter, setting the value from the first arg
+func (c *Gauge) SetArgs(args ...any) {
+	if len(args) > 0 {
+		if f, ok := args[0].(float64); ok {
+			c.SetValue(f)
+		}
+	}
+}
+
+// ValueFormat sets the fmt format of the value shown on the Gauge, "" to hide it
+func (c *Gauge) ValueFormat(f string) {
+	c.valueFormat = f
+	c.updateRequired = true
+}
+
+// Sweep sets the compass angle of the start of the dial and the angle it covers clockwise.
+// The default is 225, 270 which gives a dial with the gap at the bottom.
+// A wind direction would use 0, 360.
+func (c *Gauge) Sweep(start, sweep float64) {
+	c.startAngle, c.sweep = start, sweep
+	c.updateRequired = true
+}
+
+func (c *Gauge) angle(s Scale, v float64) float64 {
+	v = math.Max(s.Min, math.Min(s.Max, v))
+	return c.startAngle + c.sweep*s.Pos(v, 1)
+}
+
+func (c *Gauge) paint(gc draw2d2.GraphicContext) {
+	w, h := c.innerSize()
+	s := c.scale(c.min, c.max)
+
+	lh := labelHeight(gc)
+	cx, cy := w/2, h/2
+	r := math.Min(w, h)/2 - 1
+	lr := r - c.tickSize - c.labelSpan - lh
+
+	// dial, the angles here are draw2d angles, clockwise from the x-axis
+	gc.BeginPath()
+	start := (c.startAngle - 90) * util.ToRad
+	gc.ArcTo(cx, cy, r, r, start, c.sweep*util.ToRad)
+	gc.Stroke()
+
+	for _, v := range s.Ticks() {
+		// Don't repeat the label if the dial is a full circle
+		if c.sweep >= 360 && v == s.Max {
+			continue
+		}
+		a := c.angle(s, v)
+		x0, y0 := compassPoint(cx, cy, a, r)
+		x1, y1 := compassPoint(cx, cy, a, r-c.tickSize)
+		line(gc, x0, y0, x1, y1)
+
+		lx, ly := compassPoint(cx, cy, a, lr)
+		drawLabel(gc, c.label(v), lx, ly, util.CenterAlignment)
+	}
+
+	// Needle
+	a := c.angle(s, c.value)
+	nx, ny := compassPoint(cx, cy, a, lr-lh/2)
+	line(gc, cx, cy, nx, ny)
+	gc.BeginPath()
+	draw2dkit.Circle(gc, cx, cy, math.Max(2, c.lineWidth*2))
+	gc.Fill()
+
+	if c.valueFormat != "" {
+		drawLabel(gc, fmt.Sprintf(c.valueFormat, c.value), cx, cy+lr/2, util.CenterAlignment)
+	}
+}
@@ -0,0 +1,374 @@
+package layout
+
+import (
+	"fmt"
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"image"
+	"strconv"
+	"strings"
+)
+
+// TrackUnit is the unit of a Track's size
+type TrackUnit int
+
+const (
+	TrackAuto     TrackUnit = iota // Sized to its content
+	TrackPixels                    // Fixed size in pixels
+	TrackPercent                   // Percentage of the available space
+	TrackFraction                  // Fraction of the remaining space, like the CSS fr unit
+)
+
+// Track defines the size of a column or row within a GridContainer
+type Track struct {
+	Size float64
+	Unit TrackUnit
+}
+
+func (t Track) String() string {
+	switch t.Unit {
+	case TrackPixels:
+		return strconv.FormatFloat(t.Size, 'f', -1, 64)
+	case TrackPercent:
+		return strconv.FormatFloat(t.Size, 'f', -1, 64) + "%"
+	case TrackFraction:
+		return strconv.FormatFloat(t.Size, 'f', -1, 64) + "fr"
+	default:
+		return "auto"
+	}
+}
+
+// ParseTracks parses a space separated list of tracks, e.g. "200 1fr 2fr auto 25%"
+func ParseTracks(s string) ([]Track, error) {
+	var tracks []Track
+	for _, f := range strings.Fields(s) {
+		t, err := ParseTrack(f)
+		if err != nil {
+			return nil, err
+		}
+		tracks = append(tracks, t)
+	}
+	return tracks, nil
+}
+
+// ParseTrack parses a single track, either "auto", a size in pixels, a percentage like "25%"
+// or a fraction like "1fr"
+func ParseTrack(s string) (Track, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	t := Track{Unit: TrackPixels}
+	switch {
+	case s == "auto":
+		return Track{Unit: TrackAuto}, nil
+	case strings.HasSuffix(s, "fr"):
+		t.Unit, s = TrackFraction, strings.TrimSuffix(s, "fr")
+	case strings.HasSuffix(s, "%"):
+		t.Unit, s = TrackPercent, strings.TrimSuffix(s, "%")
+	case strings.HasSuffix(s, "px"):
+		s = strings.TrimSuffix(s, "px")
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 {
+		return Track{}, fmt.Errorf("invalid track %q", s)
+	}
+	t.Size = f
+	return t, nil
+}
+
+// GridContainer lays out its components in a grid of columns and rows, like a CSS grid.
+//
+// Components are placed in the next free cell, left to right then top to bottom, unless placed
+// explicitly with GridCell.Place. Components can span multiple columns or rows.
+// Rows beyond those defined by Rows are sized to their content.
+type GridContainer struct {
+	container
+	columns   []Track     // Column tracks
+	rows      []Track     // Row tracks
+	columnGap int         // Gap between columns
+	rowGap    int         // Gap between rows
+	cells     []*GridCell // Placement of each component
+}
+
+// GridCell holds the placement of a component within a GridContainer
+type GridCell struct {
+	column, row          int // Explicit position, -1 to place automatically
+	colSpan, rowSpan     int // Number of columns and rows spanned
+	placedCol, placedRow int // Resolved position
+}
+
+// Place sets the column and row of the component, starting from 0
+func (c *GridCell) Place(column, row int) *GridCell {
+	c.column, c.row = column, row
+	return c
+}
+
+// Span sets the number of columns and rows the component spans
+func (c *GridCell) Span(columns, rows int) *GridCell {
+	c.colSpan, c.rowSpan = max(columns, 1), max(rows, 1)
+	return c
+}
+
+// NewGridContainer creates a GridContainer with the specified columns.
+// If no columns are defined then it has a single column sized to its content.
+func NewGridContainer(columns ...Track) *GridContainer {
+	if len(columns) == 0 {
+		columns = []Track{{Unit: TrackAuto}}
+	}
+	c := &GridContainer{
+		container: container{
+			BaseComponent: BaseComponent{Type: "GridContainer"},
+		},
+		columns: columns,
+	}
+	c.BaseComponent.painter = c.paint
+	return c
+}
+
+// Rows sets the row tracks
+func (c *GridContainer) Rows(rows ...Track) *GridContainer {
+	c.rows = rows
+	c.updateRequired = true
+	return c
+}
+
+// Gap sets the space between columns and rows
+func (c *GridContainer) Gap(column, row int) *GridContainer {
+	c.columnGap, c.rowGap = column, row
+	c.updateRequired = true
+	return c
+}
+
+func (c *GridContainer) Add(comp Component) Container {
+	c.container.Add(comp)
+	c.cells = append(c.cells, &GridCell{column: -1, row: -1, colSpan: 1, rowSpan: 1})
+	return c
+}
+
+// Cell returns the GridCell of a component within this container, nil if it is not present
+func (c *GridContainer) Cell(comp Component) *GridCell {
+	for i, e := range c.components {
+		if e == comp {
+			return c.cells[i]
+		}
+	}
+	return nil
+}
+
+func (c *GridContainer) Measure(ctx draw2d.GraphicContext, available image.Point) image.Point {
+	var size image.Point
+	c.BaseComponent.paint(draw2d2.Wrap(ctx), func(gc draw2d2.GraphicContext) {
+		size, _ = c.plan(gc, c.contentSize(available))
+	})
+	return c.withInsets(size)
+}
+
+func (c *GridContainer) Arrange(ctx draw2d.GraphicContext, bounds image.Rectangle) {
+	c.BaseComponent.paint(draw2d2.Wrap(ctx), func(gc draw2d2.GraphicContext) {
+		size, rects := c.plan(gc, c.contentSize(bounds.Size()))
+		c.SetBounds(resolveBounds(bounds, c.withInsets(size)))
+		for i, comp := range c.components {
+			if isShown(comp) {
+				Arrange(gc, comp, rects[i])
+			}
+		}
+	})
+	c.updateRequired = false
+}
+
+func (c *GridContainer) Layout(ctx draw2d.GraphicContext) bool {
+	before := treeBounds(c)
+	c.Arrange(ctx, c.Bounds())
+	return changedSince(c, before)
+}
+
+// place resolves the position of each component, explicitly placed components first,
+// returning the number of rows in the grid. Hidden components are not placed.
+func (c *GridContainer) place() int {
+	cols := len(c.columns)
+	used := map[image.Point]bool{}
+	rows := len(c.rows)
+
+	mark := func(cell *GridCell, col, row int) {
+		cell.placedCol, cell.placedRow = col, row
+		for y := row; y < row+cell.rowSpan; y++ {
+			for x := col; x < col+cell.colSpan; x++ {
+				used[image.Pt(x, y)] = true
+			}
+		}
+		rows = max(rows, row+cell.rowSpan)
+	}
+
+	free := func(cell *GridCell, col, row int) bool {
+		if col+cell.colSpan > cols {
+			return false
+		}
+		for y := row; y < row+cell.rowSpan; y++ {
+			for x := col; x < col+cell.colSpan; x++ {
+				if used[image.Pt(x, y)] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	for i, cell := range c.cells {
+		if !isShown(c.components[i]) {
+			continue
+		}
+		cell.colSpan = min(cell.colSpan, cols)
+		if cell.column >= 0 && cell.row >= 0 {
+			mark(cell, min(cell.column, cols-cell.colSpan), cell.row)
+		}
+	}
+
+	pos := 0
+	for i, cell := range c.cells {
+		if !isShown(c.components[i]) {
+			continue
+		}
+		if cell.column < 0 || cell.row < 0 {
+			for !free(cell, pos%cols, pos/cols) {
+				pos++
+			}
+			mark(cell, pos%cols, pos/cols)
+		}
+	}
+
+	return rows
+}
+
+// plan calculates the bounds of each component within the available content space,
+// returning the size of the content and the bounds relative to the content origin.
+func (c *GridContainer) plan(ctx draw2d.GraphicContext, available image.Point) (image.Point, []image.Rectangle) {
+	nRows := c.place()
+
+	rowTracks := make([]Track, nRows)
+	copy(rowTracks, c.rows)
+
+	// Columns are sized using the natural width of their components
+	colSizes := resolveTracks(c.columns, available.X, c.columnGap, func(col int) int {
+		w := 0
+		for i, cell := range c.cells {
+			if cell.placedCol == col && cell.colSpan == 1 && isShown(c.components[i]) {
+				w = max(w, measureIn(ctx, c.components[i], image.Point{}, available).X)
+			}
+		}
+		return w
+	})
+
+	// Rows are sized using the height of their components at the width of the columns they span
+	widths := make([]int, len(c.cells))
+	for i, cell := range c.cells {
+		if !isShown(c.components[i]) {
+			continue
+		}
+		widths[i] = spanSize(colSizes, cell.placedCol, cell.colSpan, c.columnGap)
+	}
+
+	heights := make([]int, len(c.cells))
+	for i, comp := range c.components {
+		if !isShown(comp) {
+			continue
+		}
+		heights[i] = Measure(ctx, comp, image.Pt(widths[i], 0)).Y
+	}
+
+	rowSizes := resolveTracks(rowTracks, available.Y, c.rowGap, func(row int) int {
+		h := 0
+		for i, cell := range c.cells {
+			if cell.placedRow == row && cell.rowSpan == 1 && isShown(c.components[i]) {
+				h = max(h, heights[i])
+			}
+		}
+		return h
+	})
+
+	// Grow the last row spanned by components which do not fit
+	for i, cell := range c.cells {
+		if cell.rowSpan > 1 && isShown(c.components[i]) {
+			if d := heights[i] - spanSize(rowSizes, cell.placedRow, cell.rowSpan, c.rowGap); d > 0 {
+				rowSizes[cell.placedRow+cell.rowSpan-1] += d
+			}
+		}
+	}
+
+	rects := make([]image.Rectangle, len(c.cells))
+	for i, cell := range c.cells {
+		if !isShown(c.components[i]) {
+			continue
+		}
+		x := spanSize(colSizes, 0, cell.placedCol, c.columnGap)
+		y := spanSize(rowSizes, 0, cell.placedRow, c.rowGap)
+		if cell.placedCol > 0 {
+			x += c.columnGap
+		}
+		if cell.placedRow > 0 {
+			y += c.rowGap
+		}
+		rects[i] = image.Rect(x, y,
+			x+widths[i],
+			y+spanSize(rowSizes, cell.placedRow, cell.rowSpan, c.rowGap))
+	}
+
+	return image.Pt(
+		max(available.X, spanSize(colSizes, 0, len(colSizes), c.columnGap)),
+		max(available.Y, spanSize(rowSizes, 0, len(rowSizes), c.rowGap)),
+	), rects
+}
+
+// spanSize returns the total size of count tracks starting at start, including the gaps between them
+func spanSize(sizes []int, start, count, gap int) int {
+	s := 0
+	for i := start; i < start+count && i < len(sizes); i++ {
+		if i > start {
+			s += gap
+		}
+		s += sizes[i]
+	}
+	return s
+}
+
+// resolveTracks calculates the size of each track within the available space.
+// content returns the size of the content of a track, used for auto tracks.
+// If available is 0 then percentage and fraction tracks are sized to their content.
+func resolveTracks(tracks []Track, available, gap int, content func(int) int) []int {
+	sizes := make([]int, len(tracks))
+	remaining := available - gap*max(len(tracks)-1, 0)
+	fractions := 0.0
+
+	for i, t := range tracks {
+		switch {
+		case t.Unit == TrackPixels:
+			sizes[i] = int(t.Size)
+		case t.Unit == TrackPercent && available > 0:
+			sizes[i] = int(float64(available) * t.Size / 100)
+		case t.Unit == TrackFraction && available > 0:
+			fractions += t.Size
+			continue
+		default:
+			sizes[i] = content(i)
+		}
+		remaining -= sizes[i]
+	}
+
+	if fractions > 0 && remaining > 0 {
+		last := -1
+		for i, t := range tracks {
+			if t.Unit == TrackFraction {
+				sizes[i] = int(float64(remaining) * t.Size / fractions)
+				last = i
+			}
+		}
+		// Give any rounding error to the last fraction so the space is filled exactly
+		used := 0
+		for i, t := range tracks {
+			if t.Unit == TrackFraction {
+				used += sizes[i]
+			}
+		}
+		sizes[last] += remaining - used
+	}
+
+	return sizes
+}
@@ -0,0 +1,46 @@
+package layout
+
+import (
+	"image"
+	"testing"
+)
+
+func TestGridContainer(t *testing.T) {
+	cols, err := ParseTracks("50 1fr 2fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewGridContainer(cols...).Gap(10, 5)
+	header, a, b, side := newBox(0, 20), newBox(5, 10), newBox(5, 30), newBox(5, 50)
+	c.Add(header)
+	c.Add(side)
+	c.Add(a)
+	c.Add(b)
+	c.Cell(header).Span(3, 1)
+	c.Cell(side).Place(0, 1).Span(1, 2)
+
+	c.Arrange(testContext(), image.Rect(0, 0, 200, 0))
+
+	tests := []struct {
+		name string
+		box  *box
+		want image.Rectangle
+	}{
+		{name: "header", box: header, want: image.Rect(0, 0, 200, 20)},
+		{name: "side", box: side, want: image.Rect(0, 25, 50, 75)},
+		{name: "a", box: a, want: image.Rect(60, 25, 103, 55)},
+		{name: "b", box: b, want: image.Rect(113, 25, 200, 55)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.box.Bounds(); got != tt.want {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := c.Bounds(); got != image.Rect(0, 0, 200, 75) {
+		t.Errorf("grid got %v", got)
+	}
+}
@@ -2,14 +2,66 @@ package layout
 
 import (
 	"github.com/llgcode/draw2d"
-	"github.com/llgcode/draw2d/draw2dimg"
 	"github.com/peter-mount/go-anim/graph/resize"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
 	"image"
+	"math"
+	"strings"
 )
 
+// ImageFit defines how an image is scaled to the bounds of an Image
+type ImageFit int
+
+const (
+	FitContain ImageFit = iota // Scale to fit within the bounds, keeping the aspect ratio
+	FitCover                   // Scale to cover the bounds, keeping the aspect ratio, cropping any excess
+	FitFill                    // Stretch to fill the bounds
+	FitNone                    // Do not scale, cropping any excess
+)
+
+// ParseImageFit returns the ImageFit for "contain", "cover", "fill" or "none", defaulting to FitContain
+func ParseImageFit(s string) ImageFit {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "cover":
+		return FitCover
+	case "fill":
+		return FitFill
+	case "none":
+		return FitNone
+	default:
+		return FitContain
+	}
+}
+
+// ParseInterpolation returns the resize.InterpolationFunction for "nearest", "bilinear", "bicubic",
+// "mitchell", "lanczos2" or "lanczos3", defaulting to resize.NearestNeighbor
+func ParseInterpolation(s string) resize.InterpolationFunction {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "bilinear":
+		return resize.Bilinear
+	case "bicubic":
+		return resize.Bicubic
+	case "mitchell", "mitchellnetravali":
+		return resize.MitchellNetravali
+	case "lanczos2":
+		return resize.Lanczos2
+	case "lanczos3", "lanczos":
+		return resize.Lanczos3
+	default:
+		return resize.NearestNeighbor
+	}
+}
+
+// Image displays an image.Image, scaled to the component according to its ImageFit.
+//
+// Scaled copies are cached, so an unchanged image is only scaled again when its size changes.
 type Image struct {
 	BaseComponent
-	image image.Image
+	image   image.Image
+	fit     ImageFit
+	interp  resize.InterpolationFunction
+	anchorX float64 // Position of the image when it does not fill the width, 0 left to 1 right
+	anchorY float64 // Position of the image when it does not fill the height, 0 top to 1 bottom
 }
 
 func NewImage() *Image {
@@ -17,68 +69,146 @@ func NewImage() *Image {
 		BaseComponent: BaseComponent{
 			Type: "Image",
 		},
+		interp:  resize.NearestNeighbor,
+		anchorX: 0.5,
+		anchorY: 0.5,
 	}
 	i.painter = i.paint
 	return i
 }
 
-func (i *Image) Layout(ctx draw2d.GraphicContext) bool {
-	//if !i.updateRequired {
-	//	return false
-	//}
+// Fit sets how the image is scaled to the component
+func (i *Image) Fit(fit ImageFit) *Image {
+	i.fit = fit
+	i.updateRequired = true
+	return i
+}
 
-	i.updateRequired = false
+// Interpolation sets the function used to scale the image
+func (i *Image) Interpolation(interp resize.InterpolationFunction) *Image {
+	i.interp = interp
+	return i
+}
 
-	cb := i.Bounds()
-	if i.image != nil {
-		if cb.Dx() == 0 && cb.Dy() == 0 {
-			ib := i.image.Bounds()
-			w := min(ib.Dx(), ib.Dy())
-			cb.Max.X = cb.Min.X + w
-			cb.Max.Y = cb.Min.Y + w
-			i.SetBounds(cb)
+// Anchor sets the position of the image when it does not fill the component,
+// e.g. "center", "top left" or "bottom right". The default is "center".
+func (i *Image) Anchor(s string) *Image {
+	i.anchorX, i.anchorY = 0.5, 0.5
+	for _, f := range strings.Fields(strings.ToLower(s)) {
+		switch f {
+		case "left":
+			i.anchorX = 0
+		case "right":
+			i.anchorX = 1
+		case "top":
+			i.anchorY = 0
+		case "bottom":
+			i.anchorY = 1
 		}
+	}
+	return i
+}
 
-		img := i.image
-		ib := img.Bounds()
+func (i *Image) Layout(_ draw2d.GraphicContext) bool {
+	i.updateRequired = false
 
-		// Now use the inset bounds for the image
-		cb = i.InsetBounds()
+	if i.image == nil {
+		return false
+	}
 
-		if !cb.Eq(ib) {
-			// FIXME work out new size without creating an image then throwing it away
-			img = resize.Resize(uint(cb.Dx()), 0, img, resize.NearestNeighbor)
-			ib = img.Bounds()
-			cb.Max = cb.Min.Add(image.Pt(ib.Dx(), ib.Dy()))
-			i.SetBounds(cb)
-		}
+	// Size any unset dimension from the image, keeping the aspect ratio
+	ib := i.image.Bounds()
+	iw, ih := ib.Dx(), ib.Dy()
+	lb := i.LocalBounds()
+	w, h := lb.Dx(), lb.Dy()
+
+	switch {
+	case iw == 0 || ih == 0:
+		return false
+	case i.Width() == 0 && i.Height() == 0, i.fit == FitNone && (i.Width() == 0 || i.Height() == 0):
+		w, h = iw, ih
+	case i.Width() == 0:
+		w = int(math.Round(float64(iw*h) / float64(ih)))
+	case i.Height() == 0:
+		h = int(math.Round(float64(ih*w) / float64(iw)))
+	case i.fit == FitContain:
+		w, h = fitSize(iw, ih, w, h, FitContain)
 	}
 
+	cb := i.Bounds()
+	cb.Max = cb.Min.Add(image.Pt(w+i.insetMinX+i.insetMaxX, h+i.insetMinY+i.insetMaxY))
+	if cb.Eq(i.Bounds()) {
+		return false
+	}
+	i.SetBounds(cb)
 	return true
 }
 
-func (i *Image) paint(gc *draw2dimg.GraphicContext) {
+// fitSize returns the size an iw*ih image is scaled to within w*h
+func fitSize(iw, ih, w, h int, fit ImageFit) (int, int) {
+	switch fit {
+	case FitFill:
+		return w, h
+	case FitNone:
+		return iw, ih
+	}
+
+	sx, sy := float64(w)/float64(iw), float64(h)/float64(ih)
+	s := min(sx, sy)
+	if fit == FitCover {
+		s = max(sx, sy)
+	}
+	return int(math.Round(float64(iw) * s)), int(math.Round(float64(ih) * s))
+}
+
+func (i *Image) paint(gc draw2d2.GraphicContext) {
 	if i.image == nil {
 		return
 	}
 
-	cb := i.Bounds()
+	lb := i.LocalBounds()
+	ib := i.image.Bounds()
+	if lb.Empty() || ib.Empty() {
+		return
+	}
+
+	tw, th := fitSize(ib.Dx(), ib.Dy(), lb.Dx(), lb.Dy(), i.fit)
+	if tw <= 0 || th <= 0 {
+		return
+	}
 
-	// Resize the image to fit the component
 	img := i.image
-	ib := img.Bounds()
+	if i.fit != FitNone {
+		img = scaledImages.get(img, tw, th, i.interp)
+	}
 
-	if !cb.Eq(ib) {
-		img = resize.Resize(uint(cb.Dx()-i.insetX-i.insetX), 0, img, resize.NearestNeighbor)
-		ib = img.Bounds()
-		cb.Max = cb.Min.Add(image.Pt(ib.Dx()+(i.insetX<<1), ib.Dy()+i.insetMinY+i.insetMaxY))
-		i.SetBounds(cb)
+	// Offset within the component, then crop anything outside it
+	offset := image.Pt(
+		int(math.Round(float64(lb.Dx()-tw)*i.anchorX)),
+		int(math.Round(float64(lb.Dy()-th)*i.anchorY)),
+	)
+	sb := img.Bounds()
+	visible := lb.Sub(offset).Add(sb.Min).Intersect(sb)
+	if visible.Empty() {
+		return
+	}
+	if s, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok && !visible.Eq(sb) {
+		img = s.SubImage(visible)
 	}
 
+	// DrawImage draws using the image's own coordinates, so map its origin to the offset
+	gc.Translate(float64(offset.X-sb.Min.X), float64(offset.Y-sb.Min.Y))
 	gc.DrawImage(img)
 }
 
+// SetImage sets the image to display.
+// Cached scaled copies of img are discarded, so it can be called again after drawing into img.
 func (i *Image) SetImage(img image.Image) {
+	if img != nil {
+		scaledImages.remove(img)
+	}
 	i.image = img
 	i.updateRequired = true
 }
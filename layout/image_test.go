@@ -0,0 +1,73 @@
+package layout
+
+import (
+	"image"
+	"testing"
+)
+
+func TestFitSize(t *testing.T) {
+	tests := []struct {
+		fit  ImageFit
+		w, h int
+	}{
+		{FitContain, 100, 50},
+		{FitCover, 200, 100},
+		{FitFill, 100, 100},
+		{FitNone, 40, 20},
+	}
+	for _, test := range tests {
+		if w, h := fitSize(40, 20, 100, 100, test.fit); w != test.w || h != test.h {
+			t.Errorf("fit %d got %dx%d want %dx%d", test.fit, w, h, test.w, test.h)
+		}
+	}
+}
+
+func TestImage_Layout(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 40, 20))
+
+	i := NewImage()
+	i.SetImage(src)
+	i.SetBounds(image.Rect(0, 0, 100, 0))
+	i.Layout(nil)
+	if got, want := i.Bounds(), image.Rect(0, 0, 100, 50); got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+
+	i.Fit(FitFill)
+	i.SetBounds(image.Rect(0, 0, 100, 100))
+	i.Layout(nil)
+	if got, want := i.Bounds(), image.Rect(0, 0, 100, 100); got != want {
+		t.Errorf("fill got %v want %v", got, want)
+	}
+}
+
+func TestImageCache(t *testing.T) {
+	c := newImageCache(2)
+	a := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	b := image.NewRGBA(image.Rect(0, 0, 40, 20))
+
+	if c.get(a, 40, 20, 0) != a || c.scaled != 0 {
+		t.Error("expected source image when unscaled")
+	}
+
+	s := c.get(a, 20, 10, 0)
+	if got := s.Bounds().Size(); got != image.Pt(20, 10) {
+		t.Errorf("got %v", got)
+	}
+	if c.get(a, 20, 10, 0) != s || c.scaled != 1 {
+		t.Errorf("expected cached image, scaled %d", c.scaled)
+	}
+
+	// a is the most recently used so b's first copy is evicted
+	c.get(b, 20, 10, 0)
+	c.get(a, 20, 10, 0)
+	c.get(b, 10, 5, 0)
+	if len(c.images) != 2 || c.get(a, 20, 10, 0) != s {
+		t.Errorf("expected a to be kept, got %d images", len(c.images))
+	}
+
+	c.remove(a)
+	if c.get(a, 20, 10, 0) == s {
+		t.Error("expected image to be scaled again after remove")
+	}
+}
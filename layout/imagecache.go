@@ -0,0 +1,87 @@
+package layout
+
+import (
+	"github.com/peter-mount/go-anim/graph/resize"
+	"image"
+	"sync"
+)
+
+// imageCacheKey identifies a scaled copy of an image
+type imageCacheKey struct {
+	src    image.Image
+	width  int
+	height int
+	interp resize.InterpolationFunction
+}
+
+// imageCache holds recently scaled images so they are not scaled again on every frame.
+// Images are compared by identity, so it must only be used with pointer image types like *image.RGBA.
+type imageCache struct {
+	mutex  sync.Mutex
+	size   int                           // Maximum number of images held
+	images map[imageCacheKey]image.Image // Scaled images
+	recent []imageCacheKey               // Keys in the order they were last used, most recent last
+	scaled int                           // Number of images scaled, for testing
+}
+
+func newImageCache(size int) *imageCache {
+	return &imageCache{size: size, images: make(map[imageCacheKey]image.Image)}
+}
+
+// scaledImages is shared by all Image components, so an image shown in several places at the same size
+// is only scaled once
+var scaledImages = newImageCache(32)
+
+// get returns src scaled to width and height, scaling it if it is not already cached
+func (c *imageCache) get(src image.Image, width, height int, interp resize.InterpolationFunction) image.Image {
+	if b := src.Bounds(); b.Dx() == width && b.Dy() == height {
+		return src
+	}
+
+	k := imageCacheKey{src: src, width: width, height: height, interp: interp}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	img, exists := c.images[k]
+	if exists {
+		c.touch(k)
+		return img
+	}
+
+	img = resize.Resize(uint(width), uint(height), src, interp)
+	c.scaled++
+	c.images[k] = img
+	c.recent = append(c.recent, k)
+	if len(c.recent) > c.size {
+		delete(c.images, c.recent[0])
+		c.recent = c.recent[1:]
+	}
+	return img
+}
+
+// touch marks a key as the most recently used
+func (c *imageCache) touch(k imageCacheKey) {
+	for i, e := range c.recent {
+		if e == k {
+			c.recent = append(append(c.recent[:i:i], c.recent[i+1:]...), k)
+			return
+		}
+	}
+}
+
+// remove discards all scaled copies of src, used when its pixels may have changed
+func (c *imageCache) remove(src image.Image) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	recent := c.recent[:0]
+	for _, k := range c.recent {
+		if k.src == src {
+			delete(c.images, k)
+		} else {
+			recent = append(recent, k)
+		}
+	}
+	c.recent = recent
+}
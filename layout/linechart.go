@@ -0,0 +1,262 @@
+package layout
+
+import (
+	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/font"
+	"github.com/peter-mount/go-anim/util/series"
+	"math"
+	"time"
+)
+
+// chartPoint is a single point within a chart. For time series x is the unix time in seconds.
+type chartPoint struct {
+	x, y float64
+}
+
+// defaultLimit is the default number of values kept by lineData.SetArgs
+const defaultLimit = 100
+
+// lineData is the data shared by LineChart and Sparkline
+type lineData struct {
+	series  *series.Series // Series to plot, nil if using values
+	values  []float64      // Values to plot when not using a Series
+	limit   int            // Maximum number of values kept by SetArgs, 0 for no limit
+	span    time.Duration  // Duration of the window to plot, 0 for all data
+	end     time.Time      // End of the window to plot, zero for all data
+	updated *bool          // updateRequired of the component plotting the data
+}
+
+func newLineData(updated *bool) lineData {
+	return lineData{limit: defaultLimit, updated: updated}
+}
+
+// changed marks the component plotting the data as requiring an update
+func (d *lineData) changed() {
+	if d.updated != nil {
+		*d.updated = true
+	}
+}
+
+// SetSeries sets the Series to plot
+func (d *lineData) SetSeries(s *series.Series) {
+	d.series, d.values = s, nil
+	d.changed()
+}
+
+// SetValues sets the values to plot, evenly spaced along the x-axis
+func (d *lineData) SetValues(v ...float64) {
+	d.series, d.values = nil, v
+	d.changed()
+}
+
+// Limit sets the maximum number of values kept by SetArgs, dropping the oldest first. 0 keeps all of them.
+// The default is 100.
+func (d *lineData) Limit(n int) {
+	d.limit = max(0, n)
+	d.trim()
+	d.changed()
+}
+
+// trim drops the oldest values beyond the limit
+func (d *lineData) trim() {
+	if d.limit > 0 && len(d.values) > d.limit {
+		d.values = append(d.values[:0], d.values[len(d.values)-d.limit:]...)
+	}
+}
+
+// Span limits the plot of a Series to the duration before the time set by SetTime
+func (d *lineData) Span(span time.Duration) {
+	d.span = span
+	d.changed()
+}
+
+// SetTime implements TimeSetter, setting the end of the plot of a Series, usually the time of the current frame.
+// Combined with Span this allows a chart to scroll through the data.
+func (d *lineData) SetTime(t time.Time) {
+	if !t.Equal(d.end) {
+		d.end = t
+		d.changed()
+	}
+}
+
+// SetArgs implements ArgsSetter by appending the first arg to the values being plotted,
+// keeping no more than the number set by Limit
+func (d *lineData) SetArgs(args ...any) {
+	if len(args) > 0 {
+		if f, ok := args[0].(float64); ok {
+			d.series = nil
+			d.values = append(d.values, f)
+			d.trim()
+			d.changed()
+		}
+	}
+}
+
+func (d *lineData) isTime() bool {
+	return d.series != nil
+}
+
+// location returns the timezone of the Series being plotted
+func (d *lineData) location() *time.Location {
+	if d.series != nil && d.series.Len() > 0 {
+		return d.series.End().Location()
+	}
+	return time.Local
+}
+
+// points returns the points to plot along with the range of the data
+func (d *lineData) points() ([]chartPoint, float64, float64, float64, float64) {
+	var pts []chartPoint
+	if d.series != nil {
+		for _, p := range d.series.Points() {
+			if !d.end.IsZero() {
+				if p.Time.After(d.end) || (d.span > 0 && p.Time.Before(d.end.Add(-d.span))) {
+					continue
+				}
+			}
+			pts = append(pts, chartPoint{x: float64(p.Time.UnixMilli()) / 1000.0, y: p.Value})
+		}
+	} else {
+		for i, v := range d.values {
+			pts = append(pts, chartPoint{x: float64(i), y: v})
+		}
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range pts {
+		minX, maxX = math.Min(minX, p.x), math.Max(maxX, p.x)
+		minY, maxY = math.Min(minY, p.y), math.Max(maxY, p.y)
+	}
+
+	// Ensure the x-axis covers the full window when one is set
+	if d.series != nil && !d.end.IsZero() && d.span > 0 {
+		minX = float64(d.end.Add(-d.span).UnixMilli()) / 1000.0
+		maxX = float64(d.end.UnixMilli()) / 1000.0
+	}
+
+	return pts, minX, maxX, minY, maxY
+}
+
+// plot adds the path of the points to gc, scaled to the plot area of width w and height h
+func plot(gc draw2d2.GraphicContext, pts []chartPoint, xs, ys Scale, x0, y0, w, h float64) {
+	gc.BeginPath()
+	for i, p := range pts {
+		x, y := x0+xs.Pos(p.x, w), y0+h-ys.Pos(p.y, h)
+		if i == 0 {
+			gc.MoveTo(x, y)
+		} else {
+			gc.LineTo(x, y)
+		}
+	}
+}
+
+// LineChart plots a Series, or a set of values, as a line with a value and x-axis
+type LineChart struct {
+	chart
+	lineData
+	xTicks int // Approximate number of ticks on the x-axis
+}
+
+func NewLineChart() *LineChart {
+	c := &LineChart{
+		chart:  newChart("LineChart", 0.5),
+		xTicks: 6,
+	}
+	c.lineData = newLineData(&c.updateRequired)
+	c.BaseComponent.painter = c.paint
+	return c
+}
+
+// XTicks sets the approximate number of ticks on the x-axis
+func (c *LineChart) XTicks(n int) {
+	c.xTicks = max(1, n)
+	c.updateRequired = true
+}
+
+func (c *LineChart) paint(gc draw2d2.GraphicContext) {
+	pts, minX, maxX, minY, maxY := c.points()
+	if len(pts) == 0 {
+		return
+	}
+
+	w, h := c.innerSize()
+	ys := c.scale(minY, maxY)
+
+	var xs Scale
+	var xLabel func(float64) string
+	if c.isTime() {
+		xs, xLabel = timeScale(minX, maxX, c.xTicks, c.location())
+	} else {
+		xs = NiceScale(minX, maxX, c.xTicks)
+		xLabel = util.FloatToA
+	}
+
+	// Plot area leaves room for the axis labels, including half of the first & last
+	// labels which are centered on the ends of the axes
+	lh := labelHeight(gc)
+	xl := 0.0
+	for _, v := range xs.Ticks() {
+		l, _, r, _ := font.StringBounds(gc, xLabel(v))
+		xl = math.Max(xl, r-l)
+	}
+	px := c.maxLabelWidth(gc, ys) + c.tickSize + c.labelSpan
+	py := lh / 2
+	pw := w - px - xl/2
+	ph := h - py - lh - c.tickSize - c.labelSpan
+
+	c.valueAxis(gc, ys, px, py, py+ph, pw)
+
+	// x-axis
+	y := py + ph
+	line(gc, px, y, px+pw, y)
+	for _, v := range xs.Ticks() {
+		x := px + xs.Pos(v, pw)
+		line(gc, x, y, x, y+c.tickSize)
+		drawLabel(gc, xLabel(v), x, y+c.tickSize+c.labelSpan+lh/2, util.CenterAlignment)
+	}
+
+	plot(gc, pts, xs, ys, px, py, pw, ph)
+	gc.Stroke()
+}
+
+// timeSteps are the intervals in seconds used for ticks on a time axis
+var timeSteps = []float64{
+	1, 5, 10, 15, 30,
+	60, 5 * 60, 10 * 60, 15 * 60, 30 * 60,
+	3600, 2 * 3600, 3 * 3600, 6 * 3600, 12 * 3600,
+	86400,
+}
+
+// timeScale returns a Scale for unix times in seconds with ticks on whole intervals in the
+// supplied timezone, along with a function to format the tick labels
+func timeScale(lo, hi float64, ticks int, loc *time.Location) (Scale, func(float64) string) {
+	step := timeSteps[len(timeSteps)-1]
+	for _, s := range timeSteps {
+		if (hi-lo)/s <= float64(ticks) {
+			step = s
+			break
+		}
+	}
+
+	layout := "15:04"
+	switch {
+	case step < 60:
+		layout = "15:04:05"
+	case step >= 86400:
+		layout = "02 Jan"
+	}
+
+	// Offset so ticks fall on whole intervals in local time rather than UTC
+	_, off := time.Unix(int64(lo), 0).In(loc).Zone()
+	o := float64(off)
+
+	return Scale{
+		Min:  math.Floor((lo+o)/step)*step - o,
+		Max:  math.Ceil((hi+o)/step)*step - o,
+		Step: step,
+	}, func(v float64) string {
+		return time.Unix(int64(v), 0).In(loc).Format(layout)
+	}
+}
@@ -0,0 +1,208 @@
+package layout
+
+import (
+	"github.com/llgcode/draw2d"
+	"image"
+	"slices"
+)
+
+// Measurer is implemented by components which support the single pass measure/arrange protocol.
+//
+// Measure is called first with the space available to the component, then Arrange with its final bounds.
+// Children are laid out within Arrange, so a tree of Measurer's is laid out in a single pass.
+type Measurer interface {
+	// Measure returns the preferred size of the component, including insets, within the available space.
+	// A zero dimension in available means that dimension is unconstrained.
+	Measure(ctx draw2d.GraphicContext, available image.Point) image.Point
+	// Arrange sets the bounds of the component, relative to its parent, and arranges any children.
+	// A zero dimension in bounds is replaced by the preferred size of the component.
+	Arrange(ctx draw2d.GraphicContext, bounds image.Rectangle)
+}
+
+// Measure returns the preferred size of a Component within the available space, including any margin.
+//
+// Components which do not implement Measurer are measured by laying them out within the available space,
+// which is how RowContainer and ColScaleContainer size their children.
+func Measure(ctx draw2d.GraphicContext, c Component, available image.Point) image.Point {
+	return measureIn(ctx, c, available, available)
+}
+
+// measureIn is Measure but with percentages resolved against parent rather than the available space,
+// as flex and grid containers measure their components unconstrained along one axis.
+func measureIn(ctx draw2d.GraphicContext, c Component, available, parent image.Point) image.Point {
+	b := baseOf(c)
+	if b == nil {
+		return measure(ctx, c, available)
+	}
+
+	b.parentSize = parent
+	b.resolveUnits(ctx, parent)
+	margins := b.marginMin.Add(b.marginMax)
+
+	inner := available
+	if inner.X > 0 {
+		inner.X = max(inner.X-margins.X, 0)
+	}
+	if inner.Y > 0 {
+		inner.Y = max(inner.Y-margins.Y, 0)
+	}
+
+	w, h := b.preferredSize(ctx, parent.Sub(margins))
+	if w > 0 {
+		inner.X = w
+	}
+	if h > 0 {
+		inner.Y = h
+	}
+
+	size := measure(ctx, c, inner)
+	if w > 0 {
+		size.X = w
+	}
+	if h > 0 {
+		size.Y = h
+	}
+	return size.Add(margins)
+}
+
+func measure(ctx draw2d.GraphicContext, c Component, available image.Point) image.Point {
+	if m, ok := c.(Measurer); ok {
+		return m.Measure(ctx, available)
+	}
+
+	c.SetBounds(image.Rectangle{Max: available})
+	c.Layout(ctx)
+	return c.Bounds().Size()
+}
+
+// Arrange places a Component within bounds, less any margin.
+//
+// Components which do not implement Measurer are laid out within bounds, then have their bounds restored
+// as some, like Text, resize themselves during Layout.
+func Arrange(ctx draw2d.GraphicContext, c Component, bounds image.Rectangle) {
+	if b := baseOf(c); b != nil {
+		parent := b.parentSize
+		if parent == (image.Point{}) {
+			parent = bounds.Size()
+		}
+		b.resolveUnits(ctx, parent)
+
+		bounds.Min = bounds.Min.Add(b.marginMin)
+		if bounds.Dx() > 0 {
+			bounds.Max.X = max(bounds.Max.X-b.marginMax.X, bounds.Min.X)
+		} else {
+			bounds.Max.X = bounds.Min.X
+		}
+		if bounds.Dy() > 0 {
+			bounds.Max.Y = max(bounds.Max.Y-b.marginMax.Y, bounds.Min.Y)
+		} else {
+			bounds.Max.Y = bounds.Min.Y
+		}
+	}
+
+	if m, ok := c.(Measurer); ok {
+		m.Arrange(ctx, bounds)
+		return
+	}
+
+	c.SetBounds(bounds)
+	c.Layout(ctx)
+	c.SetBounds(bounds)
+}
+
+// LayoutTree lays out a tree of components within the bounds of its root in a single measure/arrange pass,
+// returning true if the bounds of any component changed.
+func LayoutTree(ctx draw2d.GraphicContext, c Component) bool {
+	before := treeBounds(c)
+	Arrange(ctx, c, c.Bounds())
+	return changedSince(c, before)
+}
+
+// treeBounds returns the bounds of a component followed by those of its descendants
+func treeBounds(c Component) []image.Rectangle {
+	r := []image.Rectangle{c.Bounds()}
+	if p, ok := c.(interface{ children() []Component }); ok {
+		for _, child := range p.children() {
+			r = append(r, treeBounds(child)...)
+		}
+	}
+	return r
+}
+
+// changedSince returns true if the bounds of c or any of its descendants differ from before, as returned by treeBounds
+func changedSince(c Component, before []image.Rectangle) bool {
+	return !slices.Equal(before, treeBounds(c))
+}
+
+// FlexAlign defines how components are positioned along an axis within a FlexContainer or GridContainer
+type FlexAlign int
+
+const (
+	AlignStart   FlexAlign = iota // Pack at the start
+	AlignEnd                      // Pack at the end
+	AlignCenter                   // Pack in the center
+	AlignStretch                  // Stretch to fill, cross axis only
+	SpaceBetween                  // Distribute space between components, main axis only
+	SpaceAround                   // Distribute space around components, main axis only
+	SpaceEvenly                   // Distribute space evenly between and around components, main axis only
+)
+
+// ParseFlexAlign parses an alignment name, using the CSS names "start", "end", "center", "stretch",
+// "space-between", "space-around" and "space-evenly". Unknown names resolve to AlignStart.
+func ParseFlexAlign(s string) FlexAlign {
+	switch s {
+	case "end", "flex-end":
+		return AlignEnd
+	case "center":
+		return AlignCenter
+	case "stretch":
+		return AlignStretch
+	case "space-between":
+		return SpaceBetween
+	case "space-around":
+		return SpaceAround
+	case "space-evenly":
+		return SpaceEvenly
+	default:
+		return AlignStart
+	}
+}
+
+// align returns the offset of a component of size within space
+func (a FlexAlign) align(size, space int) int {
+	switch a {
+	case AlignEnd:
+		return space - size
+	case AlignCenter:
+		return (space - size) / 2
+	default:
+		return 0
+	}
+}
+
+// contentSize returns the size within the insets of a component, keeping unconstrained dimensions as 0
+func (c *BaseComponent) contentSize(p image.Point) image.Point {
+	if p.X > 0 {
+		p.X = max(p.X-c.insetMinX-c.insetMaxX, 0)
+	}
+	if p.Y > 0 {
+		p.Y = max(p.Y-c.insetMinY-c.insetMaxY, 0)
+	}
+	return p
+}
+
+// withInsets returns the size of a component with content of size p
+func (c *BaseComponent) withInsets(p image.Point) image.Point {
+	return image.Pt(p.X+c.insetMinX+c.insetMaxX, p.Y+c.insetMinY+c.insetMaxY)
+}
+
+// resolveBounds replaces any zero dimension in bounds with the preferred size
+func resolveBounds(bounds image.Rectangle, size image.Point) image.Rectangle {
+	if bounds.Dx() == 0 {
+		bounds.Max.X = bounds.Min.X + size.X
+	}
+	if bounds.Dy() == 0 {
+		bounds.Max.Y = bounds.Min.Y + size.Y
+	}
+	return bounds
+}
@@ -0,0 +1,80 @@
+package layout
+
+import (
+	"github.com/llgcode/draw2d"
+	"image"
+	"strings"
+	"testing"
+)
+
+func TestLayoutTree(t *testing.T) {
+	d, err := ReadDefinition(strings.NewReader(`
+components:
+  - type: RowContainer
+    components:
+      - type: ColScaleContainer
+        scales: [0.5, 0.5]
+        components:
+          - type: Text
+            format: Left
+          - type: LineChart
+            aspect: 0.25
+      - type: FlexRow
+        components:
+          - type: Text
+            name: one
+            format: one
+            grow: 1
+          - type: Text
+            format: two
+`), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := map[string]Component{}
+	root := FixedContainer(image.Rect(0, 0, 400, 300))
+	if err := d.BuildChildren(root, func(n string, c Component) error {
+		names[n] = c
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !LayoutTree(testContext(), root) {
+		t.Error("first layout reported no change")
+	}
+	// A single pass is enough, so laying out again changes nothing
+	if LayoutTree(testContext(), root) {
+		t.Error("second layout reported a change")
+	}
+
+	names["one"].(*Text).Hide()
+	if !LayoutTree(testContext(), root) {
+		t.Error("hiding reported no change")
+	}
+}
+
+// layoutCounter counts the times it is laid out
+type layoutCounter struct {
+	BaseComponent
+	layouts int
+}
+
+func (c *layoutCounter) Layout(ctx draw2d.GraphicContext) bool {
+	c.layouts++
+	return c.BaseComponent.Layout(ctx)
+}
+
+func TestContainers_LayoutOnce(t *testing.T) {
+	// Each component is laid out once per layout of its container
+	for _, cont := range []Container{RowContainer(), ColScaleContainer(0.5, 0.5), TitledContainer("Title")} {
+		a, b := &layoutCounter{}, &layoutCounter{}
+		cont.Add(a).Add(b)
+		cont.SetBounds(image.Rect(0, 0, 200, 100))
+		cont.Layout(testContext())
+		if a.layouts != 1 || b.layouts != 1 {
+			t.Errorf("%s laid out %d and %d times", cont.GetType(), a.layouts, b.layouts)
+		}
+	}
+}
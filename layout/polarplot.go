@@ -0,0 +1,111 @@
+package layout
+
+import (
+	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"math"
+)
+
+// PolarPlot plots points of compass angle and radius, e.g. the track of the Sun across the sky
+type PolarPlot struct {
+	chart
+	angles []float64 // Compass angle of each point in degrees
+	radii  []float64 // Radius of each point
+	spokes int       // Number of spokes
+	closed bool      // true to close the path
+}
+
+func NewPolarPlot() *PolarPlot {
+	c := &PolarPlot{
+		chart:  newChart("PolarPlot", 1),
+		spokes: 12,
+	}
+	c.BaseComponent.painter = c.paint
+	return c
+}
+
+// Clear removes all points
+func (c *PolarPlot) Clear() {
+	c.angles, c.radii = nil, nil
+	c.updateRequired = true
+}
+
+// Add a point with a compass angle in degrees and radius
+func (c *PolarPlot) Add(angle, radius float64) {
+	c.angles = append(c.angles, angle)
+	c.radii = append(c.radii, radius)
+	c.updateRequired = true
+}
+
+// SetPoints replaces the points with the supplied angles and radii
+func (c *PolarPlot) SetPoints(angles, radii []float64) {
+	n := min(len(angles), len(radii))
+	c.angles, c.radii = angles[:n], radii[:n]
+	c.updateRequired = true
+}
+
+// Spokes sets the number of spokes drawn, labelled with their angle
+func (c *PolarPlot) Spokes(n int) {
+	c.spokes = max(0, n)
+	c.updateRequired = true
+}
+
+// Closed closes the plotted path, e.g. for a radar chart
+func (c *PolarPlot) Closed(b bool) {
+	c.closed = b
+	c.updateRequired = true
+}
+
+func (c *PolarPlot) paint(gc draw2d2.GraphicContext) {
+	w, h := c.innerSize()
+
+	mx := 0.0
+	for _, r := range c.radii {
+		mx = math.Max(mx, r)
+	}
+	s := c.scale(0, mx)
+
+	lh := labelHeight(gc)
+	cx, cy := w/2, h/2
+	r := math.Min(w, h)/2 - lh - c.labelSpan
+
+	for _, v := range s.Ticks() {
+		if v <= s.Min {
+			continue
+		}
+		rr := s.Pos(v, r)
+		gc.BeginPath()
+		gc.ArcTo(cx, cy, rr, rr, 0, 2*math.Pi)
+		gc.Stroke()
+
+		// Label between the first two spokes so they don't overlap
+		x, y := compassPoint(cx, cy, 180/float64(max(1, c.spokes)), rr)
+		drawLabel(gc, c.label(v), x+c.labelSpan, y, util.LeftAlignment)
+	}
+
+	for i := 0; i < c.spokes; i++ {
+		a := float64(i) * 360 / float64(c.spokes)
+		x, y := compassPoint(cx, cy, a, r)
+		line(gc, cx, cy, x, y)
+		x, y = compassPoint(cx, cy, a, r+c.labelSpan+lh/2)
+		drawLabel(gc, util.FloatToA(a), x, y, util.CenterAlignment)
+	}
+
+	if len(c.radii) == 0 {
+		return
+	}
+
+	gc.BeginPath()
+	for i, a := range c.angles {
+		x, y := compassPoint(cx, cy, a, s.Pos(math.Max(s.Min, c.radii[i]), r))
+		if i == 0 {
+			gc.MoveTo(x, y)
+		} else {
+			gc.LineTo(x, y)
+		}
+	}
+	if c.closed {
+		gc.Close()
+	}
+	gc.Stroke()
+}
@@ -0,0 +1,115 @@
+package layout
+
+import (
+	"fmt"
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/font"
+	"github.com/peter-mount/go-anim/util/richtext"
+	"math"
+)
+
+// RichText is a Component which renders text containing runs in different styles.
+// The text is formatted with fmt.Sprintf then parsed as markup, see the richtext package for the syntax.
+//
+// The text is wrapped to the width of the component, or sized to the text if it has no width.
+type RichText struct {
+	BaseComponent
+	format     string
+	args       []any
+	align      richtext.Align
+	lineHeight float64
+	maxLines   int
+	block      *richtext.Block
+}
+
+// NewRichText creates a new RichText component. Markup in args is not escaped, use richtext.Escape if required.
+func NewRichText(format string, args ...any) *RichText {
+	t := &RichText{
+		format:        format,
+		args:          args,
+		BaseComponent: BaseComponent{Type: "RichText"},
+	}
+	t.BaseComponent.painter = t.paint
+	return t
+}
+
+// Args allows the args passed to NewRichText to be replaced, causing the component's output to change
+func (t *RichText) Args(args ...any) *RichText {
+	t.animateArgs(t.args)
+	t.args = args
+	t.updateRequired = true
+	return t
+}
+
+// SetArgs implements ArgsSetter
+func (t *RichText) SetArgs(args ...any) {
+	t.Args(args...)
+}
+
+// Align sets the alignment of each line, one of "left", "center", "right" or "justify"
+func (t *RichText) Align(s string) {
+	t.BaseComponent.Align(s)
+	t.align = richtext.ParseAlign(s)
+	t.updateRequired = true
+}
+
+// LineHeight sets the height of each line as a multiple of the height of its fonts. Default 1
+func (t *RichText) LineHeight(f float64) *RichText {
+	t.lineHeight = f
+	t.updateRequired = true
+	return t
+}
+
+// MaxLines limits the number of lines, ending the text with an ellipsis if it is truncated. 0 for no limit
+func (t *RichText) MaxLines(n int) *RichText {
+	t.maxLines = n
+	t.updateRequired = true
+	return t
+}
+
+func (t *RichText) String() string {
+	return fmt.Sprintf(t.format, t.currentArgs(t.args)...)
+}
+
+func (t *RichText) Layout(ctx draw2d.GraphicContext) bool {
+	before := t.Bounds()
+	bounds := before
+
+	t.BaseComponent.paint(draw2d2.Wrap(ctx), func(gc draw2d2.GraphicContext) {
+		t.layoutText(gc)
+		if bounds.Dx() == 0 {
+			bounds.Max.X = bounds.Min.X + int(math.Ceil(t.block.Width)) + t.insetMinX + t.insetMaxX
+		}
+		bounds.Max.Y = bounds.Min.Y + int(math.Ceil(t.block.Height)) + t.insetMinY + t.insetMaxY
+		t.SetBounds(bounds)
+	})
+
+	t.updateRequired = false
+	return t.Bounds() != before
+}
+
+// layoutText lays out the text within the current width, using the font in the GraphicContext
+func (t *RichText) layoutText(gc draw2d2.GraphicContext) {
+	spans, err := richtext.Parse(t.String())
+	if err != nil {
+		// Render invalid markup as is so the problem is visible
+		spans = richtext.Plain(t.String())
+	}
+
+	fd := gc.GetFontData()
+	base := font.New(fd.Name, gc.GetFontSize(), fd.Family, fd.Style)
+
+	t.block = richtext.Layout(gc, spans, base, richtext.Options{
+		Width:      float64(max(t.LocalBounds().Dx(), 0)),
+		Align:      t.align,
+		LineHeight: t.lineHeight,
+		MaxLines:   t.maxLines,
+	})
+}
+
+func (t *RichText) paint(gc draw2d2.GraphicContext) {
+	// Layout again as the args may have changed since the last Layout
+	t.layoutText(gc)
+	t.block.DrawWithEffects(gc, 0, 0, t.effects)
+}
@@ -2,7 +2,7 @@ package layout
 
 import (
 	"github.com/llgcode/draw2d"
-	"github.com/llgcode/draw2d/draw2dimg"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
 	"image"
 )
 
@@ -25,26 +25,27 @@ type rowContainer struct {
 	container
 }
 
+// Layout places each component below the previous one at the full width of the container, laying each out once
+// in place so it can set its own height, then fits the height of the container to them.
 func (c *rowContainer) Layout(ctx draw2d.GraphicContext) bool {
-	bounds := c.Bounds()
-
-	c.FitToWidth()
+	before := treeBounds(c)
 
-	c.BaseComponent.paint(ctx.(*draw2dimg.GraphicContext), func(gc *draw2dimg.GraphicContext) {
+	bounds := c.Bounds()
+	c.BaseComponent.paint(draw2d2.Wrap(ctx), func(gc draw2d2.GraphicContext) {
 		y := c.insetMinY
 		for _, comp := range c.components {
-			comp.Layout(gc)
-			cb := comp.Bounds()
-			dy := cb.Dy()
-			comp.SetBounds(image.Rect(c.insetX, y, bounds.Dx()-c.insetX, y+dy))
+			if !isShown(comp) {
+				continue
+			}
+			resolveUnits(gc, comp, bounds.Size())
+			comp.SetBounds(image.Rect(c.insetMinX, y, bounds.Dx()-c.insetMaxX, y+comp.Bounds().Dy()))
 			comp.Layout(gc)
 			y = y + comp.Bounds().Dy()
 		}
 		bounds.Max.Y = bounds.Min.Y + y + c.insetMaxY
 		c.SetBounds(bounds)
-
-		c.container.Layout(gc)
 	})
+	c.updateRequired = false
 
-	return true
+	return changedSince(c, before)
 }
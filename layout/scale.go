@@ -0,0 +1,83 @@
+package layout
+
+import (
+	"math"
+)
+
+// Scale is an axis scale with "nice" values for its ticks, e.g. 0, 5, 10 rather than 0, 4.3, 8.6
+type Scale struct {
+	Min  float64 // Value at the start of the axis
+	Max  float64 // Value at the end of the axis
+	Step float64 // Interval between ticks
+}
+
+// NiceScale returns a Scale which covers lo..hi with approximately ticks intervals.
+//
+// This is based on the "Nice Numbers for Graph Labels" algorithm from Graphics Gems.
+func NiceScale(lo, hi float64, ticks int) Scale {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	if ticks < 1 {
+		ticks = 1
+	}
+
+	// Ensure we have a range, so a constant value is still plotted
+	if lo == hi {
+		if lo == 0 {
+			hi = 1
+		} else {
+			d := math.Abs(lo) * 0.1
+			lo, hi = lo-d, hi+d
+		}
+	}
+
+	step := niceNum((hi - lo) / float64(ticks))
+	return Scale{
+		Min:  math.Floor(lo/step) * step,
+		Max:  math.Ceil(hi/step) * step,
+		Step: step,
+	}
+}
+
+// niceNum returns a "nice" number, 1, 2 or 5 times a power of 10, approximately equal to r.
+func niceNum(r float64) float64 {
+	exp := math.Pow(10, math.Floor(math.Log10(r)))
+	switch f := r / exp; {
+	case f < 1.5:
+		return exp
+	case f < 3:
+		return 2 * exp
+	case f < 7:
+		return 5 * exp
+	default:
+		return 10 * exp
+	}
+}
+
+// Ticks returns the value of each tick on the Scale
+func (s Scale) Ticks() []float64 {
+	var r []float64
+	if s.Step <= 0 {
+		return r
+	}
+	// Use a counter to prevent accumulating floating point errors
+	n := int(math.Round((s.Max - s.Min) / s.Step))
+	for i := 0; i <= n; i++ {
+		r = append(r, s.Min+float64(i)*s.Step)
+	}
+	return r
+}
+
+// Pos returns the position of a value along an axis of the supplied length
+func (s Scale) Pos(v, length float64) float64 {
+	if s.Max == s.Min {
+		return 0
+	}
+	return (v - s.Min) * length / (s.Max - s.Min)
+}
+
+// Contains returns true if the value is within the Scale
+func (s Scale) Contains(v float64) bool {
+	return v >= s.Min && v <= s.Max
+}
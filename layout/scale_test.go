@@ -0,0 +1,38 @@
+package layout
+
+import (
+	"testing"
+)
+
+func TestNiceScale(t *testing.T) {
+	tests := []struct {
+		lo, hi        float64
+		ticks         int
+		min, max, stp float64
+	}{
+		{lo: 0, hi: 10, ticks: 5, min: 0, max: 10, stp: 2},
+		{lo: 0.3, hi: 9.7, ticks: 5, min: 0, max: 10, stp: 2},
+		{lo: -3.2, hi: 27.8, ticks: 5, min: -5, max: 30, stp: 5},
+		{lo: 1002, hi: 1031, ticks: 4, min: 1000, max: 1040, stp: 10},
+		{lo: 5, hi: 5, ticks: 5, min: 4.4, max: 5.6, stp: 0.2},
+		{lo: 0, hi: 0, ticks: 5, min: 0, max: 1, stp: 0.2},
+	}
+	for _, tt := range tests {
+		s := NiceScale(tt.lo, tt.hi, tt.ticks)
+		if !near(s.Min, tt.min) || !near(s.Max, tt.max) || !near(s.Step, tt.stp) {
+			t.Errorf("NiceScale(%v,%v,%d) got %v want %v,%v,%v", tt.lo, tt.hi, tt.ticks, s, tt.min, tt.max, tt.stp)
+		}
+	}
+}
+
+func TestScale_Ticks(t *testing.T) {
+	ticks := Scale{Min: 0, Max: 1, Step: 0.1}.Ticks()
+	if len(ticks) != 11 || !near(ticks[10], 1) {
+		t.Errorf("got %v", ticks)
+	}
+}
+
+func near(a, b float64) bool {
+	d := a - b
+	return d > -1e-9 && d < 1e-9
+}
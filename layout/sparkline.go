@@ -0,0 +1,62 @@
+package layout
+
+import (
+	"github.com/llgcode/draw2d/draw2dkit"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"math"
+)
+
+// Sparkline is a small LineChart without axes, usually shown alongside a Value
+type Sparkline struct {
+	chart
+	lineData
+	marker bool // true to mark the last value
+}
+
+func NewSparkline() *Sparkline {
+	c := &Sparkline{
+		chart:  newChart("Sparkline", 0.25),
+		marker: true,
+	}
+	c.lineData = newLineData(&c.updateRequired)
+	c.BaseComponent.painter = c.paint
+	return c
+}
+
+// Marker enables a marker on the last value
+func (c *Sparkline) Marker(b bool) {
+	c.marker = b
+	c.updateRequired = true
+}
+
+func (c *Sparkline) paint(gc draw2d2.GraphicContext) {
+	pts, minX, maxX, minY, maxY := c.points()
+	if len(pts) == 0 {
+		return
+	}
+
+	w, h := c.innerSize()
+
+	// Leave room for the marker so it's not clipped
+	r := 0.0
+	if c.marker {
+		r = math.Max(2, c.lineWidth*2)
+	}
+
+	// Sparklines scale to the data, not to nice values
+	ys := Scale{Min: minY, Max: maxY}
+	if c.fixed {
+		ys.Min, ys.Max = c.min, c.max
+	}
+	xs := Scale{Min: minX, Max: maxX}
+
+	plot(gc, pts, xs, ys, r, r, w-r-r, h-r-r)
+	gc.Stroke()
+
+	if c.marker {
+		p := pts[len(pts)-1]
+		gc.BeginPath()
+		draw2dkit.Circle(gc, r+xs.Pos(p.x, w-r-r), h-r-ys.Pos(p.y, h-r-r), r)
+		gc.Fill()
+	}
+}
@@ -0,0 +1,84 @@
+package layout
+
+import (
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/svg"
+	"image"
+	"math"
+)
+
+// SvgImage displays an SVG document, drawn as vectors so it stays sharp at any size.
+// The document keeps its aspect ratio, centered within the component.
+type SvgImage struct {
+	BaseComponent
+	doc *svg.Document
+}
+
+func NewSvgImage() *SvgImage {
+	i := &SvgImage{
+		BaseComponent: BaseComponent{
+			Type: "SvgImage",
+		},
+	}
+	i.painter = i.paint
+	return i
+}
+
+// Load loads the document to display from an SVG file
+func (i *SvgImage) Load(fileName string) error {
+	doc, err := svg.Load(fileName)
+	if err != nil {
+		return err
+	}
+	i.SetDocument(doc)
+	return nil
+}
+
+func (i *SvgImage) SetDocument(doc *svg.Document) {
+	i.doc = doc
+	i.updateRequired = true
+}
+
+func (i *SvgImage) Document() *svg.Document {
+	return i.doc
+}
+
+func (i *SvgImage) Layout(_ draw2d.GraphicContext) bool {
+	i.updateRequired = false
+
+	if i.doc == nil {
+		return false
+	}
+
+	// Size any unset dimension from the document, keeping the aspect ratio
+	dw, dh := i.doc.Size()
+	lb := i.LocalBounds()
+	w, h := lb.Dx(), lb.Dy()
+	switch {
+	case i.Width() == 0 && i.Height() == 0:
+		w, h = int(math.Ceil(dw)), int(math.Ceil(dh))
+	case i.Width() == 0:
+		w = int(math.Round(float64(h) * dw / dh))
+	case i.Height() == 0:
+		h = int(math.Round(float64(w) * dh / dw))
+	default:
+		return false
+	}
+
+	cb := i.Bounds()
+	cb.Max = cb.Min.Add(image.Pt(w+i.insetMinX+i.insetMaxX, h+i.insetMinY+i.insetMaxY))
+	if cb.Eq(i.Bounds()) {
+		return false
+	}
+	i.SetBounds(cb)
+	return true
+}
+
+func (i *SvgImage) paint(gc draw2d2.GraphicContext) {
+	if i.doc == nil {
+		return
+	}
+	lb := i.LocalBounds()
+	i.doc.Draw(gc, 0, 0, float64(lb.Dx()), float64(lb.Dy()))
+}
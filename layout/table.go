@@ -0,0 +1,401 @@
+package layout
+
+import (
+	"fmt"
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dkit"
+	"github.com/peter-mount/go-anim/graph"
+	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/font"
+	"github.com/peter-mount/go-anim/util/unit"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// TableColumn defines a column of a Table
+type TableColumn struct {
+	title  string         // Title shown in the header
+	track  Track          // Width as a Track when auto or a fraction
+	width  unit.Value     // Width with a unit, used when track is TrackPixels
+	align  util.Alignment // Alignment of the title and values
+	format string         // Format of each value
+}
+
+// NewTableColumn creates a TableColumn sized to its content, left aligned with values formatted with "%v"
+func NewTableColumn(title string) *TableColumn {
+	return &TableColumn{title: title, track: Track{Unit: TrackAuto}, format: "%v"}
+}
+
+// Width sets the width of the column, either "auto" to size it to its content, a fraction of the remaining
+// space like "1fr", or a size with a unit like "120", "20%" or "8em"
+func (c *TableColumn) Width(s string) (*TableColumn, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		s = "auto"
+	}
+	if s == "auto" || strings.HasSuffix(s, "fr") {
+		t, err := ParseTrack(s)
+		if err != nil {
+			return nil, err
+		}
+		c.track = t
+		return c, nil
+	}
+
+	v, err := unit.ParseValue(s)
+	if err != nil {
+		return nil, err
+	}
+	c.track, c.width = Track{Unit: TrackPixels}, v
+	return c, nil
+}
+
+// Align sets the alignment of the column, "left", "center" or "right"
+func (c *TableColumn) Align(s string) *TableColumn {
+	c.align = util.ParseAlignment(s)
+	return c
+}
+
+// Format sets the format used to display each value, e.g. "%.1f"
+func (c *TableColumn) Format(s string) *TableColumn {
+	if s == "" {
+		s = "%v"
+	}
+	c.format = s
+	return c
+}
+
+// TableCellStyle overrides how an individual cell is drawn
+type TableCellStyle struct {
+	fill   color.Color // Background of the cell, nil for none
+	colour color.Color // Colour of the text, nil to use the table's
+}
+
+// Fill sets the background of the cell
+func (s *TableCellStyle) Fill(c color.Color) *TableCellStyle {
+	s.fill = c
+	return s
+}
+
+// Colour sets the colour of the text
+func (s *TableCellStyle) Colour(c color.Color) *TableCellStyle {
+	s.colour = c
+	return s
+}
+
+// Table is a Component which renders rows of values in columns, with an optional header row.
+//
+// The text is drawn with the component's Fill colour, and borders with its LineWidth.
+type Table struct {
+	BaseComponent
+	columns      []*TableColumn
+	rows         [][]any
+	header       bool                            // true to show the header row
+	headerFont   string                          // Font of the header, "" for the table's font
+	headerFill   color.Color                     // Background of the header, nil for none
+	stripe       color.Color                     // Background of alternate rows, nil for none
+	border       color.Color                     // Colour of the borders, nil for none
+	padding      int                             // Space around the text in each cell
+	styles       map[image.Point]*TableCellStyle // Style of individual cells, keyed by column and row
+	widths       []int                           // Width of each column
+	rowHeight    int                             // Height of each row
+	headerHeight int                             // Height of the header row, 0 if not shown
+	ascent       float64                         // Distance from the top of the text to its baseline
+	headAscent   float64                         // ascent of the header font
+}
+
+// NewTable creates a Table with the given columns
+func NewTable(columns ...*TableColumn) *Table {
+	t := &Table{
+		BaseComponent: BaseComponent{Type: "Table"},
+		columns:       columns,
+		header:        true,
+		padding:       4,
+		styles:        make(map[image.Point]*TableCellStyle),
+	}
+	t.BaseComponent.painter = t.paint
+	return t
+}
+
+// AddColumn adds a column to the table
+func (t *Table) AddColumn(c *TableColumn) *Table {
+	t.columns = append(t.columns, c)
+	t.updateRequired = true
+	return t
+}
+
+// Columns returns the columns of the table
+func (t *Table) Columns() []*TableColumn {
+	return t.columns
+}
+
+// Header shows or hides the header row
+func (t *Table) Header(show bool) *Table {
+	t.header = show
+	t.updateRequired = true
+	return t
+}
+
+// HeaderFont sets the font of the header row, e.g. "luxi 12 bold"
+func (t *Table) HeaderFont(font string) *Table {
+	t.headerFont = font
+	t.updateRequired = true
+	return t
+}
+
+// HeaderFill sets the background of the header row
+func (t *Table) HeaderFill(c color.Color) *Table {
+	t.headerFill = c
+	t.updateRequired = true
+	return t
+}
+
+// Stripe sets the background of alternate rows, nil for none
+func (t *Table) Stripe(c color.Color) *Table {
+	t.stripe = c
+	t.updateRequired = true
+	return t
+}
+
+// Border sets the colour of the borders around and between cells, nil for none
+func (t *Table) Border(c color.Color) *Table {
+	t.border = c
+	t.updateRequired = true
+	return t
+}
+
+// CellPadding sets the space around the text in each cell, default 4
+func (t *Table) CellPadding(p int) *Table {
+	t.padding = max(p, 0)
+	t.updateRequired = true
+	return t
+}
+
+// CellStyle returns the style of a cell, creating it if required. Row -1 is the header row.
+func (t *Table) CellStyle(column, row int) *TableCellStyle {
+	k := image.Pt(column, row)
+	s := t.styles[k]
+	if s == nil {
+		s = &TableCellStyle{}
+		t.styles[k] = s
+	}
+	t.updateRequired = true
+	return s
+}
+
+// SetRows replaces the rows in the table
+func (t *Table) SetRows(rows [][]any) *Table {
+	t.rows = rows
+	t.updateRequired = true
+	return t
+}
+
+// AddRow appends a row to the table
+func (t *Table) AddRow(values ...any) *Table {
+	t.rows = append(t.rows, values)
+	t.updateRequired = true
+	return t
+}
+
+// ClearRows removes all rows from the table
+func (t *Table) ClearRows() *Table {
+	t.rows = nil
+	t.updateRequired = true
+	return t
+}
+
+// SetCell sets the value of a cell, adding rows if required
+func (t *Table) SetCell(column, row int, v any) *Table {
+	for len(t.rows) <= row {
+		t.rows = append(t.rows, nil)
+	}
+	for len(t.rows[row]) <= column {
+		t.rows[row] = append(t.rows[row], nil)
+	}
+	t.rows[row][column] = v
+	t.updateRequired = true
+	return t
+}
+
+// SetArgs implements ArgsSetter, filling the table a row at a time
+func (t *Table) SetArgs(args ...any) {
+	n := max(len(t.columns), 1)
+	t.rows = nil
+	for i := 0; i < len(args); i += n {
+		j := min(i+n, len(args))
+		t.rows = append(t.rows, args[i:j:j])
+	}
+	t.updateRequired = true
+}
+
+// Cell returns the formatted value of a cell, "" if it has no value
+func (t *Table) Cell(column, row int) string {
+	if row >= len(t.rows) || column >= len(t.rows[row]) || column >= len(t.columns) || t.rows[row][column] == nil {
+		return ""
+	}
+	return fmt.Sprintf(t.columns[column].format, t.rows[row][column])
+}
+
+func (t *Table) Layout(ctx draw2d.GraphicContext) bool {
+	before := t.Bounds()
+	bounds := before
+
+	t.BaseComponent.paint(draw2d2.Wrap(ctx), func(gc draw2d2.GraphicContext) {
+		width := t.measure(gc, max(t.LocalBounds().Dx(), 0))
+		if bounds.Dx() == 0 {
+			bounds.Max.X = bounds.Min.X + width + t.insetMinX + t.insetMaxX
+		}
+		bounds.Max.Y = bounds.Min.Y + t.headerHeight + t.rowHeight*len(t.rows) + t.insetMinY + t.insetMaxY
+		t.SetBounds(bounds)
+	})
+
+	t.updateRequired = false
+	return t.Bounds() != before
+}
+
+// measure calculates the width of each column and the height of the rows within the available width,
+// returning the total width
+func (t *Table) measure(gc draw2d2.GraphicContext, available int) int {
+	pad := t.padding * 2
+
+	textHeight := func() (int, float64) {
+		_, top, _, bottom := font.StringBounds(gc, "Åg")
+		return int(math.Ceil(bottom-top)) + pad, -top
+	}
+	textWidth := func(s string) int {
+		l, _, r, _ := font.StringBounds(gc, s)
+		return int(math.Ceil(r-l)) + pad
+	}
+
+	contents := make([]int, len(t.columns))
+	for row := range t.rows {
+		for col := range t.columns {
+			if s := t.Cell(col, row); s != "" {
+				contents[col] = max(contents[col], textWidth(s))
+			}
+		}
+	}
+	t.rowHeight, t.ascent = textHeight()
+
+	t.headerHeight = 0
+	if t.header {
+		gc.Save()
+		if t.headerFont != "" {
+			_ = graph.SetFont(gc, t.headerFont)
+		}
+		for col, c := range t.columns {
+			contents[col] = max(contents[col], textWidth(c.title))
+		}
+		t.headerHeight, t.headAscent = textHeight()
+		gc.Restore()
+	}
+
+	tracks := make([]Track, len(t.columns))
+	for i, c := range t.columns {
+		tracks[i] = c.track
+		if c.track.Unit == TrackPixels {
+			tracks[i].Size = float64(t.resolveSize(gc, c.width, available))
+		}
+	}
+
+	t.widths = resolveTracks(tracks, available, 0, func(col int) int { return contents[col] })
+	return spanSize(t.widths, 0, len(t.widths), 0)
+}
+
+func (t *Table) paint(gc draw2d2.GraphicContext) {
+	// Measure again as the rows may have changed since the last Layout
+	width := t.measure(gc, max(t.LocalBounds().Dx(), 0))
+	height := t.headerHeight + t.rowHeight*len(t.rows)
+	textColour := gc.GetFillColor()
+
+	if t.header {
+		t.fillRect(gc, 0, 0, width, t.headerHeight, t.headerFill)
+		gc.Save()
+		if t.headerFont != "" {
+			_ = graph.SetFont(gc, t.headerFont)
+		}
+		x := 0
+		for col, c := range t.columns {
+			t.drawCell(gc, col, -1, c.title, x, 0, t.headerHeight, t.headAscent, textColour)
+			x += t.widths[col]
+		}
+		gc.Restore()
+	}
+
+	for row := range t.rows {
+		y := t.headerHeight + row*t.rowHeight
+		if row%2 == 1 {
+			t.fillRect(gc, 0, y, width, t.rowHeight, t.stripe)
+		}
+		x := 0
+		for col := range t.columns {
+			t.drawCell(gc, col, row, t.Cell(col, row), x, y, t.rowHeight, t.ascent, textColour)
+			x += t.widths[col]
+		}
+	}
+
+	if t.border != nil && width > 0 && height > 0 {
+		gc.Save()
+		gc.SetStrokeColor(t.border)
+		gc.BeginPath()
+		draw2dkit.Rectangle(gc, 0, 0, float64(width), float64(height))
+		x := 0
+		for _, w := range t.widths[:max(len(t.widths)-1, 0)] {
+			x += w
+			gc.MoveTo(float64(x), 0)
+			gc.LineTo(float64(x), float64(height))
+		}
+		for y := t.headerHeight; y < height; y += t.rowHeight {
+			if y > 0 {
+				gc.MoveTo(0, float64(y))
+				gc.LineTo(float64(width), float64(y))
+			}
+		}
+		gc.Stroke()
+		gc.Restore()
+	}
+}
+
+// drawCell draws the text of a cell at x, y with its style
+func (t *Table) drawCell(gc draw2d2.GraphicContext, col, row int, s string, x, y, h int, ascent float64, textColour color.Color) {
+	w := t.widths[col]
+	style := t.styles[image.Pt(col, row)]
+	if style != nil {
+		t.fillRect(gc, x, y, w, h, style.fill)
+	}
+	if s == "" {
+		return
+	}
+
+	l, _, r, _ := font.StringBounds(gc, s)
+	tx := float64(x + t.padding)
+	switch t.columns[col].align {
+	case util.CenterAlignment:
+		tx = float64(x) + (float64(w)-(r-l))/2
+	case util.RightAlignment:
+		tx = float64(x+w-t.padding) - (r - l)
+	}
+
+	gc.Save()
+	defer gc.Restore()
+	gc.SetFillColor(textColour)
+	if style != nil && style.colour != nil {
+		gc.SetFillColor(style.colour)
+	}
+	t.effects.FillStringAt(gc, s, tx-l, float64(y+t.padding)+ascent)
+}
+
+func (t *Table) fillRect(gc draw2d2.GraphicContext, x, y, w, h int, c color.Color) {
+	if c == nil || w <= 0 || h <= 0 {
+		return
+	}
+	gc.Save()
+	defer gc.Restore()
+	gc.SetFillColor(c)
+	gc.BeginPath()
+	draw2dkit.Rectangle(gc, float64(x), float64(y), float64(x+w), float64(y+h))
+	gc.Fill()
+}
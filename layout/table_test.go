@@ -0,0 +1,55 @@
+package layout
+
+import (
+	"github.com/llgcode/draw2d"
+	"image"
+	"testing"
+)
+
+func TestTable_Layout(t *testing.T) {
+	draw2d.SetFontFolder("../lib/font")
+
+	fixed, _ := NewTableColumn("Sensor").Width("100")
+	auto := NewTableColumn("Min").Align("right").Format("%.1f")
+	fill, _ := NewTableColumn("Max").Width("1fr")
+
+	table := NewTable(fixed, auto, fill)
+	table.AddRow("Outside", 1.25, 9.5)
+	table.AddRow("Inside", 18.0, 21.0)
+
+	root := FixedContainer(image.Rect(0, 0, 400, 300))
+	root.Add(table)
+	root.Layout(testContext())
+
+	if got := table.Cell(1, 0); got != "1.2" {
+		t.Errorf("cell got %q want 1.2", got)
+	}
+
+	w := table.widths
+	if len(w) != 3 || w[0] != 100 || w[1] <= 0 || w[0]+w[1]+w[2] != 400 {
+		t.Errorf("widths got %v", w)
+	}
+
+	if got, want := table.Bounds().Dy(), table.headerHeight+2*table.rowHeight; got != want || table.rowHeight <= 0 {
+		t.Errorf("height got %d want %d", got, want)
+	}
+
+	table.Header(false)
+	root.Layout(testContext())
+	if got, want := table.Bounds().Dy(), 2*table.rowHeight; got != want {
+		t.Errorf("no header height got %d want %d", got, want)
+	}
+}
+
+func TestTable_SetArgs(t *testing.T) {
+	table := NewTable(NewTableColumn("a"), NewTableColumn("b"))
+	table.SetArgs(1, 2, 3, 4, 5)
+	if len(table.rows) != 3 || table.Cell(1, 1) != "4" || table.Cell(1, 2) != "" {
+		t.Errorf("got %v", table.rows)
+	}
+
+	table.SetCell(1, 2, 6)
+	if table.Cell(1, 2) != "6" || table.Cell(0, 2) != "5" {
+		t.Errorf("got %v", table.rows)
+	}
+}
@@ -3,7 +3,8 @@ package layout
 import (
 	"fmt"
 	"github.com/llgcode/draw2d"
-	"github.com/llgcode/draw2d/draw2dimg"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/font"
 )
 
 // Text is a simple Component which renders a formatted string.
@@ -36,16 +37,23 @@ func (t *Text) Pos(x, y float64) *Text {
 
 // Args allows the args passed to NewText to be replaced, causing the component's output to change
 func (t *Text) Args(args ...any) *Text {
+	t.animateArgs(t.args)
 	t.args = args
 	t.updateRequired = true
 	return t
 }
 
+// SetArgs implements ArgsSetter
+func (t *Text) SetArgs(args ...any) {
+	t.Args(args...)
+}
+
 func (t *Text) Layout(ctx draw2d.GraphicContext) bool {
-	bounds := t.Bounds()
+	before := t.Bounds()
+	bounds := before
 
-	t.BaseComponent.paint(ctx.(*draw2dimg.GraphicContext), func(gc *draw2dimg.GraphicContext) {
-		t.l, t.top, t.r, t.b = ctx.GetStringBounds(t.String())
+	t.BaseComponent.paint(draw2d2.Wrap(ctx), func(gc draw2d2.GraphicContext) {
+		t.l, t.top, t.r, t.b = font.StringBounds(gc, t.String())
 		if bounds.Dx() == 0 {
 			bounds.Max.X = bounds.Min.X + int(t.r-t.l)
 		}
@@ -54,13 +62,15 @@ func (t *Text) Layout(ctx draw2d.GraphicContext) bool {
 	})
 
 	t.updateRequired = false
-	return true
+	return t.Bounds() != before
 }
 
-func (t *Text) paint(gc *draw2dimg.GraphicContext) {
-	t.alignment.Fill(gc, t.Bounds(), 2, t.format, t.args...)
+func (t *Text) paint(gc draw2d2.GraphicContext) {
+	m := t.alignment.Metrics(gc, t.LocalBounds(), 2, "%s", t.String())
+	m.Effects = t.effects
+	m.Fill(gc)
 }
 
 func (t *Text) String() string {
-	return fmt.Sprintf(t.format, t.args...)
+	return fmt.Sprintf(t.format, t.currentArgs(t.args)...)
 }
@@ -2,11 +2,11 @@ package layout
 
 import (
 	"github.com/llgcode/draw2d"
-	"github.com/llgcode/draw2d/draw2dimg"
 	"github.com/llgcode/draw2d/draw2dkit"
 	"github.com/peter-mount/go-anim/graph"
 	"github.com/peter-mount/go-anim/util"
 	color2 "github.com/peter-mount/go-anim/util/color"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
 	"image"
 	"image/color"
 )
@@ -46,48 +46,51 @@ func (c *titledContainer) TitleFont(font string) Container {
 }
 
 func (c *titledContainer) Layout(ctx draw2d.GraphicContext) bool {
-	c.BaseComponent.paint(ctx.(*draw2dimg.GraphicContext), func(gc *draw2dimg.GraphicContext) {
+	before := treeBounds(c)
+
+	c.BaseComponent.paint(draw2d2.Wrap(ctx), func(gc draw2d2.GraphicContext) {
 		gc.Save()
 		_ = graph.SetFont(gc, c.titleFont)
 		b := c.Bounds()
 		b = b.Add(image.Pt(-b.Min.X, -b.Min.Y))
-		c.metrics = util.LeftAlignment.Metrics(gc, b, 2, c.title)
+		c.metrics = util.LeftAlignment.Metrics(gc, b, 2, "%s", c.title)
 		gc.Restore()
 
 		c.insetMinY = 10 + int(c.metrics.MaxLineHeight)
 		c.insetMaxY = 10
-		c.insetX = 5
+		c.insetMinX = 5
+		c.insetMaxX = 5
 
 		_ = c.rowContainer.Layout(ctx)
 
 		//c.SetBounds(c.rowContainer.BaseComponent.Bounds())
 	})
-	return true
+	return changedSince(c, before)
 }
 
-func (c *titledContainer) paint(gc *draw2dimg.GraphicContext) {
+func (c *titledContainer) paint(gc draw2d2.GraphicContext) {
 	gc.Save()
 	b := c.Bounds()
 
 	gc.SetFillColor(c.background)
 	gc.BeginPath()
 	draw2dkit.Rectangle(gc,
-		float64(c.insetX), float64(0),
-		float64(b.Dx()-c.insetX-c.insetX), float64(b.Dy()-c.insetMaxY),
+		float64(c.insetMinX), float64(0),
+		float64(b.Dx()-c.insetMinX-c.insetMaxX), float64(b.Dy()-c.insetMaxY),
 	)
 	gc.Fill()
 
 	gc.SetFillColor(c.menuColor)
 	gc.BeginPath()
 	draw2dkit.Rectangle(gc,
-		float64(c.insetX), float64(0),
-		float64(b.Dx()-c.insetX-c.insetX), float64(c.insetMinY),
+		float64(c.insetMinX), float64(0),
+		float64(b.Dx()-c.insetMinX-c.insetMaxX), float64(c.insetMinY),
 	)
 	gc.Fill()
 
 	gc.SetFillColor(c.textColour)
 	_ = graph.SetFont(gc, c.titleFont)
-	gc.Translate(float64(c.insetX)+5, 5)
+	gc.Translate(float64(c.insetMinX)+5, 5)
 	c.metrics.Fill(gc)
 
 	gc.Restore()
@@ -101,8 +104,8 @@ func (c *titledContainer) paint(gc *draw2dimg.GraphicContext) {
 	gc.SetLineWidth(2)
 	gc.BeginPath()
 	draw2dkit.Rectangle(gc,
-		float64(c.insetX), float64(0),
-		float64(b.Dx()-c.insetX-c.insetX), float64(b.Dy()-c.insetMaxY),
+		float64(c.insetMinX), float64(0),
+		float64(b.Dx()-c.insetMinX-c.insetMaxX), float64(b.Dy()-c.insetMaxY),
 	)
 	gc.Stroke()
 	gc.Restore()
@@ -0,0 +1,165 @@
+package layout
+
+import (
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dkit"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/gps"
+	"image/color"
+	"math"
+	"time"
+)
+
+// TrackMap renders a GPS Track projected into its bounds, with a marker showing the position
+// at the current time. It is rendered entirely from vector data, so needs no map tiles.
+type TrackMap struct {
+	BaseComponent
+	aspect       float64     // Height as a proportion of the width, 0 to leave the height alone
+	track        *gps.Track  // Track to render
+	layers       []mapLayer  // Background layers, e.g. roads or coastlines
+	time         time.Time   // Current time, zero to show no marker
+	travelled    color.Color // Colour of the track already travelled, nil to use the stroke colour
+	marker       float64     // Radius of the position marker
+	trackPadding float64     // Padding around the track
+}
+
+type mapLayer struct {
+	track  *gps.Track
+	colour color.Color
+}
+
+func NewTrackMap() *TrackMap {
+	c := &TrackMap{
+		BaseComponent: BaseComponent{Type: "TrackMap"},
+		aspect:        1,
+		marker:        5,
+		trackPadding:  10,
+	}
+	c.BaseComponent.painter = c.paint
+	return c
+}
+
+// Aspect sets the height of the map as a proportion of its width.
+// Setting 0 leaves the height as set by the container.
+func (c *TrackMap) Aspect(a float64) {
+	c.aspect = math.Max(0, a)
+	c.updateRequired = true
+}
+
+// SetTrack sets the Track to render
+func (c *TrackMap) SetTrack(t *gps.Track) {
+	c.track = t
+	c.updateRequired = true
+}
+
+// AddLayer adds a Track which is drawn behind the main Track in the specified colour
+func (c *TrackMap) AddLayer(t *gps.Track, col color.Color) {
+	c.layers = append(c.layers, mapLayer{track: t, colour: col})
+	c.updateRequired = true
+}
+
+// SetTime implements TimeSetter, setting the current time, usually the Time of the Frame being rendered
+func (c *TrackMap) SetTime(t time.Time) {
+	if !t.Equal(c.time) {
+		c.time = t
+		c.updateRequired = true
+	}
+}
+
+// Travelled sets the colour of the part of the track before the current time
+func (c *TrackMap) Travelled(col color.Color) {
+	c.travelled = col
+	c.updateRequired = true
+}
+
+// Marker sets the radius of the position marker, 0 to hide it
+func (c *TrackMap) Marker(r float64) {
+	c.marker = math.Max(0, r)
+	c.updateRequired = true
+}
+
+// TrackPadding sets the space left around the track
+func (c *TrackMap) TrackPadding(p float64) {
+	c.trackPadding = math.Max(0, p)
+	c.updateRequired = true
+}
+
+func (c *TrackMap) Layout(_ draw2d.GraphicContext) bool {
+	changed := c.layoutAspect(c.aspect)
+	c.updateRequired = false
+	return changed
+}
+
+// fitTrack returns the Track the projection should fit
+func (c *TrackMap) fitTrack() *gps.Track {
+	if c.track != nil && c.track.Len() > 0 {
+		return c.track
+	}
+	// No main track so fit all layers
+	t := gps.NewTrack("")
+	for _, l := range c.layers {
+		for _, p := range l.track.Points() {
+			t.Add(p)
+		}
+	}
+	return t
+}
+
+func (c *TrackMap) paint(gc draw2d2.GraphicContext) {
+	fit := c.fitTrack()
+	if fit.Len() == 0 {
+		return
+	}
+
+	b := c.InsetBounds()
+	proj := gps.TrackProjection(fit, float64(b.Dx()), float64(b.Dy()), c.trackPadding+c.marker)
+
+	for _, l := range c.layers {
+		gc.Save()
+		if l.colour != nil {
+			gc.SetStrokeColor(l.colour)
+		}
+		drawTrack(gc, proj, l.track.Points())
+		gc.Restore()
+	}
+
+	if c.track == nil {
+		return
+	}
+
+	drawTrack(gc, proj, c.track.Points())
+
+	if c.time.IsZero() || !c.track.IsTimed() {
+		return
+	}
+
+	if c.travelled != nil {
+		gc.Save()
+		gc.SetStrokeColor(c.travelled)
+		drawTrack(gc, proj, c.track.Until(c.time))
+		gc.Restore()
+	}
+
+	if p, ok := c.track.At(c.time); ok && c.marker > 0 {
+		x, y := proj.ProjectPoint(p)
+		gc.BeginPath()
+		draw2dkit.Circle(gc, x, y, c.marker)
+		gc.FillStroke()
+	}
+}
+
+func drawTrack(gc draw2d2.GraphicContext, proj gps.Projection, pts []gps.Point) {
+	if len(pts) < 2 {
+		return
+	}
+	gc.BeginPath()
+	for i, p := range pts {
+		x, y := proj.ProjectPoint(p)
+		if i == 0 {
+			gc.MoveTo(x, y)
+		} else {
+			gc.LineTo(x, y)
+		}
+	}
+	gc.Stroke()
+}
@@ -0,0 +1,137 @@
+package layout
+
+import (
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/unit"
+	"image"
+	"regexp"
+	"strings"
+)
+
+// Size sets the preferred width and height of the component. A zero Value sizes that dimension to its content.
+// Percentages are relative to the space available from the parent.
+func (c *BaseComponent) Size(width, height unit.Value) {
+	c.width = width
+	c.height = height
+	c.updateRequired = true
+}
+
+// Margin sets the space around the component within its parent.
+// Like css, percentages are relative to the width available from the parent.
+func (c *BaseComponent) Margin(d unit.Dimension) {
+	c.margin = d
+	c.updateRequired = true
+}
+
+// Padding sets the space within the component, replacing any insets set with Inset.
+// Like css, percentages are relative to the width available from the parent.
+func (c *BaseComponent) Padding(d unit.Dimension) {
+	c.padding = d
+	c.updateRequired = true
+}
+
+// FontSize sets the size of the font, replacing the size set with Font.
+// Percentages are relative to the size of the font inherited from the parent.
+func (c *BaseComponent) FontSize(v unit.Value) {
+	c.fontSize = v
+	c.updateRequired = true
+}
+
+func (c *BaseComponent) base() *BaseComponent {
+	return c
+}
+
+// baseOf returns the BaseComponent of a Component, nil if it does not embed one
+func baseOf(c Component) *BaseComponent {
+	if b, ok := c.(interface{ base() *BaseComponent }); ok {
+		return b.base()
+	}
+	return nil
+}
+
+// fontSizePattern matches a size with an explicit unit, e.g. "2em" or "5vh"
+var fontSizePattern = regexp.MustCompile(`^(\d+\.?\d*|\.\d+)(px|dp|pt|in|mm|em|ex|ch|%|vw|vh|vmin|vmax)$`)
+
+// splitFontSize removes any size with a unit, e.g. "2em" or "5vh", from a font definition.
+// Anything else, including plain numbers which are the font size in points, is left as part of the font.
+func splitFontSize(font string) (string, unit.Value, bool) {
+	var a []string
+	var size unit.Value
+	found := false
+	for _, e := range strings.Split(font, " ") {
+		if fontSizePattern.MatchString(e) {
+			if v, err := unit.ParseValue(e); err == nil {
+				size, found = v, true
+				continue
+			}
+		}
+		a = append(a, e)
+	}
+	return strings.Join(a, " "), size, found
+}
+
+func (c *BaseComponent) unitContext(ctx draw2d.GraphicContext, parent int) unit.Context {
+	return unit.Context{GC: draw2d2.Wrap(ctx), Parent: float64(parent), Viewport: c.viewport}
+}
+
+// resolveUnits resolves the padding and margin within the space available from the parent
+func (c *BaseComponent) resolveUnits(ctx draw2d.GraphicContext, parent image.Point) {
+	uc := c.unitContext(ctx, parent.X)
+
+	if !c.padding.IsZero() {
+		t, r, b, l := c.padding.Resolve(uc)
+		c.insetMinX, c.insetMinY, c.insetMaxX, c.insetMaxY = int(l), int(t), int(r), int(b)
+	}
+
+	t, r, b, l := c.margin.Resolve(uc)
+	c.marginMin = image.Pt(int(l), int(t))
+	c.marginMax = image.Pt(int(r), int(b))
+}
+
+// preferredSize returns the width and height set with Size, 0 if a dimension is sized to its content
+func (c *BaseComponent) preferredSize(ctx draw2d.GraphicContext, parent image.Point) (int, int) {
+	return c.resolveSize(ctx, c.width, parent.X), c.resolveSize(ctx, c.height, parent.Y)
+}
+
+func (c *BaseComponent) resolveSize(ctx draw2d.GraphicContext, v unit.Value, parent int) int {
+	if v.U == unit.Percent && parent <= 0 {
+		// Percentage of an unconstrained parent so size to content
+		return 0
+	}
+	return int(v.Resolve(c.unitContext(ctx, parent)))
+}
+
+// applyFontSize sets the font size, if one has been set with FontSize
+func (c *BaseComponent) applyFontSize(gc draw2d2.GraphicContext) {
+	if c.fontSize.IsZero() {
+		return
+	}
+
+	// Font sizes in draw2d are in points
+	pointsToPixels := float64(gc.GetDPI()) / unit.PointsPerInch
+	current := gc.GetFontSize() * pointsToPixels
+	px := c.fontSize.Resolve(unit.Context{GC: gc, Parent: current, Viewport: c.viewport})
+	if px > 0 {
+		gc.SetFontSize(px / pointsToPixels)
+	}
+}
+
+// setViewport sets the viewport of a Component and all of its children
+func setViewport(c Component, viewport image.Point) {
+	if b := baseOf(c); b != nil {
+		b.viewport = viewport
+	}
+	if p, ok := c.(interface{ children() []Component }); ok {
+		for _, child := range p.children() {
+			setViewport(child, viewport)
+		}
+	}
+}
+
+// resolveUnits resolves the padding and margin of a Component within the space available from its parent
+func resolveUnits(ctx draw2d.GraphicContext, c Component, parent image.Point) {
+	if b := baseOf(c); b != nil {
+		b.resolveUnits(ctx, parent)
+	}
+}
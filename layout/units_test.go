@@ -0,0 +1,86 @@
+package layout
+
+import (
+	"github.com/peter-mount/go-anim/util/unit"
+	"image"
+	"strings"
+	"testing"
+)
+
+func TestUnits_Flex(t *testing.T) {
+	a, b := newBox(10, 10), newBox(10, 10)
+	a.Size(unit.Value{F: 25, U: unit.Percent}, unit.Value{F: 10, U: unit.Vh})
+	b.Margin(unit.SquareDimension(unit.Pixels(5)))
+
+	c := FlexRow().AlignItems(AlignStart)
+	c.Add(a)
+	c.Add(b)
+
+	root := FixedContainer(image.Rect(0, 0, 400, 300))
+	root.Add(c)
+	root.Layout(testContext())
+
+	if got, want := a.Bounds(), image.Rect(0, 0, 100, 30); got != want {
+		t.Errorf("a got %v want %v", got, want)
+	}
+	if got, want := b.Bounds(), image.Rect(105, 5, 115, 15); got != want {
+		t.Errorf("b got %v want %v", got, want)
+	}
+}
+
+func TestUnits_Definition(t *testing.T) {
+	d, err := ReadDefinition(strings.NewReader(`
+type: Text
+width: 50%
+height: 2em
+padding: 1 2 3 4
+margin: 5
+fontSize: 3vh
+`), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (unit.Value{F: 50, U: unit.Percent}); !d.Width.Equals(want) {
+		t.Errorf("width got %v want %v", d.Width, want)
+	}
+	if want := (unit.Value{F: 2, U: unit.Em}); !d.Height.Equals(want) {
+		t.Errorf("height got %v want %v", d.Height, want)
+	}
+	if want := "1px 2px 3px 4px"; d.Padding.String() != want {
+		t.Errorf("padding got %q want %q", d.Padding.String(), want)
+	}
+	if want := "5px"; d.Margin.String() != want {
+		t.Errorf("margin got %q want %q", d.Margin.String(), want)
+	}
+	if want := (unit.Value{F: 3, U: unit.Vh}); !d.FontSize.Equals(want) {
+		t.Errorf("fontSize got %v want %v", d.FontSize, want)
+	}
+}
+
+func TestSplitFontSize(t *testing.T) {
+	tests := []struct {
+		font     string
+		wantFont string
+		wantSize string
+	}{
+		{"luxi 3vh mono", "luxi mono", "3vh"},
+		{"luxi 20", "luxi 20", ""},
+		{"luxi 1.5em", "luxi", "1.50em"},
+		// Numbers within a family name are not a size
+		{"Font Awesome 5 Free 12", "Font Awesome 5 Free 12", ""},
+		{"Infin 3D 12", "Infin 3D 12", ""},
+		{"NaNpt 12", "NaNpt 12", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.font, func(t *testing.T) {
+			f, size, found := splitFontSize(tt.font)
+			if f != tt.wantFont {
+				t.Errorf("font got %q want %q", f, tt.wantFont)
+			}
+			if found != (tt.wantSize != "") || (found && size.String() != tt.wantSize) {
+				t.Errorf("size got %v %v want %q", size, found, tt.wantSize)
+			}
+		})
+	}
+}
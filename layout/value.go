@@ -3,8 +3,8 @@ package layout
 import (
 	"fmt"
 	"github.com/llgcode/draw2d"
-	"github.com/llgcode/draw2d/draw2dimg"
 	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
 	"image"
 )
 
@@ -39,15 +39,22 @@ func (t *Value) Pos(x, y float64) *Value {
 }
 
 func (t *Value) Args(args ...any) *Value {
+	t.animateArgs(t.args)
 	t.args = args
 	t.updateRequired = true
 	return t
 }
 
+// SetArgs implements ArgsSetter
+func (t *Value) SetArgs(args ...any) {
+	t.Args(args...)
+}
+
 func (t *Value) Layout(ctx draw2d.GraphicContext) bool {
-	bounds := t.Bounds()
+	before := t.Bounds()
+	bounds := before
 
-	t.BaseComponent.paint(ctx.(*draw2dimg.GraphicContext), func(gc *draw2dimg.GraphicContext) {
+	t.BaseComponent.paint(draw2d2.Wrap(ctx), func(gc draw2d2.GraphicContext) {
 		lm, rm := t.metrics(gc)
 		if bounds.Dx() == 0 {
 			bounds.Max.X = bounds.Min.X + int(lm.MaxLineWidth) + int(rm.MaxLineWidth)
@@ -57,24 +64,30 @@ func (t *Value) Layout(ctx draw2d.GraphicContext) bool {
 	})
 
 	t.updateRequired = false
-	return true
+	return t.Bounds() != before
 }
 
-func (t *Value) paint(gc *draw2dimg.GraphicContext) {
+func (t *Value) paint(gc draw2d2.GraphicContext) {
 	lm, rm := t.metrics(gc)
+
+	// A single plate behind both the label and value
+	t.effects.DrawPlate(gc, lm.TextBounds().Add(rm.TextBounds()))
+	lm.Effects = t.effects.WithoutPlate()
+	rm.Effects = lm.Effects
+
 	lm.Fill(gc)
 	rm.Fill(gc)
 }
 
-func (t *Value) metrics(gc *draw2dimg.GraphicContext) (*util.AlignmentMetrics, *util.AlignmentMetrics) {
-	bounds := t.Bounds()
+func (t *Value) metrics(gc draw2d2.GraphicContext) (*util.AlignmentMetrics, *util.AlignmentMetrics) {
+	bounds := t.LocalBounds()
 
 	cx := bounds.Dx() >> 1
 
 	offset := 4
 
-	lm := util.RightAlignment.Metrics(gc, image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Min.X+cx-offset, bounds.Max.Y), 2, t.label)
-	rm := util.LeftAlignment.Metrics(gc, image.Rect(bounds.Min.X+cx+offset, bounds.Min.Y, bounds.Max.X, bounds.Max.X), 2, t.format, t.args...)
+	lm := util.RightAlignment.Metrics(gc, image.Rect(bounds.Min.X, bounds.Min.Y, bounds.Min.X+cx-offset, bounds.Max.Y), 2, "%s", t.label)
+	rm := util.LeftAlignment.Metrics(gc, image.Rect(bounds.Min.X+cx+offset, bounds.Min.Y, bounds.Max.X, bounds.Max.X), 2, t.format, t.currentArgs(t.args)...)
 	// Merge so they share some metric data ensuring they line up with each other
 	lm.Merge(rm)
 
@@ -82,5 +95,5 @@ func (t *Value) metrics(gc *draw2dimg.GraphicContext) (*util.AlignmentMetrics, *
 }
 
 func (t *Value) String() string {
-	return fmt.Sprintf(t.format, t.args...)
+	return fmt.Sprintf(t.format, t.currentArgs(t.args)...)
 }
@@ -0,0 +1,140 @@
+package layout
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SetVisible shows or hides the component
+func (c *BaseComponent) SetVisible(visible bool) {
+	if visible {
+		c.Show()
+	} else {
+		c.Hide()
+	}
+}
+
+// ZIndex sets the order the component is drawn within its container.
+// Components with a higher z-index are drawn over those with a lower one, the default is 0.
+// Components with the same z-index are drawn in the order they were added.
+func (c *BaseComponent) ZIndex(z int) {
+	c.zIndex = z
+	c.updateRequired = true
+}
+
+// GetZIndex returns the z-index of the component
+func (c *BaseComponent) GetZIndex() int {
+	return c.zIndex
+}
+
+// Opacity sets the opacity of the component, from 0 transparent to 1 opaque, the default
+func (c *BaseComponent) Opacity(f float64) {
+	c.transparency = 1 - max(0, min(1, f))
+	c.updateRequired = true
+}
+
+// GetOpacity returns the opacity of the component
+func (c *BaseComponent) GetOpacity() float64 {
+	return 1 - c.transparency
+}
+
+// isShown returns true if a component takes part in the layout of its container,
+// which a hidden component does until it has finished disappearing
+func isShown(c Component) bool {
+	if b := baseOf(c); b != nil {
+		return !b.anim.hidden || b.anim.visibility() > 0
+	}
+	if a, ok := c.(Animated); ok {
+		return a.IsShown()
+	}
+	return true
+}
+
+// shown returns the indices of the components which take part in the layout
+func (c *container) shown() []int {
+	var r []int
+	for i, comp := range c.components {
+		if isShown(comp) {
+			r = append(r, i)
+		}
+	}
+	return r
+}
+
+// drawOrder returns the components in the order they are drawn, by z-index
+func (c *container) drawOrder() []Component {
+	r := slices.Clone(c.components)
+	slices.SortStableFunc(r, func(a, b Component) int {
+		return zIndex(a) - zIndex(b)
+	})
+	return r
+}
+
+func zIndex(c Component) int {
+	if b := baseOf(c); b != nil {
+		return b.zIndex
+	}
+	return 0
+}
+
+// Condition tests a value, usually from a bound Series, deciding if a component is shown
+type Condition func(any) bool
+
+// ParseCondition parses a condition comparing a value with a number, e.g. "> 0" or "<= 10.5".
+// The operators are ==, !=, <, <=, > and >=. An empty string is true for any non-zero value.
+func ParseCondition(s string) (Condition, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return func(v any) bool {
+			f, ok := toFloat(reflect.ValueOf(v))
+			if !ok {
+				return v != nil && v != ""
+			}
+			return f != 0
+		}, nil
+	}
+
+	op := strings.TrimRight(s[:min(2, len(s))], "0123456789.-+ ")
+	n, err := strconv.ParseFloat(strings.TrimSpace(s[len(op):]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("condition %q: %w", s, err)
+	}
+
+	var cmp func(float64) bool
+	switch op {
+	case "==", "=":
+		cmp = func(f float64) bool { return f == n }
+	case "!=":
+		cmp = func(f float64) bool { return f != n }
+	case "<":
+		cmp = func(f float64) bool { return f < n }
+	case "<=":
+		cmp = func(f float64) bool { return f <= n }
+	case ">":
+		cmp = func(f float64) bool { return f > n }
+	case ">=":
+		cmp = func(f float64) bool { return f >= n }
+	default:
+		return nil, fmt.Errorf("condition %q: unsupported operator %q", s, op)
+	}
+
+	return func(v any) bool {
+		f, ok := toFloat(reflect.ValueOf(v))
+		return ok && cmp(f)
+	}, nil
+}
+
+// ShowWhen returns a function which shows the component when the first arg passed to it meets the condition,
+// hiding it otherwise. It is usually bound to a Series so the component is shown depending on its value.
+func ShowWhen(c Animated, cond Condition) func(...any) {
+	return func(args ...any) {
+		if len(args) > 0 && cond(args[0]) {
+			c.Show()
+		} else {
+			c.Hide()
+		}
+	}
+}
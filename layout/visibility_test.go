@@ -0,0 +1,104 @@
+package layout
+
+import (
+	"image"
+	"testing"
+)
+
+func TestVisibility_Flex(t *testing.T) {
+	a, b, c := newBox(10, 10), newBox(20, 10), newBox(30, 10)
+	row := FlexRow().Gap(5).AlignItems(AlignStart)
+	row.Add(a)
+	row.Add(b)
+	row.Add(c)
+
+	root := FixedContainer(image.Rect(0, 0, 400, 300))
+	root.Add(row)
+	root.Layout(testContext())
+
+	if got, want := c.Bounds(), image.Rect(40, 0, 70, 10); got != want {
+		t.Errorf("shown got %v want %v", got, want)
+	}
+
+	// Hiding a component removes it and its gap from the layout
+	b.Hide()
+	if !root.IsUpdateRequired() {
+		t.Error("hiding did not require an update")
+	}
+	root.Layout(testContext())
+	if got, want := c.Bounds(), image.Rect(15, 0, 45, 10); got != want {
+		t.Errorf("hidden got %v want %v", got, want)
+	}
+
+	b.Show()
+	root.Layout(testContext())
+	if got, want := c.Bounds(), image.Rect(40, 0, 70, 10); got != want {
+		t.Errorf("reshown got %v want %v", got, want)
+	}
+}
+
+func TestVisibility_Grid(t *testing.T) {
+	a, b, c := newBox(10, 10), newBox(10, 10), newBox(10, 10)
+	cols, _ := ParseTracks("1fr 1fr")
+	grid := NewGridContainer(cols...)
+	grid.Add(a)
+	grid.Add(b)
+	grid.Add(c)
+	a.Hide()
+
+	root := FixedContainer(image.Rect(0, 0, 100, 100))
+	root.Add(grid)
+	root.Layout(testContext())
+
+	// b and c fill the first row
+	if got, want := c.Bounds().Min, image.Pt(50, 0); got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestVisibility_DrawOrder(t *testing.T) {
+	a, b, c := newBox(10, 10), newBox(10, 10), newBox(10, 10)
+	row := FlexRow()
+	row.Add(a)
+	row.Add(b)
+	row.Add(c)
+	a.ZIndex(1)
+	c.ZIndex(-1)
+
+	got := row.drawOrder()
+	if got[0] != c || got[1] != b || got[2] != a {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		cond string
+		v    any
+		want bool
+	}{
+		{cond: "", v: 0.0, want: false},
+		{cond: "", v: 0.2, want: true},
+		{cond: "> 0", v: 1, want: true},
+		{cond: "> 0", v: 0, want: false},
+		{cond: ">=5", v: 5.0, want: true},
+		{cond: "< -1", v: -2, want: true},
+		{cond: "<= 10.5", v: uint(11), want: false},
+		{cond: "== 3", v: 3, want: true},
+		{cond: "!= 3", v: 3, want: false},
+		{cond: "> 0", v: "text", want: false},
+	}
+	for _, tt := range tests {
+		cond, err := ParseCondition(tt.cond)
+		if err != nil {
+			t.Fatalf("%q %v", tt.cond, err)
+		}
+		if got := cond(tt.v); got != tt.want {
+			t.Errorf("%q %v got %v want %v", tt.cond, tt.v, got, tt.want)
+		}
+	}
+
+	if _, err := ParseCondition("~ 3"); err == nil {
+		t.Error("expected error")
+	}
+}
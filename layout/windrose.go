@@ -0,0 +1,192 @@
+package layout
+
+import (
+	"github.com/peter-mount/go-anim/util"
+	color2 "github.com/peter-mount/go-anim/util/color"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/series"
+	"image/color"
+	"math"
+)
+
+// WindRose shows the frequency of wind from each direction, with each petal split by wind speed
+type WindRose struct {
+	chart
+	dirs    []float64     // Direction of each sample in degrees
+	speeds  []float64     // Speed of each sample
+	sectors int           // Number of sectors, usually 8, 16 or 32
+	bins    []float64     // Lower bound of each speed bin
+	colours []color.Color // Colour of each speed bin
+}
+
+var compassLabels = []string{"N", "E", "S", "W"}
+
+func NewWindRose() *WindRose {
+	c := &WindRose{
+		chart:   newChart("WindRose", 1),
+		sectors: 16,
+	}
+	c.format = "%v%%"
+	c.SpeedBins(0, 5, 10, 20)
+	c.BaseComponent.painter = c.paint
+	return c
+}
+
+// Clear removes all samples
+func (c *WindRose) Clear() {
+	c.dirs, c.speeds = nil, nil
+	c.updateRequired = true
+}
+
+// Add a sample of direction in degrees and speed
+func (c *WindRose) Add(dir, speed float64) {
+	c.dirs = append(c.dirs, dir)
+	c.speeds = append(c.speeds, speed)
+	c.updateRequired = true
+}
+
+// SetSeries replaces the samples with those in two Series.
+// Each point in dirs is paired with the value of speeds at the same time.
+func (c *WindRose) SetSeries(dirs, speeds *series.Series) {
+	c.Clear()
+	for _, p := range dirs.Points() {
+		if s, ok := speeds.At(p.Time); ok {
+			c.Add(p.Value, s)
+		}
+	}
+}
+
+// Sectors sets the number of sectors, usually 8, 16 or 32
+func (c *WindRose) Sectors(n int) {
+	c.sectors = max(4, n)
+	c.updateRequired = true
+}
+
+// SpeedBins sets the lower bound of each speed bin. This also resets the bin colours.
+func (c *WindRose) SpeedBins(b ...float64) {
+	c.bins = b
+	from, _ := color2.ParseColour("#2c7bb6")
+	to, _ := color2.ParseColour("#d7191c")
+	if len(b) > 1 {
+		c.colours = color2.Gradient(len(b), from, to)
+	} else {
+		c.colours = []color.Color{from}
+	}
+	c.updateRequired = true
+}
+
+// Colours sets the colour of each speed bin
+func (c *WindRose) Colours(cols ...color.Color) {
+	c.colours = cols
+	c.updateRequired = true
+}
+
+// bin returns the index of the speed bin for a speed
+func (c *WindRose) bin(speed float64) int {
+	b := 0
+	for i, lo := range c.bins {
+		if speed >= lo {
+			b = i
+		}
+	}
+	return b
+}
+
+// frequencies returns the cumulative percentage of samples in each sector for each speed bin
+func (c *WindRose) frequencies() ([][]float64, float64) {
+	nb := max(1, len(c.bins))
+	freq := make([][]float64, c.sectors)
+	for i := range freq {
+		freq[i] = make([]float64, nb)
+	}
+
+	n := len(c.dirs)
+	if n == 0 {
+		return freq, 0
+	}
+
+	sw := 360.0 / float64(c.sectors)
+	for i, d := range c.dirs {
+		// Sectors are centered on their direction, so sector 0 covers -sw/2..sw/2
+		s := int(math.Floor(normalizeAngle(d+sw/2)/sw)) % c.sectors
+		freq[s][c.bin(c.speeds[i])]++
+	}
+
+	mx := 0.0
+	for _, f := range freq {
+		// Make cumulative so each bin's petal includes those below it
+		for b := range f {
+			f[b] = f[b] * 100 / float64(n)
+			if b > 0 {
+				f[b] += f[b-1]
+			}
+		}
+		mx = math.Max(mx, f[nb-1])
+	}
+	return freq, mx
+}
+
+func normalizeAngle(a float64) float64 {
+	a = math.Mod(a, 360)
+	if a < 0 {
+		a += 360
+	}
+	return a
+}
+
+func (c *WindRose) paint(gc draw2d2.GraphicContext) {
+	w, h := c.innerSize()
+	freq, mx := c.frequencies()
+	s := c.scale(0, mx)
+
+	lh := labelHeight(gc)
+	cx, cy := w/2, h/2
+	r := math.Min(w, h)/2 - lh - c.labelSpan
+
+	// Petals, largest first so smaller bins are drawn over them
+	sw := 360.0 / float64(c.sectors)
+	for b := len(freq[0]) - 1; b >= 0; b-- {
+		if b < len(c.colours) {
+			gc.SetFillColor(c.colours[b])
+		}
+		for i, f := range freq {
+			pr := s.Pos(f[b], r)
+			if pr <= 0 {
+				continue
+			}
+			a := float64(i) * sw
+			gc.BeginPath()
+			gc.MoveTo(cx, cy)
+			gc.ArcTo(cx, cy, pr, pr, (a-sw/2-90)*util.ToRad, sw*util.ToRad)
+			gc.Close()
+			gc.Fill()
+		}
+	}
+
+	if c.fill != nil {
+		gc.SetFillColor(c.fill)
+	}
+
+	// Rings with their labels along the north-east diagonal
+	for _, v := range s.Ticks() {
+		if v <= s.Min {
+			continue
+		}
+		rr := s.Pos(v, r)
+		gc.BeginPath()
+		gc.ArcTo(cx, cy, rr, rr, 0, 2*math.Pi)
+		gc.Stroke()
+
+		x, y := compassPoint(cx, cy, 45, rr)
+		drawLabel(gc, c.label(v), x+c.labelSpan, y, util.LeftAlignment)
+	}
+
+	// Spokes with cardinal labels
+	for i, l := range compassLabels {
+		a := float64(i) * 90
+		x, y := compassPoint(cx, cy, a, r)
+		line(gc, cx, cy, x, y)
+		x, y = compassPoint(cx, cy, a, r+c.labelSpan+lh/2)
+		drawLabel(gc, l, x, y, util.CenterAlignment)
+	}
+}
@@ -0,0 +1,96 @@
+package renderer
+
+import (
+	"fmt"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"image/draw"
+	"sort"
+	"sync"
+)
+
+// Backend creates the GraphicContext a Context draws with, so rasterisers other than draw2d can be used.
+// draw2d is the default.
+type Backend interface {
+	// Name of the backend, used to select it with LookupBackend
+	Name() string
+	// NewGraphicContext returns a GraphicContext drawing onto img
+	NewGraphicContext(img draw.Image) draw2d2.GraphicContext
+}
+
+// Draw2dBackend is the name of the default Backend using draw2d
+const Draw2dBackend = "draw2d"
+
+type draw2dBackend struct{}
+
+func (draw2dBackend) Name() string {
+	return Draw2dBackend
+}
+
+func (draw2dBackend) NewGraphicContext(img draw.Image) draw2d2.GraphicContext {
+	return draw2d2.NewImageGraphicContext(img)
+}
+
+var (
+	backendMutex   sync.Mutex
+	backends       = map[string]Backend{Draw2dBackend: draw2dBackend{}}
+	defaultBackend = Draw2dBackend
+)
+
+// RegisterBackend makes a Backend available by name, replacing any of the same name
+func RegisterBackend(b Backend) {
+	backendMutex.Lock()
+	defer backendMutex.Unlock()
+	backends[b.Name()] = b
+}
+
+// LookupBackend returns the named Backend
+func LookupBackend(name string) (Backend, error) {
+	backendMutex.Lock()
+	defer backendMutex.Unlock()
+	if b, exists := backends[name]; exists {
+		return b, nil
+	}
+	return nil, fmt.Errorf("backend %q not found", name)
+}
+
+// Backends returns the names of the registered backends, sorted
+func Backends() []string {
+	backendMutex.Lock()
+	defer backendMutex.Unlock()
+	var names []string
+	for k := range backends {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultBackend returns the Backend used by new contexts
+func DefaultBackend() Backend {
+	backendMutex.Lock()
+	defer backendMutex.Unlock()
+	return backends[defaultBackend]
+}
+
+// SetDefaultBackend sets the Backend used by new contexts
+func SetDefaultBackend(name string) error {
+	backendMutex.Lock()
+	defer backendMutex.Unlock()
+	if _, exists := backends[name]; !exists {
+		return fmt.Errorf("backend %q not found", name)
+	}
+	defaultBackend = name
+	return nil
+}
+
+func (c *context) Backend() Backend {
+	return c.backend
+}
+
+func (c *context) SetBackend(b Backend) Context {
+	if b != nil && b != c.backend {
+		c.backend = b
+		c.Reset()
+	}
+	return c
+}
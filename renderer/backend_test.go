@@ -0,0 +1,63 @@
+package renderer
+
+import (
+	"github.com/llgcode/draw2d/draw2dkit"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+// countingBackend is the draw2d backend recording how many GraphicContexts it has created
+type countingBackend struct {
+	count int
+}
+
+func (b *countingBackend) Name() string {
+	return "counting"
+}
+
+func (b *countingBackend) NewGraphicContext(img draw.Image) draw2d2.GraphicContext {
+	b.count++
+	return draw2d2.NewImageGraphicContext(img)
+}
+
+func TestBackend(t *testing.T) {
+	if DefaultBackend().Name() != Draw2dBackend {
+		t.Fatalf("default backend %q", DefaultBackend().Name())
+	}
+	if _, err := LookupBackend("missing"); err == nil {
+		t.Error("expected error for missing backend")
+	}
+	if err := SetDefaultBackend("missing"); err == nil {
+		t.Error("expected error setting missing backend")
+	}
+
+	b := &countingBackend{}
+	RegisterBackend(b)
+	if lb, err := LookupBackend("counting"); err != nil || lb != b {
+		t.Fatalf("LookupBackend got %v %v", lb, err)
+	}
+
+	ctx := NewContext(10, 10).SetBackend(b)
+	if ctx.Backend() != b || b.count != 1 {
+		t.Fatalf("SetBackend: backend %v count %d", ctx.Backend(), b.count)
+	}
+
+	// Layers and clones use the same backend
+	if l := ctx.NewLayer("a"); l.Backend() != b {
+		t.Error("layer did not inherit backend")
+	}
+	if c := CloneContext(ctx); c.Backend() != b {
+		t.Error("clone did not inherit backend")
+	}
+
+	// Drawing goes through the backend's GraphicContext
+	gc := ctx.Gc()
+	gc.SetFillColor(color.RGBA{R: 255, A: 255})
+	draw2dkit.Rectangle(gc, 0, 0, 10, 10)
+	gc.Fill()
+	if r, _, _, _ := ctx.Image().At(5, 5).RGBA(); r != 0xffff {
+		t.Errorf("pixel not drawn, red %04x", r)
+	}
+}
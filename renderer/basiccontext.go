@@ -1,9 +1,11 @@
 package renderer
 
 import (
-	"github.com/llgcode/draw2d/draw2dimg"
 	"github.com/peter-mount/go-anim/graph"
 	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/goexr/exr"
+	"github.com/peter-mount/go-anim/util/time"
 	"golang.org/x/image/draw"
 	"image"
 )
@@ -15,25 +17,58 @@ func NewContext(width, height int) Context {
 	return NewImageContext(image.NewRGBA(image.Rect(0, 0, width, height)))
 }
 
+// NewRGBA64Context creates a new context drawing at 16 bits per channel
+func NewRGBA64Context(width, height int) Context {
+	return NewImageContext(image.NewRGBA64(image.Rect(0, 0, width, height)))
+}
+
+// NewFloatContext creates a new context drawing onto a float32 exr.RGBAImage,
+// keeping full precision for writing as EXR. The image is in linear space,
+// with colours other than exr.RGBAColor converted from sRGB as they are drawn.
+func NewFloatContext(width, height int) Context {
+	return NewImageContext(exr.NewFloat32(image.Rect(0, 0, width, height)))
+}
+
 func NewImageContext(img draw.Image) Context {
-	ctx := &context{}
+	ctx := newContext()
 	return ctx.SetImage(img)
 }
 
+func newContext() *context {
+	return &context{
+		backend:    DefaultBackend(),
+		userdata:   make(map[string]any),
+		persistent: make(map[string]any),
+	}
+}
+
 type context struct {
-	img      draw.Image                // Image to use for frame generation
-	width    int                       // Width of image
-	height   int                       // Height of image
-	gc       *draw2dimg.GraphicContext // Graphic context
-	userdata map[string]any            // User data
+	img        draw.Image             // Image to use for frame generation
+	width      int                    // Width of image
+	height     int                    // Height of image
+	backend    Backend                // Creates gc
+	gc         draw2d2.GraphicContext // Graphic context
+	userdata   map[string]any         // User data, cleared by BeginFrame
+	persistent map[string]any         // User data kept between frames
+	layers     map[string]Context     // Off-screen layers
+	clips      []*clip                // Active clips, innermost last
+	frame      time.TimeCodeFragment  // TimeCode of the current frame
+	inFrame    bool                   // True between BeginFrame and EndFrame
+	beginHooks []FrameHook            // Called by BeginFrame
+	endHooks   []FrameHook            // Called by EndFrame
 }
 
 func (c *context) Image() draw.Image {
+	if mask := c.clipMask(); mask != nil {
+		return &clippedImage{Image: c.img, mask: mask}
+	}
 	return c.img
 }
 
 func (c *context) SetImage(img draw.Image) Context {
 	c.img = img
+	// Clips apply to the previous image
+	c.clips = nil
 	b := img.Bounds()
 	c.width = b.Dx()
 	c.height = b.Dy()
@@ -47,7 +82,7 @@ func (c *context) NewImage() Context {
 	if c.height == 0 {
 		c.height = util.Height4K
 	}
-	return c.SetImage(image.NewRGBA(image.Rect(0, 0, c.width, c.height)))
+	return c.SetImage(graph.NewImageOf(c.img, image.Rect(0, 0, c.width, c.height)))
 }
 
 func (c *context) Width() int {
@@ -66,7 +101,7 @@ func (c *context) Bounds() util.Rectangle {
 	return util.Rect(0, 0, float64(c.width), float64(c.height))
 }
 
-func (c *context) Gc() *draw2dimg.GraphicContext {
+func (c *context) Gc() draw2d2.GraphicContext {
 	return c.gc
 }
 
@@ -88,6 +123,24 @@ func (c *context) Remove(k string) Context {
 	return c
 }
 
+func (c *context) GetPersistent(k string) any {
+	return c.persistent[k]
+}
+
+func (c *context) SetPersistent(k string, v any) Context {
+	if v == nil {
+		delete(c.persistent, k)
+	} else {
+		c.persistent[k] = v
+	}
+	return c
+}
+
+func (c *context) RemovePersistent(k string) Context {
+	delete(c.persistent, k)
+	return c
+}
+
 // Create from CreateCloser interface, used in try resources block
 // to save and close state in the context
 func (c *context) Create() error {
@@ -104,7 +157,8 @@ func (c *context) Close() error {
 
 func (c *context) Reset() Context {
 	// Reset the Context state
-	c.gc = draw2dimg.NewGraphicContext(c.img)
+	c.gc = c.backend.NewGraphicContext(c.img)
+	c.applyClip()
 	return c
 }
 
@@ -115,11 +169,12 @@ func (c *context) Draw(d Drawable) {
 }
 
 func (c *context) Filter(f graph.Filter) error {
-	return f.DoOver(c.Image())
+	return c.FilterBounds(f, c.img.Bounds())
 }
 
 func (c *context) FilterBounds(f graph.Filter, b image.Rectangle) error {
-	return f.Do(c.Image(), c.Image(), b)
+	// Read the image as-is but write through any clip
+	return f.Do(c.img, c.Image(), b)
 }
 
 func (c *context) Map(m graph.Mapper) error {
@@ -0,0 +1,134 @@
+package renderer
+
+import (
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dkit"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/goexr/exr"
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// clip is a clipping region started by PushClip
+type clip struct {
+	mask *image.Alpha // Coverage of the clip, opaque within it
+}
+
+func (c *context) PushClip() Context {
+	// Start with any enclosing clip so clips nest
+	mask := image.NewAlpha(c.img.Bounds())
+	if n := len(c.clips); n > 0 {
+		copy(mask.Pix, c.clips[n-1].mask.Pix)
+	} else {
+		for i := range mask.Pix {
+			mask.Pix[i] = 0xff
+		}
+	}
+
+	c.clips = append(c.clips, &clip{mask: mask})
+	c.applyClip()
+	return c
+}
+
+func (c *context) PopClip() Context {
+	n := len(c.clips)
+	if n == 0 {
+		return c
+	}
+	c.clips = c.clips[:n-1]
+	c.applyClip()
+	return c
+}
+
+// clipMask returns the mask of the innermost clip, nil if there is none
+func (c *context) clipMask() *image.Alpha {
+	if n := len(c.clips); n > 0 {
+		return c.clips[n-1].mask
+	}
+	return nil
+}
+
+// applyClip limits drawing by gc to the innermost clip, if its GraphicContext supports it
+func (c *context) applyClip() {
+	if m, ok := c.gc.(draw2d2.Masker); ok {
+		m.SetMask(c.clipMask())
+	}
+}
+
+func (c *context) ClipRect(x1, y1, x2, y2 float64) Context {
+	p := &draw2d.Path{}
+	draw2dkit.Rectangle(p, x1, y1, x2, y2)
+	return c.ClipPath(p)
+}
+
+func (c *context) ClipCircle(cx, cy, r float64) Context {
+	p := &draw2d.Path{}
+	draw2dkit.Circle(p, cx, cy, r)
+	return c.ClipPath(p)
+}
+
+func (c *context) Clip() Context {
+	p := c.gc.GetPath()
+	c.gc.BeginPath()
+	return c.ClipPath(&p)
+}
+
+func (c *context) ClipPath(path *draw2d.Path) Context {
+	if len(c.clips) == 0 {
+		c.PushClip()
+	}
+	mask := c.clips[len(c.clips)-1].mask
+
+	shape := draw2d2.FillMask(path, c.gc.GetMatrixTransform(), c.gc.GetFillRule(), mask.Rect)
+	for i, a := range shape.Pix {
+		mask.Pix[i] = uint8(uint32(mask.Pix[i]) * uint32(a) / 0xff)
+	}
+	return c
+}
+
+// clippedImage is a draw.Image which only changes pixels where mask has coverage,
+// blending across its anti-aliased edges
+type clippedImage struct {
+	draw.Image
+	mask *image.Alpha
+}
+
+func (i *clippedImage) Set(x, y int, c color.Color) {
+	if !(image.Point{X: x, Y: y}).In(i.mask.Rect) {
+		return
+	}
+
+	a := i.mask.Pix[i.mask.PixOffset(x, y)]
+	switch a {
+	case 0:
+		return
+	case 0xff:
+		i.Image.Set(x, y, c)
+		return
+	}
+
+	// Blend with what is there, keeping the precision of floating point images
+	if img, ok := i.Image.(*exr.RGBAImage); ok {
+		f := float32(a) / 0xff
+		c0 := img.At(x, y).(exr.RGBAColor)
+		c1 := exr.RGBAModel.Convert(c).(exr.RGBAColor)
+		img.Set(x, y, exr.RGBAColor{
+			R: c0.R*(1-f) + c1.R*f,
+			G: c0.G*(1-f) + c1.G*f,
+			B: c0.B*(1-f) + c1.B*f,
+			A: c0.A*(1-f) + c1.A*f,
+		})
+		return
+	}
+
+	m := uint32(a)
+	r0, g0, b0, a0 := i.Image.At(x, y).RGBA()
+	r1, g1, b1, a1 := c.RGBA()
+	i.Image.Set(x, y, color.RGBA64{
+		R: uint16((r0*(0xff-m) + r1*m) / 0xff),
+		G: uint16((g0*(0xff-m) + g1*m) / 0xff),
+		B: uint16((b0*(0xff-m) + b1*m) / 0xff),
+		A: uint16((a0*(0xff-m) + a1*m) / 0xff),
+	})
+}
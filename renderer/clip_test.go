@@ -0,0 +1,132 @@
+package renderer
+
+import (
+	"github.com/llgcode/draw2d/draw2dkit"
+	"github.com/peter-mount/go-anim/graph"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+// solid returns an image covering r filled with c
+func solid(c color.Color, r image.Rectangle) *image.RGBA {
+	img := image.NewRGBA(r)
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+	return img
+}
+
+func TestContext_Clip(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+
+	ctx := NewContext(100, 100)
+	img := ctx.Image().(*image.RGBA)
+	ctx.PushClip().
+		ClipRect(10, 10, 90, 90).
+		ClipCircle(50, 50, 30)
+
+	// A shape and an image drawn with the GraphicContext are both clipped
+	gc := ctx.Gc()
+	gc.SetFillColor(red)
+	draw2dkit.Rectangle(gc, 0, 0, 100, 50)
+	gc.Fill()
+	gc.DrawImage(solid(red, image.Rect(0, 50, 100, 100)))
+
+	// Nothing outside the clip is drawn, even before PopClip
+	if got := img.RGBAAt(5, 5); got != (color.RGBA{}) {
+		t.Errorf("drawn outside clip before PopClip, got %v", got)
+	}
+
+	ctx.PopClip()
+
+	tests := []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{50, 50, red},
+		{50, 25, red},
+		{50, 75, red},
+		// Outside the circle
+		{5, 5, color.RGBA{}},
+		{15, 15, color.RGBA{}},
+		{50, 85, color.RGBA{}},
+	}
+	for _, test := range tests {
+		if got := img.RGBAAt(test.x, test.y); got != test.want {
+			t.Errorf("at %d,%d got %v want %v", test.x, test.y, got, test.want)
+		}
+	}
+
+	// The edge is anti-aliased
+	if a := img.RGBAAt(71, 71).A; a == 0 || a == 0xff {
+		t.Errorf("edge alpha %d", a)
+	}
+}
+
+func TestContext_ClipNested(t *testing.T) {
+	ctx := NewContext(100, 100)
+	gc := ctx.Gc()
+	gc.SetFillColor(color.White)
+
+	ctx.ClipRect(0, 0, 60, 100)
+	ctx.PushClip().ClipRect(40, 0, 100, 100)
+	gc.Clear()
+	ctx.PopClip()
+
+	// Drawing after the inner clip is limited by the outer one
+	gc.ClearRect(0, 0, 10, 100)
+	gc.ClearRect(90, 0, 100, 100)
+	ctx.PopClip()
+
+	img := ctx.Image().(*image.RGBA)
+	for x, want := range map[int]uint8{5: 0xff, 30: 0, 50: 0xff, 70: 0, 95: 0} {
+		if got := img.RGBAAt(x, 50).A; got != want {
+			t.Errorf("at %d got %d want %d", x, got, want)
+		}
+	}
+}
+
+func TestContext_ClipComposite(t *testing.T) {
+	// Composite is clipped, whatever the type of image
+	for _, ctx := range []Context{NewContext(100, 100), NewRGBA64Context(100, 100), NewFloatContext(100, 100)} {
+		ctx.ClipRect(0, 0, 50, 100)
+		ctx.Composite(solid(color.White, ctx.Image().Bounds()), 1, graph.BlendNormal, nil)
+		ctx.PopClip()
+
+		img := ctx.Image()
+		if _, _, _, a := img.At(25, 50).RGBA(); a != 0xffff {
+			t.Errorf("%T inside clip alpha %d", img, a)
+		}
+		if _, _, _, a := img.At(75, 50).RGBA(); a != 0 {
+			t.Errorf("%T outside clip alpha %d", img, a)
+		}
+	}
+}
+
+func TestContext_ClipImage(t *testing.T) {
+	white := func(_, _ int, _ color.Color) (color.Color, error) {
+		return color.White, nil
+	}
+
+	// Images drawn onto Image() and filters are clipped, whatever the type of image
+	for _, ctx := range []Context{NewContext(100, 100), NewRGBA64Context(100, 100), NewFloatContext(100, 100)} {
+		ctx.ClipRect(0, 0, 50, 100)
+		draw.Draw(ctx.Image(), image.Rect(0, 0, 100, 50), image.White, image.Point{}, draw.Src)
+		if err := ctx.Filter(white); err != nil {
+			t.Fatal(err)
+		}
+		ctx.PopClip()
+
+		img := ctx.Image()
+		for _, p := range []image.Point{{25, 25}, {25, 75}} {
+			if _, _, _, a := img.At(p.X, p.Y).RGBA(); a != 0xffff {
+				t.Errorf("%T inside clip at %v alpha %d", img, p, a)
+			}
+		}
+		for _, p := range []image.Point{{75, 25}, {75, 75}} {
+			if _, _, _, a := img.At(p.X, p.Y).RGBA(); a != 0 {
+				t.Errorf("%T outside clip at %v alpha %d", img, p, a)
+			}
+		}
+	}
+}
@@ -2,26 +2,31 @@ package renderer
 
 import (
 	"errors"
-	"github.com/llgcode/draw2d/draw2dimg"
 	"github.com/peter-mount/go-anim/graph"
 )
 
 func CloneContext(ctx Context) Context {
 	if c, ok := ctx.(*context); ok {
-		n := &context{
-			width:    c.width,
-			height:   c.height,
-			userdata: make(map[string]any),
-		}
+		n := newContext()
+		n.backend = c.backend
+		n.width = c.width
+		n.height = c.height
+		n.frame = c.frame
+		n.inFrame = c.inFrame
+		n.beginHooks = append(n.beginHooks, c.beginHooks...)
+		n.endHooks = append(n.endHooks, c.endHooks...)
 
-		// Copy the image as-is
-		n.img = graph.DuplicateImage(c.Image())
+		// Copy the image as-is, keeping its precision
+		n.img = graph.CopyImage(c.img)
 
-		n.gc = draw2dimg.NewGraphicContext(n.img)
+		n.gc = n.backend.NewGraphicContext(n.img)
 
 		for k, v := range c.userdata {
 			n.userdata[k] = v
 		}
+		for k, v := range c.persistent {
+			n.persistent[k] = v
+		}
 
 		return n
 	}
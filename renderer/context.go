@@ -1,14 +1,17 @@
 package renderer
 
 import (
-	"github.com/llgcode/draw2d/draw2dimg"
+	"github.com/llgcode/draw2d"
 	"github.com/peter-mount/go-anim/graph"
 	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/time"
 	"golang.org/x/image/draw"
 	"image"
 )
 
 type Context interface {
+	// Image returns the image drawn onto. While a clip is active, pixels set on it outside the clip are left unchanged.
 	Image() draw.Image
 	SetImage(draw.Image) Context
 	NewImage() Context
@@ -20,17 +23,46 @@ type Context interface {
 	Bounds() util.Rectangle
 	// Center coordinates of the Image
 	Center() (float64, float64)
-	// Gc draw2dimg.GraphicContext
-	Gc() *draw2dimg.GraphicContext
+	// Gc returns the GraphicContext drawing onto the image
+	Gc() draw2d2.GraphicContext
+	// Backend returns the Backend creating Gc
+	Backend() Backend
+	// SetBackend changes the Backend, resetting Gc
+	SetBackend(b Backend) Context
 	// Get returns a named user object, used in keeping state.
-	// This is cleared at the start of each frame
+	// This is cleared at the start of each frame by BeginFrame
 	Get(string) any
 	// Set allows for a user object to be stored for retrieval with Get().
 	// This allows for storing information during a frame's rendering.
-	// This is cleared at the start of each frame
+	// This is cleared at the start of each frame by BeginFrame
 	Set(string, any) Context
 	// Remove removes a key from the user object storage
 	Remove(k string) Context
+	// GetPersistent returns a named user object which is kept between frames
+	GetPersistent(string) any
+	// SetPersistent stores a user object for retrieval with GetPersistent(), kept between frames
+	SetPersistent(string, any) Context
+	// RemovePersistent removes a key from the persistent user object storage
+	RemovePersistent(k string) Context
+	// BeginFrame starts rendering the frame with the given TimeCode.
+	// It clears the per-frame user objects then calls the hooks registered with OnBeginFrame.
+	// If a hook fails the context is left as it was before the call.
+	BeginFrame(tc time.TimeCodeFragment) error
+	// EndFrame finishes the current frame, ending any clips still active then calling the hooks
+	// registered with OnEndFrame, e.g. to draw overlays. It does nothing if no frame has begun.
+	EndFrame() error
+	// InFrame returns true between BeginFrame and EndFrame
+	InFrame() bool
+	// Frame returns the TimeCode of the current or last frame
+	Frame() time.TimeCodeFragment
+	// OnBeginFrame registers a hook to call at the start of every frame
+	OnBeginFrame(h FrameHook) Context
+	// OnEndFrame registers a hook to call at the end of every frame
+	OnEndFrame(h FrameHook) Context
+	// Overlay registers a Drawable to draw at the end of every frame, over everything else
+	Overlay(d Drawable) Context
+	// ClearFrameHooks removes all hooks and overlays
+	ClearFrameHooks() Context
 	Create() error
 	Close() error
 	Reset() Context
@@ -40,6 +72,38 @@ type Context interface {
 	Map(m graph.Mapper) error
 	MapBounds(m graph.Mapper, b image.Rectangle) error
 
+	// NewLayer creates a transparent off-screen layer the same size as this Context, replacing any layer
+	// of the same name. Draw into the returned Context then composite it with CompositeLayer.
+	NewLayer(name string) Context
+	// Layer returns the named layer, nil if there is none
+	Layer(name string) Context
+	// RemoveLayer discards the named layer
+	RemoveLayer(name string) Context
+	// LayerNames returns the names of the layers, sorted
+	LayerNames() []string
+	// CompositeLayer draws the named layer over the image with an opacity and blend mode.
+	// If mask is not nil then its alpha limits where the layer is drawn.
+	// The layer is kept so it can be composited again.
+	CompositeLayer(name string, opacity float64, mode graph.BlendMode, mask image.Image) error
+	// Composite draws an image over this one with an opacity, blend mode and optional mask as with CompositeLayer
+	Composite(img image.Image, opacity float64, mode graph.BlendMode, mask image.Image) Context
+
+	// PushClip starts limiting drawing to a clip, initially that of any enclosing clip.
+	// Narrow it with ClipRect, ClipCircle, ClipPath or Clip. The clip applies, with anti-aliased edges,
+	// to everything drawn with Gc(), Composite, Filter and Map, and to anything drawn onto Image() while it is active.
+	// Gc() only clips if its backend's GraphicContext implements draw2d.Masker.
+	PushClip() Context
+	// PopClip ends the clip started by the last PushClip
+	PopClip() Context
+	// ClipRect intersects the clip with a rectangle in the current coordinates, calling PushClip if no clip is active
+	ClipRect(x1, y1, x2, y2 float64) Context
+	// ClipCircle intersects the clip with a circle in the current coordinates, calling PushClip if no clip is active
+	ClipCircle(cx, cy, r float64) Context
+	// ClipPath intersects the clip with a path in the current coordinates, calling PushClip if no clip is active
+	ClipPath(path *draw2d.Path) Context
+	// Clip intersects the clip with the current path of Gc(), clearing it, calling PushClip if no clip is active
+	Clip() Context
+
 	Rotate(angle float64) Context
 	Translate(tx, ty float64) Context
 	Scale(sx, sy float64) Context
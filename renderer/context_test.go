@@ -0,0 +1,101 @@
+package renderer
+
+import (
+	"github.com/llgcode/draw2d/draw2dkit"
+	"github.com/peter-mount/go-anim/util/goexr/exr"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestNewRGBA64Context(t *testing.T) {
+	want := color.RGBA64{R: 0x1234, G: 0x5678, B: 0x9abc, A: 0xffff}
+	ctx := NewRGBA64Context(10, 10)
+	ctx.Gc().SetFillColor(want)
+	draw2dkit.Rectangle(ctx.Gc(), 0, 0, 10, 10)
+	ctx.Gc().Fill()
+
+	// Clones, layers and clips all keep 16 bits
+	c := CloneContext(ctx)
+	img, ok := c.Image().(*image.RGBA64)
+	if !ok {
+		t.Fatalf("clone is %T", c.Image())
+	}
+	if got := img.RGBA64At(5, 5); got != want {
+		t.Errorf("clone got %v want %v", got, want)
+	}
+	if l := ctx.NewLayer("a"); l.Image().Bounds() != ctx.Image().Bounds() {
+		t.Error("layer bounds differ")
+	} else if _, ok := l.Image().(*image.RGBA64); !ok {
+		t.Errorf("layer is %T", l.Image())
+	}
+
+	ctx.ClipRect(0, 0, 5, 10)
+	ctx.Gc().SetFillColor(color.Black)
+	draw2dkit.Rectangle(ctx.Gc(), 0, 0, 10, 10)
+	ctx.Gc().Fill()
+	ctx.PopClip()
+	if got := ctx.Image().(*image.RGBA64).RGBA64At(7, 5); got != want {
+		t.Errorf("outside clip got %v want %v", got, want)
+	}
+}
+
+func TestNewFloatContext(t *testing.T) {
+	want := exr.RGBAColor{R: 4, G: 0.5, B: 0.125, A: 1}
+	ctx := NewFloatContext(10, 10)
+	ctx.Gc().SetFillColor(want)
+	draw2dkit.Rectangle(ctx.Gc(), 0, 0, 10, 10)
+	ctx.Gc().Fill()
+
+	c := CloneContext(ctx)
+	if got, ok := c.Image().At(5, 5).(exr.RGBAColor); !ok || got != want {
+		t.Errorf("clone got %v want %v", c.Image().At(5, 5), want)
+	}
+
+	ctx.ClipRect(0, 0, 5, 10)
+	ctx.Gc().SetFillColor(color.Black)
+	draw2dkit.Rectangle(ctx.Gc(), 0, 0, 10, 10)
+	ctx.Gc().Fill()
+	ctx.PopClip()
+	if got := ctx.Image().At(7, 5).(exr.RGBAColor); got != want {
+		t.Errorf("outside clip got %v want %v", got, want)
+	}
+}
+
+func TestNewFloatContext_MatchesRGBA64(t *testing.T) {
+	// The same frame drawn in floating point is the 16-bit one in linear space
+	draw := func(ctx Context) {
+		gc := ctx.Gc()
+		gc.SetFillColor(color.RGBA{R: 0x80, G: 0x80, B: 0x80, A: 0xff})
+		gc.Clear()
+
+		for i, c := range []color.Color{
+			color.RGBA{R: 0xff, A: 0xff},
+			color.RGBA{R: 0x20, G: 0x60, B: 0xc0, A: 0xff},
+			color.RGBA64{R: 0x1234, G: 0x5678, B: 0x9abc, A: 0xffff},
+		} {
+			gc.SetFillColor(c)
+			draw2dkit.Rectangle(gc, float64(i*3), 0, float64(i*3+3), 5)
+			gc.Fill()
+		}
+
+		gc.DrawImage(solid(color.RGBA{G: 0xff, A: 0xff}, image.Rect(0, 5, 10, 10)))
+	}
+
+	ctx16, ctxF := NewRGBA64Context(10, 10), NewFloatContext(10, 10)
+	draw(ctx16)
+	draw(ctxF)
+
+	const tolerance = 1e-3
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			want := exr.RGBAModel.Convert(ctx16.Image().At(x, y)).(exr.RGBAColor)
+			got := ctxF.Image().At(x, y).(exr.RGBAColor)
+			for i, d := range []float32{got.R - want.R, got.G - want.G, got.B - want.B, got.A - want.A} {
+				if d < -tolerance || d > tolerance {
+					t.Errorf("at %d,%d channel %d got %v want %v", x, y, i, got, want)
+				}
+			}
+		}
+	}
+}
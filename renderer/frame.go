@@ -0,0 +1,109 @@
+package renderer
+
+import (
+	"github.com/peter-mount/go-anim/util/time"
+)
+
+// FrameHook is called at the start or end of every frame of a Context
+type FrameHook interface {
+	Frame(ctx Context, tc time.TimeCodeFragment) error
+}
+
+// FrameHookFunc is a function implementing FrameHook
+type FrameHookFunc func(ctx Context, tc time.TimeCodeFragment) error
+
+func (f FrameHookFunc) Frame(ctx Context, tc time.TimeCodeFragment) error {
+	return f(ctx, tc)
+}
+
+// overlay is a FrameHook drawing a Drawable
+type overlay struct {
+	d Drawable
+}
+
+func (o overlay) Frame(ctx Context, _ time.TimeCodeFragment) error {
+	ctx.Draw(o.d)
+	return nil
+}
+
+func (c *context) BeginFrame(tc time.TimeCodeFragment) error {
+	// Clips left by a frame which was never ended do not carry over
+	for len(c.clips) > 0 {
+		c.PopClip()
+	}
+
+	userdata, frame, inFrame := c.userdata, c.frame, c.inFrame
+	c.userdata = make(map[string]any)
+	c.frame = tc
+	c.inFrame = true
+
+	if err := c.callHooks(c.beginHooks); err != nil {
+		// Roll back so the failed frame leaves the context as it was
+		for len(c.clips) > 0 {
+			c.PopClip()
+		}
+		c.userdata, c.frame, c.inFrame = userdata, frame, inFrame
+		return err
+	}
+	return nil
+}
+
+func (c *context) EndFrame() error {
+	if !c.inFrame {
+		return nil
+	}
+	c.inFrame = false
+
+	for len(c.clips) > 0 {
+		c.PopClip()
+	}
+	return c.callHooks(c.endHooks)
+}
+
+func (c *context) callHooks(hooks []FrameHook) error {
+	for _, h := range hooks {
+		// Each hook starts with the state left by the frame and cannot change it for those following
+		c.gc.Save()
+		err := h.Frame(c, c.frame)
+		c.gc.Restore()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *context) InFrame() bool {
+	return c.inFrame
+}
+
+func (c *context) Frame() time.TimeCodeFragment {
+	return c.frame
+}
+
+func (c *context) OnBeginFrame(h FrameHook) Context {
+	if h != nil {
+		c.beginHooks = append(c.beginHooks, h)
+	}
+	return c
+}
+
+func (c *context) OnEndFrame(h FrameHook) Context {
+	if h != nil {
+		c.endHooks = append(c.endHooks, h)
+	}
+	return c
+}
+
+func (c *context) Overlay(d Drawable) Context {
+	if d != nil {
+		c.endHooks = append(c.endHooks, overlay{d: d})
+	}
+	return c
+}
+
+func (c *context) ClearFrameHooks() Context {
+	c.beginHooks = nil
+	c.endHooks = nil
+	return c
+}
@@ -0,0 +1,102 @@
+package renderer
+
+import (
+	"errors"
+	"github.com/peter-mount/go-anim/util/time"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestContext_UserData(t *testing.T) {
+	// Set must work on a context created from an image
+	ctx := NewImageContext(image.NewRGBA(image.Rect(0, 0, 10, 10)))
+	ctx.Set("frame", 1).SetPersistent("total", 2)
+
+	if err := ctx.BeginFrame(time.NewTimeCode(25).TimeCode()); err != nil {
+		t.Fatal(err)
+	}
+	if v := ctx.Get("frame"); v != nil {
+		t.Errorf("per-frame data kept, got %v", v)
+	}
+	if v := ctx.GetPersistent("total"); v != 2 {
+		t.Errorf("persistent data got %v", v)
+	}
+}
+
+type fill color.RGBA
+
+func (f fill) Draw(ctx Context) {
+	ctx.Image().Set(0, 0, color.RGBA(f))
+}
+
+func TestContext_Frame(t *testing.T) {
+	tc := time.NewTimeCode(25)
+	tc.Next()
+
+	var calls []string
+	hook := func(name string) FrameHook {
+		return FrameHookFunc(func(ctx Context, f time.TimeCodeFragment) error {
+			if !f.Equals(tc.TimeCode()) {
+				t.Errorf("%s got %s want %s", name, f.TimeCode(), tc.TimeCode().TimeCode())
+			}
+			calls = append(calls, name)
+			return nil
+		})
+	}
+
+	red := color.RGBA{R: 255, A: 255}
+	ctx := NewContext(10, 10).
+		OnBeginFrame(hook("begin")).
+		OnEndFrame(hook("end")).
+		Overlay(fill(red))
+
+	if err := ctx.BeginFrame(tc.TimeCode()); err != nil {
+		t.Fatal(err)
+	}
+	if !ctx.InFrame() {
+		t.Error("not in frame")
+	}
+
+	// An unclosed clip is ended before the overlay is drawn
+	ctx.ClipRect(5, 5, 10, 10)
+	if err := ctx.EndFrame(); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.InFrame() {
+		t.Error("still in frame")
+	}
+	if got := ctx.Image().At(0, 0); got != red {
+		t.Errorf("overlay not drawn, got %v", got)
+	}
+	if len(calls) != 2 || calls[0] != "begin" || calls[1] != "end" {
+		t.Errorf("calls %v", calls)
+	}
+
+	// Ending again does nothing
+	if err := ctx.EndFrame(); err != nil || len(calls) != 2 {
+		t.Errorf("second EndFrame err %v calls %v", err, calls)
+	}
+
+	// Errors from hooks are returned and the frame is rolled back
+	last := ctx.Frame()
+	ctx.Set("kept", 1)
+	tc.Next()
+	ctx.ClearFrameHooks().OnBeginFrame(FrameHookFunc(func(ctx Context, _ time.TimeCodeFragment) error {
+		ctx.Set("partial", 1)
+		ctx.ClipRect(0, 0, 5, 5)
+		return errors.New("failed")
+	}))
+	if err := ctx.BeginFrame(tc.TimeCode()); err == nil {
+		t.Error("expected error")
+	}
+	if ctx.InFrame() {
+		t.Error("in frame after failed BeginFrame")
+	}
+	if !ctx.Frame().Equals(last) {
+		t.Errorf("frame got %s want %s", ctx.Frame().TimeCode(), last.TimeCode())
+	}
+	if ctx.Get("partial") != nil || ctx.Get("kept") != 1 {
+		t.Errorf("userdata not rolled back, partial %v kept %v", ctx.Get("partial"), ctx.Get("kept"))
+	}
+}
@@ -0,0 +1,70 @@
+package renderer
+
+import (
+	"fmt"
+	"github.com/peter-mount/go-anim/graph"
+	"image"
+	"sort"
+)
+
+func (c *context) NewLayer(name string) Context {
+	if c.layers == nil {
+		c.layers = make(map[string]Context)
+	}
+	l := NewImageContext(graph.NewImageOf(c.img, c.img.Bounds())).SetBackend(c.backend)
+	c.layers[name] = l
+	return l
+}
+
+func (c *context) Layer(name string) Context {
+	return c.layers[name]
+}
+
+func (c *context) RemoveLayer(name string) Context {
+	delete(c.layers, name)
+	return c
+}
+
+func (c *context) LayerNames() []string {
+	var names []string
+	for k := range c.layers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *context) CompositeLayer(name string, opacity float64, mode graph.BlendMode, mask image.Image) error {
+	l, exists := c.layers[name]
+	if !exists {
+		return fmt.Errorf("layer %q not found", name)
+	}
+	c.Composite(l.Image(), opacity, mode, mask)
+	return nil
+}
+
+func (c *context) Composite(img image.Image, opacity float64, mode graph.BlendMode, mask image.Image) Context {
+	graph.Composite(c.img, img, c.withClip(mask), opacity, mode)
+	return c
+}
+
+// withClip returns mask limited to the innermost clip
+func (c *context) withClip(mask image.Image) image.Image {
+	clipMask := c.clipMask()
+	switch {
+	case clipMask == nil:
+		return mask
+	case mask == nil:
+		return clipMask
+	}
+
+	b := clipMask.Rect.Intersect(mask.Bounds())
+	m := image.NewAlpha(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			_, _, _, a := mask.At(x, y).RGBA()
+			m.Pix[m.PixOffset(x, y)] = uint8(uint32(clipMask.Pix[clipMask.PixOffset(x, y)]) * a / 0xffff)
+		}
+	}
+	return m
+}
@@ -0,0 +1,67 @@
+package renderer
+
+import (
+	"github.com/peter-mount/go-anim/graph"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func TestContext_CompositeLayer(t *testing.T) {
+	grey := color.RGBA{R: 128, G: 128, B: 128, A: 255}
+	tests := []struct {
+		name    string
+		mode    graph.BlendMode
+		opacity float64
+		mask    bool
+		want    color.RGBA // Centre pixel
+		edge    color.RGBA // Pixel outside the mask
+	}{
+		{"normal", graph.BlendNormal, 1, false, color.RGBA{R: 255, A: 255}, color.RGBA{R: 255, A: 255}},
+		{"half", graph.BlendNormal, 0.5, false, color.RGBA{R: 192, G: 64, B: 64, A: 255}, color.RGBA{R: 192, G: 64, B: 64, A: 255}},
+		{"multiply", graph.BlendMultiply, 1, false, color.RGBA{R: 128, A: 255}, color.RGBA{R: 128, A: 255}},
+		{"screen", graph.BlendScreen, 1, false, color.RGBA{R: 255, G: 128, B: 128, A: 255}, color.RGBA{R: 255, G: 128, B: 128, A: 255}},
+		{"mask", graph.BlendNormal, 1, true, color.RGBA{R: 255, A: 255}, grey},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+			draw.Draw(img, img.Bounds(), image.NewUniform(grey), image.Point{}, draw.Src)
+			ctx := NewImageContext(img)
+
+			l := ctx.NewLayer("red")
+			draw.Draw(l.Image(), l.Image().Bounds(), image.NewUniform(color.RGBA{R: 255, A: 255}), image.Point{}, draw.Src)
+
+			var mask image.Image
+			if test.mask {
+				m := image.NewAlpha(img.Bounds())
+				draw.Draw(m, image.Rect(3, 3, 7, 7), image.Opaque, image.Point{}, draw.Src)
+				mask = m
+			}
+
+			if err := ctx.CompositeLayer("red", test.opacity, test.mode, mask); err != nil {
+				t.Fatal(err)
+			}
+			if got := img.RGBAAt(5, 5); !near(got, test.want) {
+				t.Errorf("centre got %v want %v", got, test.want)
+			}
+			if got := img.RGBAAt(0, 0); !near(got, test.edge) {
+				t.Errorf("edge got %v want %v", got, test.edge)
+			}
+		})
+	}
+
+	ctx := NewContext(10, 10)
+	if err := ctx.CompositeLayer("missing", 1, graph.BlendNormal, nil); err == nil {
+		t.Error("expected error for missing layer")
+	}
+}
+
+// near returns true if two colours differ by at most 1 in each channel, allowing for rounding
+func near(a, b color.RGBA) bool {
+	d := func(x, y uint8) bool {
+		return max(x, y)-min(x, y) <= 1
+	}
+	return d(a.R, b.R) && d(a.G, b.G) && d(a.B, b.B) && d(a.A, b.A)
+}
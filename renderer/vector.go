@@ -0,0 +1,151 @@
+package renderer
+
+import (
+	"fmt"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/llgcode/draw2d/draw2dsvg"
+	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/font"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// VectorFormat is the document format written by a VectorContext
+type VectorFormat int
+
+const (
+	VectorSVG VectorFormat = iota
+	VectorPDF
+)
+
+// VectorFormatOf returns the VectorFormat for a file name based on its extension, ".svg" or ".pdf"
+func VectorFormatOf(fileName string) (VectorFormat, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".svg":
+		return VectorSVG, nil
+	case ".pdf":
+		return VectorPDF, nil
+	default:
+		return 0, fmt.Errorf("unsupported vector format %q", fileName)
+	}
+}
+
+// VectorContext draws a frame as vectors rather than pixels, so it can be written as an SVG or PDF
+// document which stays sharp when printed at any size. One pixel of a Context is one point in the document.
+type VectorContext struct {
+	format VectorFormat
+	width  int
+	height int
+	gc     draw2d2.GraphicContext
+	svg    *draw2dsvg.Svg
+	pdf    *gofpdf.Fpdf
+}
+
+// NewVectorContext creates a VectorContext of the given size
+func NewVectorContext(format VectorFormat, width, height int) *VectorContext {
+	c := &VectorContext{format: format, width: width, height: height}
+	return c.Reset()
+}
+
+func NewSvgContext(width, height int) *VectorContext {
+	return NewVectorContext(VectorSVG, width, height)
+}
+
+func NewPdfContext(width, height int) *VectorContext {
+	return NewVectorContext(VectorPDF, width, height)
+}
+
+// Reset discards everything drawn, starting a new document
+func (c *VectorContext) Reset() *VectorContext {
+	c.svg, c.pdf = nil, nil
+	switch c.format {
+	case VectorPDF:
+		c.pdf = gofpdf.NewCustom(&gofpdf.InitType{
+			UnitStr: "pt",
+			Size:    gofpdf.SizeType{Wd: float64(c.width), Ht: float64(c.height)},
+		})
+		c.pdf.SetMargins(0, 0, 0)
+		c.pdf.SetAutoPageBreak(false, 0)
+		c.pdf.AddPage()
+		// draw2dpdf sets the font size when restoring state, which needs a font to be valid. Text itself is drawn as paths.
+		c.pdf.SetFont("Helvetica", "", 12)
+		c.gc = draw2d2.Wrap(newPdfContext(c.pdf))
+	default:
+		c.svg = draw2dsvg.NewSvg()
+		c.svg.Width = fmt.Sprintf("%dpx", c.width)
+		c.svg.Height = fmt.Sprintf("%dpx", c.height)
+		c.svg.ViewBox = fmt.Sprintf("0 0 %d %d", c.width, c.height)
+		c.gc = draw2d2.Wrap(draw2dsvg.NewGraphicContext(c.svg))
+	}
+	return c
+}
+
+func (c *VectorContext) Format() VectorFormat {
+	return c.format
+}
+
+func (c *VectorContext) Width() int {
+	return c.width
+}
+
+func (c *VectorContext) Height() int {
+	return c.height
+}
+
+func (c *VectorContext) Center() (float64, float64) {
+	return float64(c.width) / 2, float64(c.height) / 2
+}
+
+func (c *VectorContext) Bounds() util.Rectangle {
+	return util.Rect(0, 0, float64(c.width), float64(c.height))
+}
+
+// Gc returns the GraphicContext drawing into the document, usable with anything drawing onto a Context's Gc()
+func (c *VectorContext) Gc() draw2d2.GraphicContext {
+	return c.gc
+}
+
+// SetFont sets the font used for text
+func (c *VectorContext) SetFont(f font.Font) *VectorContext {
+	c.gc.SetFontData(f.FontData())
+	c.gc.SetFontSize(f.Size())
+	return c
+}
+
+// Create from CreateCloser interface, used in try resources block
+// to save and close state in the context
+func (c *VectorContext) Create() error {
+	c.gc.Save()
+	return nil
+}
+
+// Close from CreateCloser interface, used in try resources block
+// to save and close state in the context
+func (c *VectorContext) Close() error {
+	c.gc.Restore()
+	return nil
+}
+
+// Write writes the document to w
+func (c *VectorContext) Write(w io.Writer) error {
+	if c.pdf != nil {
+		return c.pdf.Output(w)
+	}
+	return draw2dsvg.WriteSvg(w, c.svg)
+}
+
+// Save writes the document to a file
+func (c *VectorContext) Save(fileName string) error {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	err = c.Write(f)
+	if err1 := f.Close(); err == nil {
+		err = err1
+	}
+	return err
+}
@@ -0,0 +1,56 @@
+package renderer
+
+import (
+	"bytes"
+	"github.com/llgcode/draw2d/draw2dkit"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestVectorFormatOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    VectorFormat
+		wantErr bool
+	}{
+		{"card.svg", VectorSVG, false},
+		{"card.PDF", VectorPDF, false},
+		{"card.png", 0, true},
+	}
+	for _, test := range tests {
+		got, err := VectorFormatOf(test.name)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf("%q got %v %v", test.name, got, err)
+		}
+	}
+}
+
+func TestVectorContext_Write(t *testing.T) {
+	tests := []struct {
+		format VectorFormat
+		want   string
+	}{
+		{VectorSVG, `<path d="M 10,10 L 90,10 L 90,40 L 10,40 Z">`},
+		{VectorPDF, "%PDF-"},
+	}
+	for _, test := range tests {
+		c := NewVectorContext(test.format, 100, 50)
+		gc := c.Gc()
+		gc.SetFillColor(color.White)
+		// The state is available to helpers drawing with a draw2d.GraphicContext
+		if gc.GetFillColor() != color.White {
+			t.Errorf("format %d fill colour %v", test.format, gc.GetFillColor())
+		}
+		draw2dkit.Rectangle(gc, 10, 10, 90, 40)
+		gc.Fill()
+
+		var buf bytes.Buffer
+		if err := c.Write(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), test.want) {
+			t.Errorf("format %d missing %q", test.format, test.want)
+		}
+	}
+}
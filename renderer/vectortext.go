@@ -0,0 +1,111 @@
+package renderer
+
+import (
+	"github.com/golang/freetype/truetype"
+	"github.com/jung-kurt/gofpdf"
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dpdf"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"image"
+)
+
+// pdfContext draws text on a PDF as glyph outlines, laid out exactly as on an image.
+// draw2dpdf otherwise needs gofpdf font definition files for every font, failing the whole document without them.
+type pdfContext struct {
+	*draw2dpdf.GraphicContext
+	text draw2d2.GraphicContext // Lays out text, never drawn on
+}
+
+func newPdfContext(pdf *gofpdf.Fpdf) *pdfContext {
+	return &pdfContext{
+		GraphicContext: draw2dpdf.NewGraphicContext(pdf),
+		text:           DefaultBackend().NewGraphicContext(image.NewRGBA(image.Rect(0, 0, 1, 1))),
+	}
+}
+
+func (gc *pdfContext) SetDPI(dpi int) {
+	gc.text.SetDPI(dpi)
+}
+
+func (gc *pdfContext) GetDPI() int {
+	return gc.text.GetDPI()
+}
+
+func (gc *pdfContext) SetFont(f *truetype.Font) {
+	if t, ok := gc.text.(interface{ SetFont(*truetype.Font) }); ok {
+		t.SetFont(f)
+	}
+}
+
+func (gc *pdfContext) GetFontName() string {
+	return gc.text.GetFontName()
+}
+
+func (gc *pdfContext) SetFontData(fontData draw2d.FontData) {
+	gc.StackGraphicContext.SetFontData(fontData)
+	gc.text.SetFontData(fontData)
+}
+
+func (gc *pdfContext) SetFontSize(fontSize float64) {
+	gc.StackGraphicContext.SetFontSize(fontSize)
+	gc.text.SetFontSize(fontSize)
+}
+
+func (gc *pdfContext) Save() {
+	gc.GraphicContext.Save()
+	gc.text.Save()
+}
+
+func (gc *pdfContext) Restore() {
+	gc.text.Restore()
+	gc.GraphicContext.Restore()
+}
+
+func (gc *pdfContext) GetStringBounds(s string) (left, top, right, bottom float64) {
+	return gc.text.GetStringBounds(s)
+}
+
+// CreateStringPath adds the outline of s at x,y to the current path, returning its width
+func (gc *pdfContext) CreateStringPath(s string, x, y float64) float64 {
+	p, w := gc.stringPath(s, x, y)
+	gc.Current.Path.Components = append(gc.Current.Path.Components, p.Components...)
+	gc.Current.Path.Points = append(gc.Current.Path.Points, p.Points...)
+	return w
+}
+
+func (gc *pdfContext) FillString(s string) float64 {
+	return gc.FillStringAt(s, 0, 0)
+}
+
+func (gc *pdfContext) FillStringAt(s string, x, y float64) float64 {
+	p, w := gc.stringPath(s, x, y)
+	gc.drawString(p, gc.Fill)
+	return w
+}
+
+func (gc *pdfContext) StrokeString(s string) float64 {
+	return gc.StrokeStringAt(s, 0, 0)
+}
+
+func (gc *pdfContext) StrokeStringAt(s string, x, y float64) float64 {
+	p, w := gc.stringPath(s, x, y)
+	gc.drawString(p, gc.Stroke)
+	return w
+}
+
+// drawString fills or strokes the outline of a string, leaving the current path untouched as draw2dimg does
+func (gc *pdfContext) drawString(p *draw2d.Path, draw func(...*draw2d.Path)) {
+	current := gc.Current.Path.Copy()
+	gc.Current.Path.Clear()
+	draw(p)
+	gc.Current.Path = current
+}
+
+// stringPath returns the outline of s at x,y and its width
+func (gc *pdfContext) stringPath(s string, x, y float64) (*draw2d.Path, float64) {
+	gc.text.BeginPath()
+	w := gc.text.CreateStringPath(s, x, y)
+	p := gc.text.GetPath()
+	gc.text.BeginPath()
+	return &p, w
+}
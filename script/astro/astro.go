@@ -0,0 +1,69 @@
+package astro
+
+import (
+	"github.com/peter-mount/go-anim/util/astro"
+	"github.com/peter-mount/go-anim/util/frames"
+	"github.com/peter-mount/go-script/packages"
+	"time"
+)
+
+func init() {
+	packages.RegisterPackage(&Astro{})
+}
+
+// Astro provides the Sun and Moon calculations to scripts.
+//
+// All latitudes, longitudes and angles are in degrees.
+// Times are usually those returned by util.TimeFromFileNameIn or a Frame's Time.
+type Astro struct {
+}
+
+// Sun returns the position of the Sun at a specific time and location
+func (_ Astro) Sun(t time.Time, lat, lon float64) astro.Position {
+	return astro.SunPosition(t, lat, lon)
+}
+
+// SunTimes returns the times of sunrise, sunset, twilight etc. for the day containing t
+func (_ Astro) SunTimes(t time.Time, lat, lon float64) astro.SunTimes {
+	return astro.GetSunTimes(t, lat, lon)
+}
+
+// Moon returns the position of the Moon at a specific time and location
+func (_ Astro) Moon(t time.Time, lat, lon float64) astro.Position {
+	return astro.MoonPosition(t, lat, lon)
+}
+
+// MoonTimes returns the times of moonrise, moonset and transit for the day containing t
+func (_ Astro) MoonTimes(t time.Time, lat, lon float64) astro.MoonTimes {
+	return astro.GetMoonTimes(t, lat, lon)
+}
+
+// MoonPhase returns the illumination of the Moon at a specific time
+func (_ Astro) MoonPhase(t time.Time) astro.MoonPhase {
+	return astro.GetMoonPhase(t)
+}
+
+// Twilight returns the phase of the day at a specific time and location
+func (_ Astro) Twilight(t time.Time, lat, lon float64) astro.Twilight {
+	return astro.GetTwilight(t, lat, lon)
+}
+
+// IsDaylight returns true if the Sun has risen at a specific time and location
+func (_ Astro) IsDaylight(t time.Time, lat, lon float64) bool {
+	return astro.IsDaylight(t, lat, lon)
+}
+
+// Daylight returns a FrameSet containing just the frames taken whilst the Sun was up
+func (_ Astro) Daylight(fs *frames.FrameSet, lat, lon float64) *frames.FrameSet {
+	return fs.Filter(func(f *frames.Frame) bool {
+		return astro.IsDaylight(f.Time, lat, lon)
+	})
+}
+
+// SunAbove returns a FrameSet containing just the frames taken whilst the Sun was at or above
+// the specified altitude, e.g. -6 to include civil twilight
+func (_ Astro) SunAbove(fs *frames.FrameSet, lat, lon, alt float64) *frames.FrameSet {
+	return fs.Filter(func(f *frames.Frame) bool {
+		return astro.SunPosition(f.Time, lat, lon).Altitude >= alt
+	})
+}
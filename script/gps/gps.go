@@ -0,0 +1,42 @@
+package gps
+
+import (
+	"github.com/peter-mount/go-anim/util/gps"
+	"github.com/peter-mount/go-script/packages"
+	"time"
+)
+
+func init() {
+	packages.RegisterPackage(&GPS{})
+}
+
+// GPS provides GPS tracks to scripts, from either GPX files or the EXIF data of a set of images
+type GPS struct {
+}
+
+// LoadGPX returns all tracks and routes within a GPX file
+func (_ GPS) LoadGPX(fileName string) ([]*gps.Track, error) {
+	return gps.LoadGPX(fileName)
+}
+
+// LoadExif returns a Track from the EXIF data of a set of images, using the local timezone
+// for times taken from their file names
+func (_ GPS) LoadExif(files []string) (*gps.Track, error) {
+	return gps.LoadExif(files, time.Local)
+}
+
+// LoadExifIn returns a Track from the EXIF data of a set of images, using the specified timezone
+// for times taken from their file names
+func (_ GPS) LoadExifIn(files []string, loc *time.Location) (*gps.Track, error) {
+	return gps.LoadExif(files, loc)
+}
+
+// NewTrack returns an empty Track
+func (_ GPS) NewTrack(name string) *gps.Track {
+	return gps.NewTrack(name)
+}
+
+// Distance returns the great circle distance in metres between two Points
+func (_ GPS) Distance(a, b gps.Point) float64 {
+	return gps.Distance(a, b)
+}
@@ -0,0 +1,20 @@
+package graph
+
+import (
+	"github.com/peter-mount/go-anim/renderer"
+)
+
+// Backend returns a renderer backend by name, e.g. "draw2d", for use with Context.SetBackend
+func (_ Graph) Backend(name string) (renderer.Backend, error) {
+	return renderer.LookupBackend(name)
+}
+
+// Backends returns the names of the available renderer backends
+func (_ Graph) Backends() []string {
+	return renderer.Backends()
+}
+
+// SetDefaultBackend sets the renderer backend used by new contexts
+func (_ Graph) SetDefaultBackend(name string) error {
+	return renderer.SetDefaultBackend(name)
+}
@@ -2,7 +2,6 @@ package graph
 
 import (
 	"github.com/llgcode/draw2d"
-	"github.com/llgcode/draw2d/draw2dimg"
 	"github.com/peter-mount/go-anim/graph"
 	"github.com/peter-mount/go-anim/renderer"
 	"github.com/peter-mount/go-anim/script/image"
@@ -40,10 +39,37 @@ func (_ Graph) NewSizedContext(w, h int) renderer.Context {
 	return renderer.NewContext(w, h)
 }
 
+// NewRGBA64Context returns a context drawing at 16 bits per channel, e.g. for 16-bit TIFF output
+func (_ Graph) NewRGBA64Context(w, h int) renderer.Context {
+	return renderer.NewRGBA64Context(w, h)
+}
+
+// NewFloatContext returns a context drawing in floating point, e.g. for EXR output
+func (_ Graph) NewFloatContext(w, h int) renderer.Context {
+	return renderer.NewFloatContext(w, h)
+}
+
 func (_ Graph) NewImageContext(img draw.Image) renderer.Context {
 	return renderer.NewImageContext(img)
 }
 
+// NewVectorContext returns a context drawing as vectors, saved as an SVG or PDF depending on the extension of fileName
+func (_ Graph) NewVectorContext(fileName string, w, h int) (*renderer.VectorContext, error) {
+	f, err := renderer.VectorFormatOf(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return renderer.NewVectorContext(f, w, h), nil
+}
+
+func (_ Graph) NewSvgContext(w, h int) *renderer.VectorContext {
+	return renderer.NewSvgContext(w, h)
+}
+
+func (_ Graph) NewPdfContext(w, h int) *renderer.VectorContext {
+	return renderer.NewPdfContext(w, h)
+}
+
 func (_ Graph) NewFont(name string, size float64, family draw2d.FontFamily, style draw2d.FontStyle) font.Font {
 	return font.New(name, size, family, style)
 }
@@ -52,26 +78,46 @@ func (_ Graph) ParseFont(s string) (font.Font, error) {
 	return font.ParseFont(s)
 }
 
-func (_ Graph) SetFont(gc *draw2dimg.GraphicContext, s string) error {
+// FontFamilies returns the font families available by name
+func (_ Graph) FontFamilies() []string {
+	return font.DefaultRegistry.Families()
+}
+
+// FontFaces returns the faces available within a font family
+func (_ Graph) FontFaces(family string) []*font.Face {
+	return font.DefaultRegistry.Faces(family)
+}
+
+// AddFontDir makes the fonts within a directory available by family name
+func (_ Graph) AddFontDir(dir string) error {
+	return font.DefaultRegistry.AddDir(dir)
+}
+
+// AddFontFile makes the fonts within a font file available by family name
+func (_ Graph) AddFontFile(fileName string) error {
+	return font.DefaultRegistry.AddFile(fileName)
+}
+
+func (_ Graph) SetFont(gc draw2d2.GraphicContext, s string) error {
 	return graph.SetFont(gc, s)
 }
 
-func (_ Graph) FillPoly(gc *draw2dimg.GraphicContext, c color.Color, v ...float64) {
+func (_ Graph) FillPoly(gc draw2d2.GraphicContext, c color.Color, v ...float64) {
 	draw2d2.FillPoly(gc, c, v...)
 }
 
-func (_ Graph) FillPolyRel(gc *draw2dimg.GraphicContext, c color.Color, v ...float64) {
+func (_ Graph) FillPolyRel(gc draw2d2.GraphicContext, c color.Color, v ...float64) {
 	draw2d2.FillPolyRel(gc, c, v...)
 }
 
-func (_ Graph) FillRectangle(gc *draw2dimg.GraphicContext, x, y, w, h float64, c color.Color) (float64, float64) {
+func (_ Graph) FillRectangle(gc draw2d2.GraphicContext, x, y, w, h float64, c color.Color) (float64, float64) {
 	return draw2d2.FillRectangle(gc, x, y, w, h, c)
 }
 
-func (_ Graph) Rectangle(gc *draw2dimg.GraphicContext, x, y, w, h float64) {
+func (_ Graph) Rectangle(gc draw2d2.GraphicContext, x, y, w, h float64) {
 	draw2d2.Rectangle(gc, x, y, w, h)
 }
 
-func (_ Graph) RelLine(gc *draw2dimg.GraphicContext, x, y float64, v ...float64) {
+func (_ Graph) RelLine(gc draw2d2.GraphicContext, x, y float64, v ...float64) {
 	draw2d2.RelLine(gc, x, y, v...)
 }
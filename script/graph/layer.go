@@ -0,0 +1,22 @@
+package graph
+
+import (
+	"github.com/peter-mount/go-anim/graph"
+	"github.com/peter-mount/go-anim/renderer"
+	"image"
+)
+
+// BlendMode returns a blend mode by name, e.g. "multiply", "screen" or "overlay"
+func (_ Graph) BlendMode(s string) graph.BlendMode {
+	return graph.ParseBlendMode(s)
+}
+
+// CompositeLayer draws the named layer of ctx over it with an opacity and blend mode, e.g. "normal" or "multiply"
+func (_ Graph) CompositeLayer(ctx renderer.Context, name string, opacity float64, mode string) error {
+	return ctx.CompositeLayer(name, opacity, graph.ParseBlendMode(mode), nil)
+}
+
+// CompositeLayerMask is CompositeLayer but only where mask is not transparent
+func (_ Graph) CompositeLayerMask(ctx renderer.Context, name string, opacity float64, mode string, mask image.Image) error {
+	return ctx.CompositeLayer(name, opacity, graph.ParseBlendMode(mode), mask)
+}
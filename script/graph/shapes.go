@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"github.com/peter-mount/go-anim/renderer"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/font"
+	"image"
+	"image/color"
+	"math"
+)
+
+// The following add shapes to the current path of a Context, which can then be filled or stroked.
+// Angles are in degrees clockwise from the positive x axis.
+
+// RoundedRectangle adds a rectangle with corners of radius r
+func (_ Graph) RoundedRectangle(ctx renderer.Context, x, y, w, h, r float64) {
+	draw2d2.RoundedRectangle(ctx.Gc(), x, y, w, h, r)
+}
+
+// Arc adds an arc of an ellipse centered on cx,cy from start degrees sweeping through angle degrees
+func (_ Graph) Arc(ctx renderer.Context, cx, cy, rx, ry, start, angle float64) {
+	draw2d2.Arc(ctx.Gc(), cx, cy, rx, ry, start, angle)
+}
+
+// Pie adds a closed segment of an ellipse centered on cx,cy
+func (_ Graph) Pie(ctx renderer.Context, cx, cy, rx, ry, start, angle float64) {
+	draw2d2.Pie(ctx.Gc(), cx, cy, rx, ry, start, angle)
+}
+
+// Ellipse adds an ellipse centered on cx,cy
+func (_ Graph) Ellipse(ctx renderer.Context, cx, cy, rx, ry float64) {
+	draw2d2.Ellipse(ctx.Gc(), cx, cy, rx, ry)
+}
+
+// Circle adds a circle centered on cx,cy
+func (_ Graph) Circle(ctx renderer.Context, cx, cy, r float64) {
+	draw2d2.Ellipse(ctx.Gc(), cx, cy, r, r)
+}
+
+// Polygon adds a regular polygon centered on cx,cy with its first vertex at rotation degrees
+func (_ Graph) Polygon(ctx renderer.Context, cx, cy, r float64, sides int, rotation float64) {
+	draw2d2.Polygon(ctx.Gc(), cx, cy, r, sides, rotation)
+}
+
+// Star adds a star centered on cx,cy with points at radius outer, the vertices between them at radius inner
+func (_ Graph) Star(ctx renderer.Context, cx, cy, outer, inner float64, points int, rotation float64) {
+	draw2d2.Star(ctx.Gc(), cx, cy, outer, inner, points, rotation)
+}
+
+// Spline adds a smooth curve through the points given as x,y pairs.
+// tension is 0 for the default curve up to 1 for straight lines.
+func (_ Graph) Spline(ctx renderer.Context, tension float64, v ...float64) {
+	draw2d2.Spline(ctx.Gc(), tension, false, v...)
+}
+
+// ClosedSpline adds a smooth closed curve through the points given as x,y pairs
+func (_ Graph) ClosedSpline(ctx renderer.Context, tension float64, v ...float64) {
+	draw2d2.Spline(ctx.Gc(), tension, true, v...)
+}
+
+// ArrowHead returns the head of an arrow of style "none", "triangle", "open", "circle" or "bar"
+func (_ Graph) ArrowHead(style string, length, width float64) draw2d2.ArrowHead {
+	return draw2d2.NewArrowHead(style, length, width)
+}
+
+// Arrow draws a line from x1,y1 to x2,y2 with heads at either end
+func (_ Graph) Arrow(ctx renderer.Context, c color.Color, x1, y1, x2, y2 float64, start, end draw2d2.ArrowHead) {
+	draw2d2.Arrow(ctx.Gc(), c, x1, y1, x2, y2, start, end)
+}
+
+// Dashed sets strokes to alternate dashes and gaps of the given lengths, none for solid strokes
+func (_ Graph) Dashed(ctx renderer.Context, dash ...float64) {
+	draw2d2.Dashed(ctx.Gc(), dash...)
+}
+
+// Dotted sets the line width and strokes to dots the size of the line width spaced gap apart
+func (_ Graph) Dotted(ctx renderer.Context, lineWidth, gap float64) {
+	draw2d2.Dotted(ctx.Gc(), lineWidth, gap)
+}
+
+// Solid sets strokes to be solid
+func (_ Graph) Solid(ctx renderer.Context) {
+	draw2d2.Solid(ctx.Gc())
+}
+
+// LineCap sets the end of strokes to "round", "butt" or "square"
+func (_ Graph) LineCap(ctx renderer.Context, s string) {
+	ctx.Gc().SetLineCap(draw2d2.ParseLineCap(s))
+}
+
+// LineJoin sets the corners of strokes to "round", "bevel" or "miter"
+func (_ Graph) LineJoin(ctx renderer.Context, s string) {
+	ctx.Gc().SetLineJoin(draw2d2.ParseLineJoin(s))
+}
+
+// Gradient returns a new gradient. Add colours with Stop, then create a paint with Linear, Radial or Conic.
+func (_ Graph) Gradient() *draw2d2.Gradient {
+	return draw2d2.NewGradient()
+}
+
+// Spread returns how a gradient continues beyond its stops, "pad", "repeat" or "reflect"
+func (_ Graph) Spread(s string) draw2d2.Spread {
+	return draw2d2.ParseSpread(s)
+}
+
+// Pattern returns a paint of an image, tiled if repeat is true
+func (_ Graph) Pattern(img image.Image, repeat bool) *draw2d2.Pattern {
+	return draw2d2.NewPattern(img, repeat)
+}
+
+// FillPaint fills the current path with a gradient or pattern
+func (_ Graph) FillPaint(ctx renderer.Context, p draw2d2.Paint) {
+	draw2d2.FillPaint(ctx.Gc(), p)
+}
+
+// StrokePaint strokes the current path with a gradient or pattern
+func (_ Graph) StrokePaint(ctx renderer.Context, p draw2d2.Paint) {
+	draw2d2.StrokePaint(ctx.Gc(), p)
+}
+
+// FillStringPaint draws s at x,y, filled with a gradient or pattern
+func (_ Graph) FillStringPaint(ctx renderer.Context, p draw2d2.Paint, s string, x, y float64) {
+	gc := ctx.Gc()
+	l, t, r, b := font.StringBounds(gc, s)
+	bounds := image.Rect(int(math.Floor(x+l)), int(math.Floor(y+t)), int(math.Ceil(x+r)), int(math.Ceil(y+b)))
+	draw2d2.DrawPaint(gc, bounds, p, func(gc draw2d2.GraphicContext) {
+		font.FillStringAt(gc, s, x, y)
+	})
+}
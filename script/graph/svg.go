@@ -0,0 +1,22 @@
+package graph
+
+import (
+	"github.com/peter-mount/go-anim/renderer"
+	"github.com/peter-mount/go-anim/util/svg"
+	"strings"
+)
+
+// LoadSvg loads an SVG file
+func (_ Graph) LoadSvg(fileName string) (*svg.Document, error) {
+	return svg.Load(fileName)
+}
+
+// ParseSvg parses an SVG document held in a string
+func (_ Graph) ParseSvg(s string) (*svg.Document, error) {
+	return svg.Parse(strings.NewReader(s))
+}
+
+// DrawSvg draws an SVG document scaled to fit the rectangle at x,y of size w,h
+func (_ Graph) DrawSvg(ctx renderer.Context, doc *svg.Document, x, y, w, h float64) {
+	doc.Draw(ctx.Gc(), x, y, w, h)
+}
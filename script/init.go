@@ -1,12 +1,15 @@
 package script
 
 import (
+	_ "github.com/peter-mount/go-anim/script/astro"
 	_ "github.com/peter-mount/go-anim/script/colour"
 	_ "github.com/peter-mount/go-anim/script/exif"
+	_ "github.com/peter-mount/go-anim/script/gps"
 	_ "github.com/peter-mount/go-anim/script/graph"
 	_ "github.com/peter-mount/go-anim/script/image"
 	_ "github.com/peter-mount/go-anim/script/layout"
 	_ "github.com/peter-mount/go-anim/script/mapper"
 	_ "github.com/peter-mount/go-anim/script/render"
+	_ "github.com/peter-mount/go-anim/script/series"
 	_ "github.com/peter-mount/go-anim/script/util"
 )
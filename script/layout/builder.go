@@ -4,7 +4,13 @@ import (
 	"fmt"
 	"github.com/llgcode/draw2d"
 	"github.com/peter-mount/go-anim/layout"
+	"github.com/peter-mount/go-anim/renderer"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/series"
+	time2 "github.com/peter-mount/go-anim/util/time"
 	"image"
+	"strconv"
+	"time"
 )
 
 type Builder struct {
@@ -37,6 +43,41 @@ func (b *Builder) add(n string, component layout.Component) error {
 	return nil
 }
 
+// register a component created from a layout.Definition, following the same
+// naming rules as ContainerBuilder.AddComponent and AddContainer
+func (b *Builder) register(n string, component layout.Component) error {
+	if err := b.add(n, component); err != nil {
+		return err
+	}
+	if _, ok := component.(layout.Container); ok {
+		b.seq++
+		component.SetType(component.GetType() + strconv.Itoa(b.seq))
+	}
+	return nil
+}
+
+// Load a Layout from a YAML or JSON definition file.
+// If width or height are 0 then the width or height from the definition is used.
+func (p *Package) Load(fileName string, width, height int) (*Layout, error) {
+	d, err := layout.LoadDefinition(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if width == 0 {
+		width = d.PixelWidth()
+	}
+	if height == 0 {
+		height = d.PixelHeight()
+	}
+
+	b := p.New(width, height).(*ContainerBuilder).builder
+	if err := d.BuildRoot(b.root, b.register); err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+	return b.Build(), nil
+}
+
 func (b *Builder) Build() *Layout {
 	return &Layout{
 		common: b.common,
@@ -45,20 +86,117 @@ func (b *Builder) Build() *Layout {
 
 type Layout struct {
 	common
+	bindings series.Bindings
+	debug    bool
 }
 
 func (l *Layout) Get(name string) layout.Component {
 	return l.components[name]
 }
 
-func (l *Layout) Layout(ctx draw2d.GraphicContext) bool {
-	r := l.root.Layout(ctx)
-	if r {
-		r = l.root.Layout(ctx)
+// Bind the named component to one or more Series in a DataSet.
+// When Update is called, or at the start of each frame of an attached Context, the component's args
+// are replaced with the values of those Series.
+func (l *Layout) Bind(name string, ds *series.DataSet, names ...string) error {
+	comp, exists := l.components[name]
+	if !exists {
+		return fmt.Errorf("component '%s' does not exist", name)
+	}
+
+	setter, ok := comp.(layout.ArgsSetter)
+	if !ok {
+		return fmt.Errorf("component '%s' does not support args", name)
+	}
+
+	var s []*series.Series
+	for _, n := range names {
+		if !ds.Contains(n) {
+			return fmt.Errorf("series '%s' does not exist", n)
+		}
+		s = append(s, ds.Get(n))
 	}
-	return r
+
+	l.bindings.Bind(setter.SetArgs, s...)
+	return nil
+}
+
+// ShowWhen binds the visibility of the named component to a Series in a DataSet.
+// When Update is called, or at the start of each frame of an attached Context, the component is shown
+// when the value meets the condition and hidden otherwise, e.g. ShowWhen("rain", ds, "rainfall", "> 0"). An empty condition shows it for any non-zero value.
+func (l *Layout) ShowWhen(name string, ds *series.DataSet, seriesName, condition string) error {
+	comp, exists := l.components[name]
+	if !exists {
+		return fmt.Errorf("component '%s' does not exist", name)
+	}
+
+	a, ok := comp.(layout.Animated)
+	if !ok {
+		return fmt.Errorf("component '%s' does not support visibility", name)
+	}
+
+	if !ds.Contains(seriesName) {
+		return fmt.Errorf("series '%s' does not exist", seriesName)
+	}
+
+	cond, err := layout.ParseCondition(condition)
+	if err != nil {
+		return err
+	}
+
+	l.bindings.Bind(layout.ShowWhen(a, cond), ds.Get(seriesName))
+	return nil
+}
+
+// Attach the Layout to a Context so that at the start of each frame all bound components are updated
+// with their values and any animations advanced, as if Update was called.
+//
+// start is the time of the first frame and scale the seconds of data shown per second of video,
+// e.g. 1 for real time or 60 for a timelapse showing a minute every second.
+// For frames with their own times, such as a FrameSet, call Update with each Frame's Time instead.
+func (l *Layout) Attach(ctx renderer.Context, start time.Time, scale float64) *Layout {
+	if scale <= 0 {
+		scale = 1
+	}
+
+	first := -1.0
+	ctx.OnBeginFrame(renderer.FrameHookFunc(func(_ renderer.Context, tc time2.TimeCodeFragment) error {
+		sec := float64(tc.Offset()) + tc.FrameF()/float64(tc.FrameRate())
+		if first < 0 {
+			first = sec
+		}
+		l.Update(start.Add(time.Duration((sec - first) * scale * float64(time.Second))))
+		return nil
+	}))
+	return l
+}
+
+// Update all bound components with their values at the specified time and advance any animations,
+// usually the Time of the Frame being rendered.
+func (l *Layout) Update(t time.Time) {
+	// Set the clock first so changes to bound values are animated from this frame
+	layout.SetTime(l.root, t)
+	l.bindings.Update(t)
+}
+
+// Layout lays out the components in a single measure/arrange pass, returning true if any have moved or resized
+func (l *Layout) Layout(ctx draw2d.GraphicContext) bool {
+	return layout.LayoutTree(ctx, l.root)
 }
 
 func (l *Layout) Draw(context draw2d.GraphicContext) {
 	l.root.Draw(context)
+	if l.debug {
+		layout.DrawDebug(draw2d2.Wrap(context), l.root)
+	}
+}
+
+// Debug enables drawing the bounds, insets and names of each component over the frame
+func (l *Layout) Debug(debug bool) *Layout {
+	l.debug = debug
+	return l
+}
+
+// Dump returns the component tree with the bounds computed by the last Layout, as "text" or "json"
+func (l *Layout) Dump(format string) (string, error) {
+	return layout.Dump(l.root, format)
 }
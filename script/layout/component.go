@@ -1,8 +1,13 @@
 package layout
 
 import (
+	"fmt"
 	"github.com/peter-mount/go-anim/layout"
+	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/unit"
 	"image/color"
+	"time"
 )
 
 type ComponentBuilder struct {
@@ -57,6 +62,270 @@ func (b *ComponentBuilder) StrokeFill(c color.Color) any {
 	return b.this
 }
 
+// flexItem returns the FlexItem of this component if its parent is a FlexContainer
+func (b *ComponentBuilder) flexItem() *layout.FlexItem {
+	if p, ok := b.parent.(*ContainerBuilder); ok {
+		if c, ok := p.comp.(*layout.FlexContainer); ok {
+			return c.Item(b.comp)
+		}
+	}
+	return nil
+}
+
+// gridCell returns the GridCell of this component if its parent is a GridContainer
+func (b *ComponentBuilder) gridCell() *layout.GridCell {
+	if p, ok := b.parent.(*ContainerBuilder); ok {
+		if c, ok := p.comp.(*layout.GridContainer); ok {
+			return c.Cell(b.comp)
+		}
+	}
+	return nil
+}
+
+func (b *ComponentBuilder) Grow(g float64) any {
+	if i := b.flexItem(); i != nil {
+		i.Grow(g)
+	}
+	return b.this
+}
+
+func (b *ComponentBuilder) Shrink(s float64) any {
+	if i := b.flexItem(); i != nil {
+		i.Shrink(s)
+	}
+	return b.this
+}
+
+func (b *ComponentBuilder) Basis(basis int) any {
+	if i := b.flexItem(); i != nil {
+		i.Basis(basis)
+	}
+	return b.this
+}
+
+func (b *ComponentBuilder) AlignSelf(s string) any {
+	if i := b.flexItem(); i != nil {
+		i.AlignSelf(layout.ParseFlexAlign(s))
+	}
+	return b.this
+}
+
+// Cell places this component at a column and row, from 0, within a GridContainer
+func (b *ComponentBuilder) Cell(column, row int) any {
+	if c := b.gridCell(); c != nil {
+		c.Place(column, row)
+	}
+	return b.this
+}
+
+// Span sets the number of columns and rows this component spans within a GridContainer
+func (b *ComponentBuilder) Span(columns, rows int) any {
+	if c := b.gridCell(); c != nil {
+		c.Span(columns, rows)
+	}
+	return b.this
+}
+
+// Size sets the preferred size of the component, e.g. Size("50%", "10vh").
+// An empty string sizes that dimension to its content.
+func (b *ComponentBuilder) Size(width, height string) (any, error) {
+	var w, h unit.Value
+	var err error
+	if width != "" {
+		if w, err = unit.ParseValue(width); err != nil {
+			return nil, err
+		}
+	}
+	if height != "" {
+		if h, err = unit.ParseValue(height); err != nil {
+			return nil, err
+		}
+	}
+	sz, err := b.sizer()
+	if err != nil {
+		return nil, err
+	}
+	sz.Size(w, h)
+	return b.this, nil
+}
+
+// Margin sets the space around the component, using 1 to 4 values like css, e.g. Margin("1em 2%")
+func (b *ComponentBuilder) Margin(s string) (any, error) {
+	d, err := unit.ParseDimension(s)
+	if err != nil {
+		return nil, err
+	}
+	sz, err := b.sizer()
+	if err != nil {
+		return nil, err
+	}
+	sz.Margin(d)
+	return b.this, nil
+}
+
+// Padding sets the space within the component, using 1 to 4 values like css, e.g. Padding("0.5em")
+func (b *ComponentBuilder) Padding(s string) (any, error) {
+	d, err := unit.ParseDimension(s)
+	if err != nil {
+		return nil, err
+	}
+	sz, err := b.sizer()
+	if err != nil {
+		return nil, err
+	}
+	sz.Padding(d)
+	return b.this, nil
+}
+
+// FontSize sets the size of the font, e.g. FontSize("3vh")
+func (b *ComponentBuilder) FontSize(s string) (any, error) {
+	v, err := unit.ParseValue(s)
+	if err != nil {
+		return nil, err
+	}
+	sz, err := b.sizer()
+	if err != nil {
+		return nil, err
+	}
+	sz.FontSize(v)
+	return b.this, nil
+}
+
+// sizer returns the component as a layout.Sizer
+func (b *ComponentBuilder) sizer() (layout.Sizer, error) {
+	if s, ok := b.comp.(layout.Sizer); ok {
+		return s, nil
+	}
+	return nil, fmt.Errorf("%s does not support sizes, margins or padding", b.comp.GetType())
+}
+
+// textEffects returns the TextEffects of the component
+func (b *ComponentBuilder) textEffects() *util.TextEffects {
+	if c, ok := b.comp.(interface{ TextEffects() *util.TextEffects }); ok {
+		return c.TextEffects()
+	}
+	// Component does not draw text so the effects are ignored
+	return util.NewTextEffects()
+}
+
+// Outline strokes the text with a line of width pixels around each glyph
+func (b *ComponentBuilder) Outline(width float64, c color.Color) any {
+	b.textEffects().Outline(width, c)
+	return b.this
+}
+
+// Shadow draws a drop shadow behind the text, offset by dx, dy and blurred over blur pixels
+func (b *ComponentBuilder) Shadow(c color.Color, dx, dy, blur float64) any {
+	b.textEffects().Shadow(c, dx, dy, blur)
+	return b.this
+}
+
+// Glow draws a blurred halo extending radius pixels around the text
+func (b *ComponentBuilder) Glow(c color.Color, radius float64) any {
+	b.textEffects().Glow(c, radius)
+	return b.this
+}
+
+// Plate draws a rounded rectangle behind the text with padding, corner radius and opacity from 0 to 1
+func (b *ComponentBuilder) Plate(c color.Color, padding, radius, opacity float64) any {
+	b.textEffects().Plate(c, padding, radius, opacity)
+	return b.this
+}
+
+// animated returns the component if it supports animated transitions
+func (b *ComponentBuilder) animated() (layout.Animated, bool) {
+	c, ok := b.comp.(layout.Animated)
+	return c, ok
+}
+
+// transition parses a duration, e.g. "500ms" or "1.5s", and an easing, one of "linear", "ease-in", "ease-out" or "ease-in-out"
+func transition(duration, easing string) (layout.Transition, error) {
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return layout.Transition{}, err
+	}
+	return layout.Transition{Duration: d, Easing: layout.ParseEasing(easing)}, nil
+}
+
+// AnimateValues animates numeric values, so they count up or down to their new value when the args change,
+// e.g. AnimateValues("500ms", "ease-out")
+func (b *ComponentBuilder) AnimateValues(duration, easing string) (any, error) {
+	t, err := transition(duration, easing)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := b.animated(); ok {
+		c.AnimateValues(t)
+	}
+	return b.this, nil
+}
+
+// AnimateShow animates the component when it is shown or hidden.
+// effect is a combination of "fade", "slide-left", "slide-right", "slide-up" and "slide-down",
+// e.g. AnimateShow("fade slide-up", "300ms", "ease-in-out")
+func (b *ComponentBuilder) AnimateShow(effect, duration, easing string) (any, error) {
+	t, err := transition(duration, easing)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := b.animated(); ok {
+		c.AnimateShow(layout.ParseShowEffect(effect), t)
+	}
+	return b.this, nil
+}
+
+// AnimateMove animates the component moving to its new position when the layout changes
+func (b *ComponentBuilder) AnimateMove(duration, easing string) (any, error) {
+	t, err := transition(duration, easing)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := b.animated(); ok {
+		c.AnimateMove(t)
+	}
+	return b.this, nil
+}
+
+// Hide hides the component, so it is initially hidden until shown
+func (b *ComponentBuilder) Hide() any {
+	if c, ok := b.animated(); ok {
+		c.Hide()
+	}
+	return b.this
+}
+
+// ZIndex sets the order the component is drawn within its container, higher over lower
+func (b *ComponentBuilder) ZIndex(z int) any {
+	if c, ok := b.comp.(interface{ ZIndex(int) }); ok {
+		c.ZIndex(z)
+	}
+	return b.this
+}
+
+// Opacity sets the opacity of the component, from 0 transparent to 1 opaque
+func (b *ComponentBuilder) Opacity(f float64) any {
+	if c, ok := b.comp.(interface{ Opacity(float64) }); ok {
+		c.Opacity(f)
+	}
+	return b.this
+}
+
+// Background fills the component with a gradient or pattern, relative to its top left corner
+func (b *ComponentBuilder) Background(p draw2d2.Paint) any {
+	if c, ok := b.comp.(interface{ Background(draw2d2.Paint) }); ok {
+		c.Background(p)
+	}
+	return b.this
+}
+
+// FillPaint paints what the component draws, such as text, with a gradient or pattern
+func (b *ComponentBuilder) FillPaint(p draw2d2.Paint) any {
+	if c, ok := b.comp.(interface{ FillPaint(draw2d2.Paint) }); ok {
+		c.FillPaint(p)
+	}
+	return b.this
+}
+
 func (b *ComponentBuilder) End() any {
 	return b.parent
 }
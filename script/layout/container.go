@@ -42,6 +42,10 @@ func (b *ContainerBuilder) AddContainer(name string, comp layout.Container) (any
 	return newContainerBuilder(b, b.builder, comp), nil
 }
 
+func (b *ContainerBuilder) BarChart(name string) (any, error) {
+	return b.AddComponent(name, layout.NewBarChart())
+}
+
 func (b *ContainerBuilder) ColScaleContainer(scales ...float64) (any, error) {
 	return b.AddContainer("", layout.ColScaleContainer(scales...))
 }
@@ -50,14 +54,68 @@ func (b *ContainerBuilder) FixedContainer(width, height int) (any, error) {
 	return b.AddContainer("", layout.FixedContainer(image.Rect(0, 0, width, height)))
 }
 
+func (b *ContainerBuilder) FlexColumn() (any, error) {
+	return b.AddContainer("", layout.FlexColumn())
+}
+
+func (b *ContainerBuilder) FlexRow() (any, error) {
+	return b.AddContainer("", layout.FlexRow())
+}
+
+func (b *ContainerBuilder) Gauge(name string, min, max float64) (any, error) {
+	return b.AddComponent(name, layout.NewGauge(min, max))
+}
+
+// Grid adds a GridContainer with columns defined as tracks, e.g. "200 1fr 2fr"
+func (b *ContainerBuilder) Grid(columns string) (any, error) {
+	tracks, err := layout.ParseTracks(columns)
+	if err != nil {
+		return nil, err
+	}
+	return b.AddContainer("", layout.NewGridContainer(tracks...))
+}
+
 func (b *ContainerBuilder) Image(name string) (any, error) {
 	return b.AddComponent(name, layout.NewImage())
 }
 
+// Svg adds an SvgImage showing the SVG file fileName
+func (b *ContainerBuilder) Svg(name, fileName string) (any, error) {
+	c := layout.NewSvgImage()
+	if err := c.Load(fileName); err != nil {
+		return nil, err
+	}
+	return b.AddComponent(name, c)
+}
+
+func (b *ContainerBuilder) LineChart(name string) (any, error) {
+	return b.AddComponent(name, layout.NewLineChart())
+}
+
+func (b *ContainerBuilder) Map(name string) (any, error) {
+	return b.AddComponent(name, layout.NewTrackMap())
+}
+
+func (b *ContainerBuilder) PolarPlot(name string) (any, error) {
+	return b.AddComponent(name, layout.NewPolarPlot())
+}
+
+func (b *ContainerBuilder) RichText(name, format string, args ...any) (any, error) {
+	return b.AddComponent(name, layout.NewRichText(format, args...))
+}
+
 func (b *ContainerBuilder) RowContainer() (any, error) {
 	return b.AddContainer("", layout.RowContainer())
 }
 
+func (b *ContainerBuilder) Sparkline(name string) (any, error) {
+	return b.AddComponent(name, layout.NewSparkline())
+}
+
+func (b *ContainerBuilder) Table(name string) (any, error) {
+	return b.AddComponent(name, layout.NewTable())
+}
+
 func (b *ContainerBuilder) Text(name, format string, args ...any) (any, error) {
 	return b.AddComponent(name, layout.NewText(format, args...))
 }
@@ -69,3 +127,46 @@ func (b *ContainerBuilder) TitledContainer(name, title string) (any, error) {
 func (b *ContainerBuilder) Value(name, label, format string, args ...any) (any, error) {
 	return b.AddComponent(name, layout.NewValue(label, format, args...))
 }
+
+func (b *ContainerBuilder) WindRose(name string) (any, error) {
+	return b.AddComponent(name, layout.NewWindRose())
+}
+
+// Gap sets the space between components of a FlexContainer or GridContainer
+func (b *ContainerBuilder) Gap(gap int) any {
+	switch c := b.comp.(type) {
+	case *layout.FlexContainer:
+		c.Gap(gap)
+	case *layout.GridContainer:
+		c.Gap(gap, gap)
+	}
+	return b.this
+}
+
+// Justify sets the main axis alignment of a FlexContainer, e.g. "center" or "space-between"
+func (b *ContainerBuilder) Justify(s string) any {
+	if c, ok := b.comp.(*layout.FlexContainer); ok {
+		c.Justify(layout.ParseFlexAlign(s))
+	}
+	return b.this
+}
+
+// AlignItems sets the cross axis alignment of a FlexContainer, e.g. "start" or "stretch"
+func (b *ContainerBuilder) AlignItems(s string) any {
+	if c, ok := b.comp.(*layout.FlexContainer); ok {
+		c.AlignItems(layout.ParseFlexAlign(s))
+	}
+	return b.this
+}
+
+// Rows sets the row tracks of a GridContainer
+func (b *ContainerBuilder) Rows(rows string) (any, error) {
+	tracks, err := layout.ParseTracks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := b.comp.(*layout.GridContainer); ok {
+		c.Rows(tracks...)
+	}
+	return b.this, nil
+}
@@ -0,0 +1,36 @@
+package layout
+
+import (
+	"github.com/peter-mount/go-anim/layout"
+)
+
+// image returns the component if it is an Image
+func (b *ComponentBuilder) image() (*layout.Image, bool) {
+	i, ok := b.comp.(*layout.Image)
+	return i, ok
+}
+
+// Fit sets how an Image is scaled, one of "contain", "cover", "fill" or "none"
+func (b *ComponentBuilder) Fit(s string) any {
+	if i, ok := b.image(); ok {
+		i.Fit(layout.ParseImageFit(s))
+	}
+	return b.this
+}
+
+// Interpolation sets how an Image is scaled, one of "nearest", "bilinear", "bicubic", "mitchell",
+// "lanczos2" or "lanczos3"
+func (b *ComponentBuilder) Interpolation(s string) any {
+	if i, ok := b.image(); ok {
+		i.Interpolation(layout.ParseInterpolation(s))
+	}
+	return b.this
+}
+
+// Anchor sets the position of an Image when it does not fill the component, e.g. "center" or "top left"
+func (b *ComponentBuilder) Anchor(s string) any {
+	if i, ok := b.image(); ok {
+		i.Anchor(s)
+	}
+	return b.this
+}
@@ -24,10 +24,67 @@ func (*Package) ColScaleContainer(scales ...float64) layout.Container {
 	return layout.ColScaleContainer(scales...)
 }
 
+func (*Package) FlexRow() *layout.FlexContainer {
+	return layout.FlexRow()
+}
+
+func (*Package) FlexColumn() *layout.FlexContainer {
+	return layout.FlexColumn()
+}
+
+func (*Package) Grid(columns string) (*layout.GridContainer, error) {
+	tracks, err := layout.ParseTracks(columns)
+	if err != nil {
+		return nil, err
+	}
+	return layout.NewGridContainer(tracks...), nil
+}
+
 func (*Package) Image() layout.Component {
 	return layout.NewImage()
 }
 
+// SvgImage returns an SvgImage showing the SVG file fileName
+func (*Package) SvgImage(fileName string) (*layout.SvgImage, error) {
+	c := layout.NewSvgImage()
+	if err := c.Load(fileName); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (*Package) RichText(format string, args ...any) *layout.RichText {
+	return layout.NewRichText(format, args...)
+}
+
 func (*Package) Text(format string, args ...any) layout.Component {
 	return layout.NewText(format, args...)
 }
+
+func (*Package) LineChart() *layout.LineChart {
+	return layout.NewLineChart()
+}
+
+func (*Package) Sparkline() *layout.Sparkline {
+	return layout.NewSparkline()
+}
+
+func (*Package) BarChart() *layout.BarChart {
+	return layout.NewBarChart()
+}
+
+func (*Package) Gauge(min, max float64) *layout.Gauge {
+	return layout.NewGauge(min, max)
+}
+
+func (*Package) WindRose() *layout.WindRose {
+	return layout.NewWindRose()
+}
+
+func (*Package) PolarPlot() *layout.PolarPlot {
+	return layout.NewPolarPlot()
+}
+
+func (*Package) TrackMap() *layout.TrackMap {
+	return layout.NewTrackMap()
+}
@@ -0,0 +1,82 @@
+package layout
+
+import (
+	"github.com/peter-mount/go-anim/layout"
+	"image/color"
+)
+
+// table returns the component if it is a Table
+func (b *ComponentBuilder) table() (*layout.Table, bool) {
+	t, ok := b.comp.(*layout.Table)
+	return t, ok
+}
+
+// Column adds a column to a Table. width is "auto", a fraction like "1fr" or a size like "120" or "8em",
+// align is "left", "center" or "right" and format the format of each value, e.g. "%.1f"
+func (b *ComponentBuilder) Column(title, width, align, format string) (any, error) {
+	if t, ok := b.table(); ok {
+		c, err := layout.NewTableColumn(title).Align(align).Format(format).Width(width)
+		if err != nil {
+			return nil, err
+		}
+		t.AddColumn(c)
+	}
+	return b.this, nil
+}
+
+// Header shows or hides the header row of a Table
+func (b *ComponentBuilder) Header(show bool) any {
+	if t, ok := b.table(); ok {
+		t.Header(show)
+	}
+	return b.this
+}
+
+// HeaderFont sets the font of the header row of a Table
+func (b *ComponentBuilder) HeaderFont(font string) any {
+	if t, ok := b.table(); ok {
+		t.HeaderFont(font)
+	}
+	return b.this
+}
+
+// HeaderFill sets the background of the header row of a Table
+func (b *ComponentBuilder) HeaderFill(c color.Color) any {
+	if t, ok := b.table(); ok {
+		t.HeaderFill(c)
+	}
+	return b.this
+}
+
+// Stripe sets the background of alternate rows of a Table
+func (b *ComponentBuilder) Stripe(c color.Color) any {
+	if t, ok := b.table(); ok {
+		t.Stripe(c)
+	}
+	return b.this
+}
+
+// Border sets the colour of the borders of a Table
+func (b *ComponentBuilder) Border(c color.Color) any {
+	if t, ok := b.table(); ok {
+		t.Border(c)
+	}
+	return b.this
+}
+
+// CellPadding sets the space around the text in each cell of a Table
+func (b *ComponentBuilder) CellPadding(p int) any {
+	if t, ok := b.table(); ok {
+		t.CellPadding(p)
+	}
+	return b.this
+}
+
+// CellStyle sets the background and text colour of a cell of a Table, either can be nil.
+// Row -1 is the header row.
+func (b *ComponentBuilder) CellStyle(column, row int, fill, colour color.Color) any {
+	if t, ok := b.table(); ok {
+		t.CellStyle(column, row).Fill(fill).Colour(colour)
+	}
+	return b.this
+}
@@ -2,6 +2,7 @@ package render
 
 import (
 	"fmt"
+	"github.com/peter-mount/go-anim/renderer"
 	"github.com/peter-mount/go-anim/util/time"
 	"github.com/peter-mount/go-script/packages"
 	"image"
@@ -145,6 +146,8 @@ type RenderStream interface {
 	Writer
 	TimeCode() *time.TimeCode
 	EncodeBytes(img image.Image) ([]byte, error)
+	WriteContext(ctx renderer.Context) error
+	Attach(ctx renderer.Context) RenderStream
 }
 
 type RenderStreamBase struct {
@@ -154,6 +157,7 @@ type RenderStreamBase struct {
 	encoder  Encoder                     // Frame encoder
 	init     func(img image.Image) error // init function
 	write    func(b []byte) (int, error) // write function
+	contexts []renderer.Context          // Contexts whose frames are begun by an Iterator
 }
 
 func (s *RenderStreamBase) Init(_ image.Image) error {
@@ -203,6 +207,24 @@ func (s *RenderStreamBase) WriteImageN(img image.Image, num int) error {
 	return err
 }
 
+// WriteContext ends the current frame of ctx, so its end of frame hooks such as overlays are drawn,
+// then writes its image to the stream.
+func (s *RenderStreamBase) WriteContext(ctx renderer.Context) error {
+	if err := ctx.EndFrame(); err != nil {
+		return err
+	}
+	return s.WriteImage(ctx.Image())
+}
+
+// Attach ctx to the stream so that an Iterator begins each frame of ctx with the frame's TimeCode
+// before it is drawn. Use WriteContext to end and write the frame.
+func (s *RenderStreamBase) Attach(ctx renderer.Context) RenderStream {
+	if ctx != nil {
+		s.contexts = append(s.contexts, ctx)
+	}
+	return s
+}
+
 func (s *RenderStreamBase) EncodeBytes(img image.Image) ([]byte, error) {
 	return s.encoder.EncodeBytes(img)
 }
@@ -221,8 +243,9 @@ func (s *RenderStreamBase) FrameRateF() float64 {
 
 // Iterator is returned by a renderer to handle spanning over a range of TimeCode's.
 // Unlike the iterator returned by TimeCode, this one does not advance the TimeCode when Next() is
-// called as that's done when writing an image.
+// called as that's done when writing an image. Next() does begin the frame of any attached contexts.
 type Iterator struct {
+	stream  *RenderStreamBase     // The stream being iterated
 	tc      *time.TimeCode        // Pointer to underlying TimeCode
 	running bool                  // set after first call to Next()
 	last    time.TimeCodeFragment // The last value returned by Next()
@@ -249,14 +272,22 @@ func (i *Iterator) Next() interface{} {
 
 	i.running = true
 	i.last = tc
+
+	for _, ctx := range i.stream.contexts {
+		if err := ctx.BeginFrame(tc); err != nil {
+			panic(err)
+		}
+	}
+
 	return tc
 }
 
 func (s *RenderStreamBase) runUntil(tcf time.TimeCodeFragment) *Iterator {
 	// Add 1 frame as end is the TimeCode of the frame after the iterator
 	return &Iterator{
-		tc:  s.TimeCode(),
-		end: tcf.Add(0, 0, 0, 0, 1),
+		stream: s,
+		tc:     s.TimeCode(),
+		end:    tcf.Add(0, 0, 0, 0, 1),
 	}
 }
 
@@ -0,0 +1,35 @@
+package series
+
+import (
+	"github.com/peter-mount/go-anim/util/series"
+	"github.com/peter-mount/go-script/packages"
+	"time"
+)
+
+func init() {
+	packages.RegisterPackage(&Series{})
+}
+
+// Series provides time series data to scripts, so telemetry can be bound to a layout
+type Series struct {
+}
+
+// Load a DataSet from a CSV or JSON Lines file using the local timezone
+func (_ Series) Load(fileName, timeField string) (*series.DataSet, error) {
+	return series.Load(fileName, timeField, time.Local)
+}
+
+// LoadIn loads a DataSet from a CSV or JSON Lines file using the specified timezone
+func (_ Series) LoadIn(fileName, timeField string, loc *time.Location) (*series.DataSet, error) {
+	return series.Load(fileName, timeField, loc)
+}
+
+// New returns an empty DataSet
+func (_ Series) New() *series.DataSet {
+	return series.NewDataSet()
+}
+
+// Interpolation returns the named Interpolation, one of "linear", "step" or "nearest"
+func (_ Series) Interpolation(s string) (series.Interpolation, error) {
+	return series.ParseInterpolation(s)
+}
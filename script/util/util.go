@@ -1,8 +1,9 @@
 package util
 
 import (
-	"github.com/llgcode/draw2d/draw2dimg"
+	"github.com/peter-mount/go-anim/renderer"
 	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
 	"github.com/peter-mount/go-script/packages"
 	"image/color"
 	"image/draw"
@@ -19,11 +20,11 @@ func (_ *Util) Rect(x1, y1, x2, y2 float64) util.Rectangle {
 	return util.Rect(x1, y1, x2, y2)
 }
 
-func (_ *Util) GetStringBounds(gc *draw2dimg.GraphicContext, s string) util.Rectangle {
+func (_ *Util) GetStringBounds(gc draw2d2.GraphicContext, s string) util.Rectangle {
 	return util.GetStringBounds(gc, s)
 }
 
-func (_ *Util) StringSize(gc *draw2dimg.GraphicContext, s string, a ...interface{}) util.Rectangle {
+func (_ *Util) StringSize(gc draw2d2.GraphicContext, s string, a ...interface{}) util.Rectangle {
 	return util.StringSize(gc, s, a...)
 }
 
@@ -31,30 +32,36 @@ func (_ *Util) FitString(l, t, r, b, sl, st, sr, sb float64) (float64, float64,
 	return util.FitString(l, t, r, b, sl, st, sr, sb)
 }
 
-func (_ *Util) DrawStringLeft(gc *draw2dimg.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
+func (_ *Util) DrawStringLeft(gc draw2d2.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
 	return util.DrawStringLeft(gc, x, y, s, a...)
 }
 
-func (_ *Util) DrawStringCenter(gc *draw2dimg.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
+func (_ *Util) DrawStringCenter(gc draw2d2.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
 	return util.DrawStringCenter(gc, x, y, s, a...)
 }
 
-func (_ *Util) DrawStringRight(gc *draw2dimg.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
+func (_ *Util) DrawStringRight(gc draw2d2.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
 	return util.DrawStringRight(gc, x, y, s, a...)
 }
 
+// NewTextEffects creates TextEffects, used with its DrawString functions to draw text with effects
+func (_ *Util) NewTextEffects() *util.TextEffects {
+	return util.NewTextEffects()
+}
+
 func (_ *Util) FloatToA(v float64) string {
 	return util.FloatToA(v)
 }
 
-func (_ *Util) DrawColourBars(gc *draw2dimg.GraphicContext, bounds util.Rectangle, cols ...color.Color) (float64, float64) {
+func (_ *Util) DrawColourBars(gc draw2d2.GraphicContext, bounds util.Rectangle, cols ...color.Color) (float64, float64) {
 	return util.DrawColourBars(gc, bounds, cols...)
 }
 
-func (_ *Util) DrawColourBarsVertical(gc *draw2dimg.GraphicContext, bounds util.Rectangle, cols ...color.Color) (float64, float64) {
+func (_ *Util) DrawColourBarsVertical(gc draw2d2.GraphicContext, bounds util.Rectangle, cols ...color.Color) (float64, float64) {
 	return util.DrawColourBarsVertical(gc, bounds, cols...)
 }
 
-func (_ *Util) NewGraphicContext(img draw.Image) *draw2dimg.GraphicContext {
-	return draw2dimg.NewGraphicContext(img)
+// NewGraphicContext returns a GraphicContext drawing onto img, using the default backend
+func (_ *Util) NewGraphicContext(img draw.Image) draw2d2.GraphicContext {
+	return renderer.DefaultBackend().NewGraphicContext(img)
 }
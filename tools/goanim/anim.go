@@ -3,6 +3,7 @@ package goanim
 import (
 	"github.com/llgcode/draw2d"
 	_ "github.com/peter-mount/go-anim/script"
+	"github.com/peter-mount/go-anim/util/font"
 	"github.com/peter-mount/go-build/application"
 	_ "github.com/peter-mount/go-script/stdlib"
 	_ "github.com/peter-mount/go-script/stdlib/fmt"
@@ -10,17 +11,40 @@ import (
 	_ "github.com/peter-mount/go-script/stdlib/math"
 	_ "github.com/peter-mount/go-script/stdlib/time"
 	"github.com/peter-mount/go-script/tools/goscript"
+	"strings"
 )
 
 type Anim struct {
-	_ *goscript.Script `kernel:"inject"`
+	_           *goscript.Script `kernel:"inject"`
+	FontDirs    *string          `kernel:"flag,fonts,Comma separated list of additional font directories"`
+	SystemFonts *bool            `kernel:"flag,system-fonts,Make installed system fonts available"`
 }
 
 func (a *Anim) Start() error {
 
 	// Set location of our fonts
 	//draw2d.SetFontFolder(path.Join(filepath.Dir(os.Args[0]), "../lib/font"))
-	draw2d.SetFontFolder(application.FileName(application.STATIC, "font"))
+	fontDir := application.FileName(application.STATIC, "font")
+	draw2d.SetFontFolder(fontDir)
+
+	// Index the bundled fonts so they can also be used by family name, e.g. "Luxi Sans 700 12"
+	if err := font.DefaultRegistry.AddDir(fontDir); err != nil {
+		return err
+	}
+
+	if *a.SystemFonts {
+		if err := font.DefaultRegistry.AddSystemFonts(); err != nil {
+			return err
+		}
+	}
+
+	if *a.FontDirs != "" {
+		for _, dir := range strings.Split(*a.FontDirs, ",") {
+			if err := font.DefaultRegistry.AddDir(strings.TrimSpace(dir)); err != nil {
+				return err
+			}
+		}
+	}
 
 	return nil
 }
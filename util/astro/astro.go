@@ -0,0 +1,108 @@
+// Package astro provides calculations for the position of the Sun and Moon
+// for an observer on the Earth.
+//
+// The algorithms are based on those used by the suncalc javascript library
+// https://github.com/mourner/suncalc which in turn are based on the formulas
+// from https://aa.quae.nl/en/reken/hemelpositie.html
+//
+// All angles exposed by this package are in degrees. Azimuths are measured
+// clockwise from north.
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	rad     = math.Pi / 180.0       // Degrees to Radians
+	dayMs   = 1000.0 * 60 * 60 * 24 // Milliseconds per day
+	j1970   = 2440588.0             // Julian day of the unix epoch
+	j2000   = 2451545.0             // Julian day of the J2000 epoch
+	obliq   = rad * 23.4397         // obliquity of the Earth
+	j0      = 0.0009                // used in calculating the julian cycle
+	sunDist = 149598000.0           // Distance of the Sun in km
+)
+
+// Position is the position of a body in the sky.
+type Position struct {
+	Altitude         float64 // Altitude in degrees above the horizon
+	Azimuth          float64 // Azimuth in degrees clockwise from north
+	Distance         float64 // Distance in km, only set for the Moon
+	ParallacticAngle float64 // Parallactic angle in degrees, only set for the Moon
+}
+
+// IsAboveHorizon returns true if the body is above the horizon
+func (p Position) IsAboveHorizon() bool {
+	return p.Altitude > 0
+}
+
+func toJulian(t time.Time) float64 {
+	return float64(t.UnixMilli())/dayMs - 0.5 + j1970
+}
+
+func fromJulian(j float64, loc *time.Location) time.Time {
+	return time.UnixMilli(int64(math.Round((j + 0.5 - j1970) * dayMs))).In(loc)
+}
+
+func toDays(t time.Time) float64 {
+	return toJulian(t) - j2000
+}
+
+func rightAscension(l, b float64) float64 {
+	return math.Atan2(math.Sin(l)*math.Cos(obliq)-math.Tan(b)*math.Sin(obliq), math.Cos(l))
+}
+
+func declination(l, b float64) float64 {
+	return math.Asin(math.Sin(b)*math.Cos(obliq) + math.Cos(b)*math.Sin(obliq)*math.Sin(l))
+}
+
+// azimuth returns the azimuth in radians measured from south, westwards
+func azimuth(h, phi, dec float64) float64 {
+	return math.Atan2(math.Sin(h), math.Cos(h)*math.Sin(phi)-math.Tan(dec)*math.Cos(phi))
+}
+
+func altitude(h, phi, dec float64) float64 {
+	return math.Asin(math.Sin(phi)*math.Sin(dec) + math.Cos(phi)*math.Cos(dec)*math.Cos(h))
+}
+
+func siderealTime(d, lw float64) float64 {
+	return rad*(280.16+360.9856235*d) - lw
+}
+
+// astroRefraction returns the atmospheric refraction for an altitude in radians
+func astroRefraction(h float64) float64 {
+	// the following formula works for positive altitudes only.
+	// if h = -0.08901179 a div/0 would occur.
+	if h < 0 {
+		h = 0
+	}
+	// formula 16.4 of "Astronomical Algorithms" 2nd edition by Jean Meeus (Willmann-Bell, Richmond) 1998.
+	// 1.02 / tan(h + 10.26 / (h + 5.10)) h in degrees, result in arc minutes -> converted to rad:
+	return 0.0002967 / math.Tan(h+0.00312536/(h+0.08901179))
+}
+
+// compass converts an azimuth measured from south to degrees clockwise from north
+func compass(az float64) float64 {
+	return normalize(az/rad + 180.0)
+}
+
+// normalize an angle in degrees to the range 0 <= a < 360
+func normalize(a float64) float64 {
+	a = math.Mod(a, 360.0)
+	if a < 0 {
+		a += 360.0
+	}
+	return a
+}
+
+// startOfDay returns midnight at the start of the day containing t in t's location
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// hoursLater returns t plus h hours
+func hoursLater(t time.Time, h float64) time.Time {
+	return t.Add(time.Duration(h * float64(time.Hour)))
+}
@@ -0,0 +1,133 @@
+package astro
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Test values are from the suncalc test suite https://github.com/mourner/suncalc
+const (
+	testLat = 50.5
+	testLon = 30.5
+)
+
+func near(a, b, margin float64) bool {
+	return math.Abs(a-b) < margin
+}
+
+func nearTime(t *testing.T, name string, got time.Time, want string) {
+	w, err := time.Parse(time.RFC3339, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := got.Sub(w); d < -time.Second || d > time.Second {
+		t.Errorf("%s got %s want %s", name, got.Format(time.RFC3339), want)
+	}
+}
+
+func TestSunPosition(t *testing.T) {
+	p := SunPosition(time.Date(2013, 3, 5, 0, 0, 0, 0, time.UTC), testLat, testLon)
+
+	if want := compass(-2.5003175907168385); !near(p.Azimuth, want, 1e-9) {
+		t.Errorf("azimuth got %f want %f", p.Azimuth, want)
+	}
+	if want := -0.7000406838781611 / rad; !near(p.Altitude, want, 1e-9) {
+		t.Errorf("altitude got %f want %f", p.Altitude, want)
+	}
+}
+
+func TestGetSunTimes(t *testing.T) {
+	st := GetSunTimes(time.Date(2013, 3, 5, 0, 0, 0, 0, time.UTC), testLat, testLon)
+
+	nearTime(t, "Transit", st.Transit, "2013-03-05T10:10:57Z")
+	nearTime(t, "Nadir", st.Nadir, "2013-03-04T22:10:57Z")
+	nearTime(t, "Sunrise", st.Sunrise, "2013-03-05T04:34:56Z")
+	nearTime(t, "Sunset", st.Sunset, "2013-03-05T15:46:57Z")
+	nearTime(t, "SunriseEnd", st.SunriseEnd, "2013-03-05T04:38:19Z")
+	nearTime(t, "SunsetStart", st.SunsetStart, "2013-03-05T15:43:34Z")
+	nearTime(t, "CivilDawn", st.CivilDawn, "2013-03-05T04:02:17Z")
+	nearTime(t, "CivilDusk", st.CivilDusk, "2013-03-05T16:19:36Z")
+	nearTime(t, "NauticalDawn", st.NauticalDawn, "2013-03-05T03:24:31Z")
+	nearTime(t, "NauticalDusk", st.NauticalDusk, "2013-03-05T16:57:22Z")
+	nearTime(t, "AstronomicalDawn", st.AstronomicalDawn, "2013-03-05T02:46:17Z")
+	nearTime(t, "AstronomicalDusk", st.AstronomicalDusk, "2013-03-05T17:35:36Z")
+	nearTime(t, "GoldenHourEnd", st.GoldenHourEnd, "2013-03-05T05:19:01Z")
+	nearTime(t, "GoldenHour", st.GoldenHour, "2013-03-05T15:02:52Z")
+}
+
+func TestGetSunTimes_polar(t *testing.T) {
+	// Midsummer within the arctic circle so the Sun never sets
+	st := GetSunTimes(time.Date(2013, 6, 21, 0, 0, 0, 0, time.UTC), 78.2, 15.6)
+	if !st.Sunrise.IsZero() || !st.Sunset.IsZero() {
+		t.Errorf("expected no sunrise or sunset, got %v %v", st.Sunrise, st.Sunset)
+	}
+	if st.DayLength() != 0 {
+		t.Errorf("expected 0 day length got %v", st.DayLength())
+	}
+}
+
+func TestMoonPosition(t *testing.T) {
+	p := MoonPosition(time.Date(2013, 3, 5, 0, 0, 0, 0, time.UTC), testLat, testLon)
+
+	if want := compass(-0.9783999522438226); !near(p.Azimuth, want, 1e-9) {
+		t.Errorf("azimuth got %f want %f", p.Azimuth, want)
+	}
+	if want := 0.014551482243892251 / rad; !near(p.Altitude, want, 1e-9) {
+		t.Errorf("altitude got %f want %f", p.Altitude, want)
+	}
+	if !near(p.Distance, 364121.37256256194, 1e-6) {
+		t.Errorf("distance got %f", p.Distance)
+	}
+}
+
+func TestGetMoonPhase(t *testing.T) {
+	p := GetMoonPhase(time.Date(2013, 3, 5, 0, 0, 0, 0, time.UTC))
+
+	if !near(p.Fraction, 0.4848068202456373, 1e-9) {
+		t.Errorf("fraction got %f", p.Fraction)
+	}
+	if !near(p.Phase, 0.7548368838538762, 1e-9) {
+		t.Errorf("phase got %f", p.Phase)
+	}
+	if want := 1.6732942678578346 / rad; !near(p.Angle, want, 1e-9) {
+		t.Errorf("angle got %f want %f", p.Angle, want)
+	}
+	if n := p.Name(); n != "Last Quarter" {
+		t.Errorf("name got %q", n)
+	}
+}
+
+func TestGetMoonTimes(t *testing.T) {
+	mt := GetMoonTimes(time.Date(2013, 3, 4, 0, 0, 0, 0, time.UTC), testLat, testLon)
+
+	nearTime(t, "Rise", mt.Rise, "2013-03-04T23:54:29Z")
+	nearTime(t, "Set", mt.Set, "2013-03-04T07:47:58Z")
+	if mt.AlwaysUp || mt.AlwaysDown {
+		t.Errorf("expected neither AlwaysUp or AlwaysDown")
+	}
+	// Last quarter so it rose the previous evening and transits before setting
+	if mt.Transit.IsZero() || !mt.Transit.Before(mt.Set) {
+		t.Errorf("unexpected transit %v", mt.Transit)
+	}
+}
+
+func TestTwilightForAltitude(t *testing.T) {
+	tests := []struct {
+		alt  float64
+		want Twilight
+	}{
+		{alt: 10, want: Day},
+		{alt: -0.5, want: Day},
+		{alt: -1, want: CivilTwilight},
+		{alt: -6, want: CivilTwilight},
+		{alt: -7, want: NauticalTwilight},
+		{alt: -13, want: AstronomicalTwilight},
+		{alt: -19, want: Night},
+	}
+	for _, tt := range tests {
+		if got := TwilightForAltitude(tt.alt); got != tt.want {
+			t.Errorf("altitude %f got %s want %s", tt.alt, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// MoonTimes holds the times of the Moon's daily events.
+// Rise, Set or Transit will be the zero time.Time if that event does not occur on the day.
+type MoonTimes struct {
+	Rise       time.Time // Time of moonrise
+	Set        time.Time // Time of moonset
+	Transit    time.Time // Time the Moon crosses the meridian
+	AlwaysUp   bool      // true if the Moon never sets during the day
+	AlwaysDown bool      // true if the Moon never rises during the day
+}
+
+// MoonPhase describes the illuminated portion of the Moon.
+type MoonPhase struct {
+	Fraction float64 // Illuminated fraction of the Moon, 0 = new moon, 1 = full moon
+	Phase    float64 // Phase, 0 = new moon, 0.25 first quarter, 0.5 full moon, 0.75 last quarter
+	Angle    float64 // Midpoint angle in degrees of the illuminated limb, negative when waxing
+}
+
+// Phase names, in order as Phase moves from 0 to 1
+var phaseNames = []string{
+	"New Moon",
+	"Waxing Crescent",
+	"First Quarter",
+	"Waxing Gibbous",
+	"Full Moon",
+	"Waning Gibbous",
+	"Last Quarter",
+	"Waning Crescent",
+}
+
+// Name returns the name of the phase, e.g. "First Quarter"
+func (p MoonPhase) Name() string {
+	// Each name is centered on its phase, so offset by half a segment
+	i := int(math.Floor(p.Phase*8+0.5)) % len(phaseNames)
+	return phaseNames[i]
+}
+
+// IsWaxing returns true if the Moon is waxing
+func (p MoonPhase) IsWaxing() bool {
+	return p.Phase < 0.5
+}
+
+// moonCoords returns the declination, right ascension and distance in km of the Moon.
+// This is based on http://aa.quae.nl/en/reken/hemelpositie.html formulas
+func moonCoords(d float64) (float64, float64, float64) {
+	el := rad * (218.316 + 13.176396*d) // ecliptic longitude
+	m := rad * (134.963 + 13.064993*d)  // mean anomaly
+	f := rad * (93.272 + 13.229350*d)   // mean distance
+
+	l := el + rad*6.289*math.Sin(m)  // longitude
+	b := rad * 5.128 * math.Sin(f)   // latitude
+	dt := 385001 - 20905*math.Cos(m) // distance to the moon in km
+
+	return declination(l, b), rightAscension(l, b), dt
+}
+
+// moonPosition returns the Moon's altitude & azimuth in radians along with the hour angle
+func moonPosition(t time.Time, lat, lon float64) (Position, float64) {
+	lw, phi, d := rad*-lon, rad*lat, toDays(t)
+	dec, ra, dist := moonCoords(d)
+	h := siderealTime(d, lw) - ra
+	alt := altitude(h, phi, dec)
+	// formula 14.1 of "Astronomical Algorithms" 2nd edition by Jean Meeus (Willmann-Bell, Richmond) 1998.
+	pa := math.Atan2(math.Sin(h), math.Tan(phi)*math.Cos(dec)-math.Sin(dec)*math.Cos(h))
+	return Position{
+		Altitude:         alt + astroRefraction(alt),
+		Azimuth:          azimuth(h, phi, dec),
+		Distance:         dist,
+		ParallacticAngle: pa,
+	}, h
+}
+
+// MoonPosition returns the position of the Moon at a specific time and location.
+// lat and lon are in degrees, with positive values north and east respectively.
+func MoonPosition(t time.Time, lat, lon float64) Position {
+	p, _ := moonPosition(t, lat, lon)
+	p.Altitude = p.Altitude / rad
+	p.Azimuth = compass(p.Azimuth)
+	p.ParallacticAngle = p.ParallacticAngle / rad
+	return p
+}
+
+// GetMoonPhase returns the illumination of the Moon at a specific time
+func GetMoonPhase(t time.Time) MoonPhase {
+	d := toDays(t)
+	sDec, sRa := sunCoords(d)
+	mDec, mRa, mDist := moonCoords(d)
+
+	// geocentric elongation of the Moon from the Sun
+	phi := math.Acos(math.Sin(sDec)*math.Sin(mDec) + math.Cos(sDec)*math.Cos(mDec)*math.Cos(sRa-mRa))
+	// selenocentric (Moon centric) elongation of the Earth from the Sun
+	inc := math.Atan2(sunDist*math.Sin(phi), mDist-sunDist*math.Cos(phi))
+	angle := math.Atan2(math.Cos(sDec)*math.Sin(sRa-mRa),
+		math.Sin(sDec)*math.Cos(mDec)-math.Cos(sDec)*math.Sin(mDec)*math.Cos(sRa-mRa))
+
+	sign := 1.0
+	if angle < 0 {
+		sign = -1.0
+	}
+
+	return MoonPhase{
+		Fraction: (1 + math.Cos(inc)) / 2,
+		Phase:    0.5 + 0.5*inc*sign/math.Pi,
+		Angle:    angle / rad,
+	}
+}
+
+// GetMoonTimes returns the times of the Moon's events for the day containing t,
+// in the location of t.
+// lat and lon are in degrees, with positive values north and east respectively.
+func GetMoonTimes(t time.Time, lat, lon float64) MoonTimes {
+	t = startOfDay(t)
+
+	hc := 0.133 * rad
+	alt := func(h float64) float64 {
+		p, _ := moonPosition(hoursLater(t, h), lat, lon)
+		return p.Altitude - hc
+	}
+
+	var mt MoonTimes
+	var rise, set, ye float64
+	var hasRise, hasSet bool
+
+	// go in 2-hour chunks, each time seeing if a 3-point quadratic curve crosses zero (which means rise or set)
+	h0 := alt(0)
+	for i := 1.0; i <= 24; i += 2 {
+		h1, h2 := alt(i), alt(i+1)
+
+		a := (h0+h2)/2 - h1
+		b := (h2 - h0) / 2
+		xe := -b / (2 * a)
+		ye = (a*xe+b)*xe + h1
+		d := b*b - 4*a*h1
+		roots := 0
+
+		var x1, x2 float64
+		if d >= 0 {
+			dx := math.Sqrt(d) / (math.Abs(a) * 2)
+			x1, x2 = xe-dx, xe+dx
+			if math.Abs(x1) <= 1 {
+				roots++
+			}
+			if math.Abs(x2) <= 1 {
+				roots++
+			}
+			if x1 < -1 {
+				x1 = x2
+			}
+		}
+
+		switch roots {
+		case 1:
+			if h0 < 0 {
+				rise, hasRise = i+x1, true
+			} else {
+				set, hasSet = i+x1, true
+			}
+		case 2:
+			hasRise, hasSet = true, true
+			if ye < 0 {
+				rise, set = i+x2, i+x1
+			} else {
+				rise, set = i+x1, i+x2
+			}
+		}
+
+		if hasRise && hasSet {
+			break
+		}
+
+		h0 = h2
+	}
+
+	if hasRise {
+		mt.Rise = hoursLater(t, rise)
+	}
+	if hasSet {
+		mt.Set = hoursLater(t, set)
+	}
+	if !hasRise && !hasSet {
+		mt.AlwaysUp = ye > 0
+		mt.AlwaysDown = !mt.AlwaysUp
+	}
+
+	mt.Transit = moonTransit(t, lat, lon)
+
+	return mt
+}
+
+// moonTransit returns the time during the day starting at t when the Moon crosses the meridian,
+// or the zero time.Time if it does not cross on that day
+func moonTransit(t time.Time, lat, lon float64) time.Time {
+	// hour angle normalised to -Pi..Pi, so the transit is when it crosses from -ve to +ve
+	ha := func(h float64) float64 {
+		_, a := moonPosition(hoursLater(t, h), lat, lon)
+		return math.Remainder(a, 2*math.Pi)
+	}
+
+	h0 := ha(0)
+	for i := 1.0; i <= 24; i++ {
+		h1 := ha(i)
+		// Only accept small hour angles, otherwise this is the crossing at 180 degrees
+		if h0 < 0 && h1 >= 0 && h1-h0 < math.Pi {
+			// Refine by bisection to within a second
+			lo, hi := i-1, i
+			for hi-lo > 1.0/3600 {
+				mid := (lo + hi) / 2
+				if ha(mid) < 0 {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			return hoursLater(t, lo)
+		}
+		h0 = h1
+	}
+	return time.Time{}
+}
@@ -0,0 +1,133 @@
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// SunTimes holds the times of the Sun's daily events.
+// Any event which does not occur on the day, e.g. sunset during the polar summer,
+// will be the zero time.Time.
+type SunTimes struct {
+	Transit          time.Time // Solar noon, when the Sun is at its highest
+	Nadir            time.Time // Darkest moment of the night, when the Sun is at its lowest
+	Sunrise          time.Time // Top edge of the Sun appears on the horizon
+	Sunset           time.Time // Sun disappears below the horizon
+	SunriseEnd       time.Time // Bottom edge of the Sun touches the horizon
+	SunsetStart      time.Time // Bottom edge of the Sun touches the horizon
+	CivilDawn        time.Time // Morning civil twilight starts
+	CivilDusk        time.Time // Evening civil twilight ends
+	NauticalDawn     time.Time // Morning nautical twilight starts
+	NauticalDusk     time.Time // Evening nautical twilight ends
+	AstronomicalDawn time.Time // Morning astronomical twilight starts, night ends
+	AstronomicalDusk time.Time // Evening astronomical twilight ends, night starts
+	GoldenHourEnd    time.Time // Morning golden hour ends
+	GoldenHour       time.Time // Evening golden hour starts
+}
+
+// Altitudes in degrees of the Sun's centre for each event
+const (
+	SunriseAltitude      = -0.833 // Sunrise & Sunset
+	SunriseEndAltitude   = -0.3   // SunriseEnd & SunsetStart
+	CivilAltitude        = -6.0   // Civil twilight
+	NauticalAltitude     = -12.0  // Nautical twilight
+	AstronomicalAltitude = -18.0  // Astronomical twilight
+	GoldenHourAltitude   = 6.0    // Golden hour
+)
+
+func solarMeanAnomaly(d float64) float64 {
+	return rad * (357.5291 + 0.98560028*d)
+}
+
+func eclipticLongitude(m float64) float64 {
+	// equation of center
+	c := rad * (1.9148*math.Sin(m) + 0.02*math.Sin(2*m) + 0.0003*math.Sin(3*m))
+	// perihelion of the Earth
+	p := rad * 102.9372
+	return m + c + p + math.Pi
+}
+
+// sunCoords returns the declination and right ascension of the Sun
+func sunCoords(d float64) (float64, float64) {
+	l := eclipticLongitude(solarMeanAnomaly(d))
+	return declination(l, 0), rightAscension(l, 0)
+}
+
+// SunPosition returns the position of the Sun at a specific time and location.
+// lat and lon are in degrees, with positive values north and east respectively.
+func SunPosition(t time.Time, lat, lon float64) Position {
+	lw, phi, d := rad*-lon, rad*lat, toDays(t)
+	dec, ra := sunCoords(d)
+	h := siderealTime(d, lw) - ra
+	return Position{
+		Altitude: altitude(h, phi, dec) / rad,
+		Azimuth:  compass(azimuth(h, phi, dec)),
+	}
+}
+
+func julianCycle(d, lw float64) float64 {
+	return math.Round(d - j0 - lw/(2*math.Pi))
+}
+
+func approxTransit(ht, lw, n float64) float64 {
+	return j0 + (ht+lw)/(2*math.Pi) + n
+}
+
+func solarTransitJ(ds, m, l float64) float64 {
+	return j2000 + ds + 0.0053*math.Sin(m) - 0.0069*math.Sin(2*l)
+}
+
+func hourAngle(h, phi, d float64) float64 {
+	return math.Acos((math.Sin(h) - math.Sin(phi)*math.Sin(d)) / (math.Cos(phi) * math.Cos(d)))
+}
+
+// GetSunTimes returns the times of the Sun's events for the day containing t,
+// in the location of t.
+// lat and lon are in degrees, with positive values north and east respectively.
+func GetSunTimes(t time.Time, lat, lon float64) SunTimes {
+	loc := t.Location()
+
+	// Use midday, so we get the events for the requested day
+	noon := hoursLater(startOfDay(t), 12)
+
+	lw, phi := rad*-lon, rad*lat
+	n := julianCycle(toDays(noon), lw)
+	ds := approxTransit(0, lw, n)
+	m := solarMeanAnomaly(ds)
+	l := eclipticLongitude(m)
+	dec := declination(l, 0)
+	jNoon := solarTransitJ(ds, m, l)
+
+	// riseSet returns the rise & set times for the Sun reaching altitude h in degrees
+	riseSet := func(h float64) (time.Time, time.Time) {
+		w := hourAngle(h*rad, phi, dec)
+		if math.IsNaN(w) {
+			// The Sun never reaches this altitude today
+			return time.Time{}, time.Time{}
+		}
+		jSet := solarTransitJ(approxTransit(w, lw, n), m, l)
+		jRise := jNoon - (jSet - jNoon)
+		return fromJulian(jRise, loc), fromJulian(jSet, loc)
+	}
+
+	st := SunTimes{
+		Transit: fromJulian(jNoon, loc),
+		Nadir:   fromJulian(jNoon-0.5, loc),
+	}
+	st.Sunrise, st.Sunset = riseSet(SunriseAltitude)
+	st.SunriseEnd, st.SunsetStart = riseSet(SunriseEndAltitude)
+	st.CivilDawn, st.CivilDusk = riseSet(CivilAltitude)
+	st.NauticalDawn, st.NauticalDusk = riseSet(NauticalAltitude)
+	st.AstronomicalDawn, st.AstronomicalDusk = riseSet(AstronomicalAltitude)
+	st.GoldenHourEnd, st.GoldenHour = riseSet(GoldenHourAltitude)
+	return st
+}
+
+// DayLength returns the duration between sunrise and sunset.
+// This will be 0 if the Sun does not rise or set on that day.
+func (st SunTimes) DayLength() time.Duration {
+	if st.Sunrise.IsZero() || st.Sunset.IsZero() {
+		return 0
+	}
+	return st.Sunset.Sub(st.Sunrise)
+}
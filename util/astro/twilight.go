@@ -0,0 +1,55 @@
+package astro
+
+import "time"
+
+// Twilight is the phase of the day based on the altitude of the Sun
+type Twilight uint8
+
+const (
+	Night                Twilight = iota // Sun below -18°
+	AstronomicalTwilight                 // Sun between -18° and -12°
+	NauticalTwilight                     // Sun between -12° and -6°
+	CivilTwilight                        // Sun between -6° and sunrise/sunset
+	Day                                  // Sun above the horizon
+)
+
+var twilightNames = [...]string{
+	Night:                "Night",
+	AstronomicalTwilight: "Astronomical Twilight",
+	NauticalTwilight:     "Nautical Twilight",
+	CivilTwilight:        "Civil Twilight",
+	Day:                  "Day",
+}
+
+func (t Twilight) String() string {
+	if int(t) < len(twilightNames) {
+		return twilightNames[t]
+	}
+	return "Unknown"
+}
+
+// TwilightForAltitude returns the Twilight phase for a Sun altitude in degrees
+func TwilightForAltitude(alt float64) Twilight {
+	switch {
+	case alt >= SunriseAltitude:
+		return Day
+	case alt >= CivilAltitude:
+		return CivilTwilight
+	case alt >= NauticalAltitude:
+		return NauticalTwilight
+	case alt >= AstronomicalAltitude:
+		return AstronomicalTwilight
+	default:
+		return Night
+	}
+}
+
+// GetTwilight returns the Twilight phase at a specific time and location
+func GetTwilight(t time.Time, lat, lon float64) Twilight {
+	return TwilightForAltitude(SunPosition(t, lat, lon).Altitude)
+}
+
+// IsDaylight returns true if the Sun has risen at a specific time and location
+func IsDaylight(t time.Time, lat, lon float64) bool {
+	return GetTwilight(t, lat, lon) == Day
+}
@@ -1,12 +1,11 @@
 package util
 
 import (
-	"github.com/llgcode/draw2d/draw2dimg"
 	"github.com/peter-mount/go-anim/util/draw2d"
 	"image/color"
 )
 
-func DrawColourBars(gc *draw2dimg.GraphicContext, bounds Rectangle, cols ...color.Color) (float64, float64) {
+func DrawColourBars(gc draw2d.GraphicContext, bounds Rectangle, cols ...color.Color) (float64, float64) {
 	x, y, w, h := bounds.X1, bounds.Y1, bounds.Width(), bounds.Height()
 	l := float64(len(cols))
 	dw := w / l
@@ -16,7 +15,7 @@ func DrawColourBars(gc *draw2dimg.GraphicContext, bounds Rectangle, cols ...colo
 	return dw, l
 }
 
-func DrawColourBarsVertical(gc *draw2dimg.GraphicContext, bounds Rectangle, cols ...color.Color) (float64, float64) {
+func DrawColourBarsVertical(gc draw2d.GraphicContext, bounds Rectangle, cols ...color.Color) (float64, float64) {
 	x, y, w, h := bounds.X1, bounds.Y1, bounds.Width(), bounds.Height()
 	l := float64(len(cols))
 	dh := h / l
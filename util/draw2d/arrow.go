@@ -0,0 +1,120 @@
+package draw2d
+
+import (
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dkit"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// ArrowStyle is the shape of the head at the end of an arrow
+type ArrowStyle int
+
+const (
+	ArrowNone     ArrowStyle = iota // No head
+	ArrowTriangle                   // Filled triangle
+	ArrowOpen                       // Open V
+	ArrowCircle                     // Filled circle centered on the end
+	ArrowBar                        // Line across the end
+)
+
+// ParseArrowStyle returns the ArrowStyle for "none", "triangle", "open", "circle" or "bar", defaulting to ArrowNone
+func ParseArrowStyle(s string) ArrowStyle {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "triangle":
+		return ArrowTriangle
+	case "open":
+		return ArrowOpen
+	case "circle":
+		return ArrowCircle
+	case "bar":
+		return ArrowBar
+	default:
+		return ArrowNone
+	}
+}
+
+// ArrowHead describes the head at one end of an arrow
+type ArrowHead struct {
+	Style  ArrowStyle
+	Length float64 // Length along the arrow, unused by ArrowCircle and ArrowBar
+	Width  float64 // Width across the arrow, the diameter of an ArrowCircle
+}
+
+// NewArrowHead returns an ArrowHead of the named style, see ParseArrowStyle
+func NewArrowHead(style string, length, width float64) ArrowHead {
+	return ArrowHead{Style: ParseArrowStyle(style), Length: length, Width: width}
+}
+
+// inset returns how far the shaft is shortened so it does not show through the head
+func (h ArrowHead) inset() float64 {
+	switch h.Style {
+	case ArrowTriangle:
+		return h.Length
+	case ArrowCircle:
+		return h.Width / 2
+	default:
+		return 0
+	}
+}
+
+// draw the head with its tip at x,y pointing in direction dx,dy which is of unit length
+func (h ArrowHead) draw(gc draw2d.GraphicContext, x, y, dx, dy float64) {
+	// Base of the head and half its width across the arrow
+	bx, by := x-dx*h.Length, y-dy*h.Length
+	nx, ny := -dy*h.Width/2, dx*h.Width/2
+
+	gc.BeginPath()
+	switch h.Style {
+	case ArrowTriangle:
+		gc.MoveTo(x, y)
+		gc.LineTo(bx+nx, by+ny)
+		gc.LineTo(bx-nx, by-ny)
+		gc.Close()
+		gc.Fill()
+
+	case ArrowOpen:
+		gc.MoveTo(bx+nx, by+ny)
+		gc.LineTo(x, y)
+		gc.LineTo(bx-nx, by-ny)
+		gc.Stroke()
+
+	case ArrowCircle:
+		draw2dkit.Circle(gc, x, y, h.Width/2)
+		gc.Fill()
+
+	case ArrowBar:
+		gc.MoveTo(x+nx, y+ny)
+		gc.LineTo(x-nx, y-ny)
+		gc.Stroke()
+	}
+}
+
+// Arrow draws a line from x1,y1 to x2,y2 in colour c using the current line width, with heads at either end.
+// The line uses the current dash pattern but the heads are always solid.
+func Arrow(gc draw2d.GraphicContext, c color.Color, x1, y1, x2, y2 float64, start, end ArrowHead) {
+	dx, dy := x2-x1, y2-y1
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return
+	}
+	dx, dy = dx/l, dy/l
+
+	gc.Save()
+	defer gc.Restore()
+	gc.SetStrokeColor(c)
+	gc.SetFillColor(c)
+
+	// Shorten the shaft so it does not show past filled heads
+	if si, ei := start.inset(), end.inset(); si+ei < l {
+		gc.BeginPath()
+		gc.MoveTo(x1+dx*si, y1+dy*si)
+		gc.LineTo(x2-dx*ei, y2-dy*ei)
+		gc.Stroke()
+	}
+
+	Solid(gc)
+	start.draw(gc, x1, y1, -dx, -dy)
+	end.draw(gc, x2, y2, dx, dy)
+}
@@ -0,0 +1,137 @@
+package draw2d
+
+import (
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dimg"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
+	"image"
+	"image/color"
+)
+
+// GraphicContext is what everything draws with. It is a draw2d.GraphicContext which also exposes its state,
+// so any rasteriser implementing it can be used in place of draw2d's own.
+type GraphicContext interface {
+	draw2d.GraphicContext
+	// GetFillColor returns the current fill colour
+	GetFillColor() color.Color
+	// GetStrokeColor returns the current stroke colour
+	GetStrokeColor() color.Color
+	// GetFillRule returns the current fill rule
+	GetFillRule() draw2d.FillRule
+	// GetLineWidth returns the current line width
+	GetLineWidth() float64
+	// GetLineCap returns the current line cap
+	GetLineCap() draw2d.LineCap
+	// GetLineJoin returns the current line join
+	GetLineJoin() draw2d.LineJoin
+	// GetLineDash returns the current dash and its offset
+	GetLineDash() ([]float64, float64)
+	// NewGraphicContext returns a GraphicContext of the same kind drawing onto img, used for off-screen drawing
+	NewGraphicContext(img draw.Image) GraphicContext
+	// NewImage returns a transparent image covering r for off-screen drawing, with the precision of the image drawn onto
+	NewImage(r image.Rectangle) draw.Image
+}
+
+// ImageGraphicContext is the default GraphicContext, draw2d's own rasteriser
+type ImageGraphicContext struct {
+	*draw2dimg.GraphicContext
+	img     draw.Image
+	painter *MaskPainter
+	bounds  image.Rectangle // Area drawing can change, within img and the mask
+}
+
+// NewImageGraphicContext returns an ImageGraphicContext drawing onto img at its own precision
+func NewImageGraphicContext(img draw.Image) GraphicContext {
+	p := NewMaskPainter(NewPainter(img))
+	return &ImageGraphicContext{
+		GraphicContext: draw2dimg.NewGraphicContextWithPainter(img, p),
+		img:            img,
+		painter:        p,
+		bounds:         img.Bounds(),
+	}
+}
+
+func (gc *ImageGraphicContext) GetFillColor() color.Color {
+	return gc.Current.FillColor
+}
+
+func (gc *ImageGraphicContext) GetStrokeColor() color.Color {
+	return gc.Current.StrokeColor
+}
+
+func (gc *ImageGraphicContext) GetFillRule() draw2d.FillRule {
+	return gc.Current.FillRule
+}
+
+func (gc *ImageGraphicContext) GetLineWidth() float64 {
+	return gc.Current.LineWidth
+}
+
+func (gc *ImageGraphicContext) GetLineCap() draw2d.LineCap {
+	return gc.Current.Cap
+}
+
+func (gc *ImageGraphicContext) GetLineJoin() draw2d.LineJoin {
+	return gc.Current.Join
+}
+
+func (gc *ImageGraphicContext) GetLineDash() ([]float64, float64) {
+	return gc.Current.Dash, gc.Current.DashOffset
+}
+
+func (gc *ImageGraphicContext) NewGraphicContext(img draw.Image) GraphicContext {
+	return NewImageGraphicContext(img)
+}
+
+func (gc *ImageGraphicContext) NewImage(r image.Rectangle) draw.Image {
+	return NewImageOf(gc.img, r)
+}
+
+func (gc *ImageGraphicContext) SetMask(mask *image.Alpha) {
+	gc.painter.Mask = mask
+	gc.bounds = gc.img.Bounds()
+	if mask != nil {
+		gc.bounds = gc.bounds.Intersect(coverage(mask))
+	}
+}
+
+func (gc *ImageGraphicContext) DeviceBounds() image.Rectangle {
+	return gc.bounds
+}
+
+func (gc *ImageGraphicContext) Clear() {
+	b := gc.img.Bounds()
+	gc.ClearRect(0, 0, b.Dx(), b.Dy())
+}
+
+func (gc *ImageGraphicContext) ClearRect(x1, y1, x2, y2 int) {
+	mask := gc.painter.Mask
+	if mask == nil {
+		gc.GraphicContext.ClearRect(x1, y1, x2, y2)
+		return
+	}
+	r := image.Rect(x1, y1, x2, y2)
+	draw.DrawMask(gc.img, r, image.NewUniform(gc.Current.FillColor), image.Point{}, mask, r.Min, draw.Src)
+}
+
+func (gc *ImageGraphicContext) DrawImage(img image.Image) {
+	mask := gc.painter.Mask
+	if mask == nil {
+		gc.GraphicContext.DrawImage(img)
+		return
+	}
+
+	// As draw2dimg.DrawImage but only where the mask has coverage
+	var t draw.Transformer
+	switch gc.Filter {
+	case draw2dimg.LinearFilter:
+		t = draw.NearestNeighbor
+	case draw2dimg.BilinearFilter:
+		t = draw.BiLinear
+	case draw2dimg.BicubicFilter:
+		t = draw.CatmullRom
+	}
+	tr := gc.Current.Tr
+	t.Transform(gc.img, f64.Aff3{tr[0], tr[1], tr[4], tr[2], tr[3], tr[5]}, img, img.Bounds(), draw.Over, &draw.Options{DstMask: mask})
+}
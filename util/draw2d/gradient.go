@@ -0,0 +1,227 @@
+package draw2d
+
+import (
+	"image/color"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Spread defines how a gradient continues beyond its first and last stops
+type Spread int
+
+const (
+	SpreadPad     Spread = iota // Continue with the colour of the nearest stop
+	SpreadRepeat                // Repeat the gradient
+	SpreadReflect               // Repeat the gradient, reversing alternate repeats
+)
+
+// ParseSpread returns the Spread for "pad", "repeat" or "reflect", defaulting to SpreadPad
+func ParseSpread(s string) Spread {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "repeat":
+		return SpreadRepeat
+	case "reflect":
+		return SpreadReflect
+	default:
+		return SpreadPad
+	}
+}
+
+// apply maps t onto 0...1
+func (s Spread) apply(t float64) float64 {
+	switch s {
+	case SpreadRepeat:
+		return t - math.Floor(t)
+	case SpreadReflect:
+		t = math.Mod(math.Abs(t), 2)
+		if t > 1 {
+			t = 2 - t
+		}
+		return t
+	default:
+		return math.Max(0, math.Min(1, t))
+	}
+}
+
+// gradientSteps is the number of colours precomputed for each gradient
+const gradientSteps = 1024
+
+// Gradient holds the colour stops of a gradient, from which linear, radial or conic paints are created.
+// Colours are interpolated in linear light so the midpoint between two colours has the expected brightness.
+type Gradient struct {
+	stops  []gradientStop
+	spread Spread
+}
+
+type gradientStop struct {
+	offset float64
+	colour color.Color
+}
+
+// NewGradient returns a Gradient with no stops
+func NewGradient() *Gradient {
+	return &Gradient{}
+}
+
+// Stop adds a colour at offset, from 0 at the start of the gradient to 1 at the end
+func (g *Gradient) Stop(offset float64, c color.Color) *Gradient {
+	g.stops = append(g.stops, gradientStop{offset: offset, colour: c})
+	return g
+}
+
+// Spread sets how the gradient continues beyond its first and last stops
+func (g *Gradient) Spread(s Spread) *Gradient {
+	g.spread = s
+	return g
+}
+
+// Linear returns a Paint changing along the line from x1,y1 to x2,y2
+func (g *Gradient) Linear(x1, y1, x2, y2 float64) Paint {
+	dx, dy := x2-x1, y2-y1
+	return &linearGradient{lut: g.lut(), x: x1, y: y1, dx: dx, dy: dy, l2: dx*dx + dy*dy}
+}
+
+// Radial returns a Paint changing from the center cx,cy out to radius r
+func (g *Gradient) Radial(cx, cy, r float64) Paint {
+	return &radialGradient{lut: g.lut(), cx: cx, cy: cy, r: r}
+}
+
+// Conic returns a Paint changing clockwise around cx,cy, starting at angle degrees from the positive x axis
+func (g *Gradient) Conic(cx, cy, angle float64) Paint {
+	return &conicGradient{lut: g.lut(), cx: cx, cy: cy, angle: angle * toRad}
+}
+
+// gradientLUT holds the precomputed colours of a gradient
+type gradientLUT struct {
+	colours [gradientSteps]color.RGBA64
+	spread  Spread
+}
+
+func (l *gradientLUT) at(t float64) color.RGBA64 {
+	t = l.spread.apply(t)
+	if math.IsNaN(t) {
+		t = 0
+	}
+	return l.colours[int(math.Round(t*(gradientSteps-1)))]
+}
+
+// lut computes the colours of the gradient
+func (g *Gradient) lut() *gradientLUT {
+	l := &gradientLUT{spread: g.spread}
+
+	stops := append([]gradientStop{}, g.stops...)
+	sort.SliceStable(stops, func(i, j int) bool { return stops[i].offset < stops[j].offset })
+	if len(stops) == 0 {
+		return l
+	}
+
+	lin := make([][4]float64, len(stops))
+	for i, s := range stops {
+		lin[i] = toLinear(s.colour)
+	}
+
+	j := 0
+	for i := range l.colours {
+		t := float64(i) / (gradientSteps - 1)
+		for j < len(stops) && stops[j].offset <= t {
+			j++
+		}
+
+		var c [4]float64
+		switch {
+		case j == 0:
+			c = lin[0]
+		case j == len(stops):
+			c = lin[j-1]
+		default:
+			a, b := stops[j-1], stops[j]
+			f := (t - a.offset) / (b.offset - a.offset)
+			for k := range c {
+				c[k] = lin[j-1][k] + (lin[j][k]-lin[j-1][k])*f
+			}
+		}
+		l.colours[i] = fromLinear(c)
+	}
+	return l
+}
+
+// toLinear returns the premultiplied linear light components of c, from 0 to 1
+func toLinear(c color.Color) [4]float64 {
+	if c == nil {
+		return [4]float64{}
+	}
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return [4]float64{}
+	}
+	fa := float64(a) / 0xffff
+	conv := func(v uint32) float64 {
+		return srgbToLinear(float64(v)/float64(a)) * fa
+	}
+	return [4]float64{conv(r), conv(g), conv(b), fa}
+}
+
+// fromLinear returns the premultiplied sRGB colour of premultiplied linear light components
+func fromLinear(c [4]float64) color.RGBA64 {
+	a := math.Max(0, math.Min(1, c[3]))
+	if a == 0 {
+		return color.RGBA64{}
+	}
+	conv := func(v float64) uint16 {
+		return uint16(math.Round(linearToSrgb(v/a) * a * 0xffff))
+	}
+	return color.RGBA64{R: conv(c[0]), G: conv(c[1]), B: conv(c[2]), A: uint16(math.Round(a * 0xffff))}
+}
+
+func srgbToLinear(v float64) float64 {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSrgb(v float64) float64 {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+type linearGradient struct {
+	lut          *gradientLUT
+	x, y, dx, dy float64
+	l2           float64 // Square of the length of the gradient
+}
+
+func (g *linearGradient) At(x, y float64) color.RGBA64 {
+	if g.l2 == 0 {
+		return g.lut.at(0)
+	}
+	return g.lut.at(((x-g.x)*g.dx + (y-g.y)*g.dy) / g.l2)
+}
+
+type radialGradient struct {
+	lut       *gradientLUT
+	cx, cy, r float64
+}
+
+func (g *radialGradient) At(x, y float64) color.RGBA64 {
+	if g.r <= 0 {
+		return g.lut.at(1)
+	}
+	return g.lut.at(math.Hypot(x-g.cx, y-g.cy) / g.r)
+}
+
+type conicGradient struct {
+	lut           *gradientLUT
+	cx, cy, angle float64
+}
+
+func (g *conicGradient) At(x, y float64) color.RGBA64 {
+	a := math.Atan2(y-g.cy, x-g.cx) - g.angle
+	a /= 2 * math.Pi
+	return g.lut.at(a - math.Floor(a))
+}
@@ -0,0 +1,72 @@
+package draw2d
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestGradient_Linear(t *testing.T) {
+	p := NewGradient().
+		Stop(1, color.White).
+		Stop(0, color.Black).
+		Linear(0, 0, 100, 0)
+
+	tests := []struct {
+		x    float64
+		want uint8
+	}{
+		{-10, 0},
+		{0, 0},
+		// Half way in linear light is brighter than half way in sRGB
+		{50, 188},
+		{100, 255},
+		{110, 255},
+	}
+	for _, test := range tests {
+		if got := uint8(p.At(test.x, 50).R >> 8); got != test.want {
+			t.Errorf("at %v got %d want %d", test.x, got, test.want)
+		}
+	}
+}
+
+func TestSpread(t *testing.T) {
+	tests := []struct {
+		spread Spread
+		t      float64
+		want   float64
+	}{
+		{SpreadPad, 1.25, 1},
+		{SpreadPad, -0.5, 0},
+		{SpreadRepeat, 1.25, 0.25},
+		{SpreadRepeat, -0.25, 0.75},
+		{SpreadReflect, 1.25, 0.75},
+		{SpreadReflect, -0.25, 0.25},
+	}
+	for _, test := range tests {
+		if got := test.spread.apply(test.t); got != test.want {
+			t.Errorf("spread %d at %v got %v want %v", test.spread, test.t, got, test.want)
+		}
+	}
+}
+
+func TestFillPaint(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	gc := NewImageGraphicContext(img)
+
+	// The paint moves with the transform
+	gc.Translate(5, 5)
+	gc.BeginPath()
+	Rectangle(gc, 0, 0, 10, 10)
+	FillPaint(gc, NewGradient().Stop(0, color.Black).Stop(1, color.White).Linear(0, 0, 10, 0))
+
+	if c := img.RGBAAt(2, 10); c.A != 0 {
+		t.Errorf("outside got %v", c)
+	}
+	if c := img.RGBAAt(5, 10); c.A != 255 || c.R > 80 {
+		t.Errorf("left got %v", c)
+	}
+	if c := img.RGBAAt(14, 10); c.A != 255 || c.R < 240 {
+		t.Errorf("right got %v", c)
+	}
+}
@@ -1,11 +1,11 @@
 package draw2d
 
 import (
-	"github.com/llgcode/draw2d/draw2dimg"
+	"github.com/llgcode/draw2d"
 	"image/color"
 )
 
-func FillPoly(gc *draw2dimg.GraphicContext, c color.Color, v ...float64) {
+func FillPoly(gc GraphicContext, c color.Color, v ...float64) {
 	gc.SetFillColor(c)
 	gc.BeginPath()
 	for i := 0; i < len(v); i += 2 {
@@ -19,7 +19,7 @@ func FillPoly(gc *draw2dimg.GraphicContext, c color.Color, v ...float64) {
 	gc.Fill()
 }
 
-func FillPolyRel(gc *draw2dimg.GraphicContext, c color.Color, v ...float64) {
+func FillPolyRel(gc GraphicContext, c color.Color, v ...float64) {
 	gc.SetFillColor(c)
 	gc.BeginPath()
 	var x, y float64
@@ -35,7 +35,7 @@ func FillPolyRel(gc *draw2dimg.GraphicContext, c color.Color, v ...float64) {
 	gc.Fill()
 }
 
-func FillRectangle(gc *draw2dimg.GraphicContext, x, y, w, h float64, c color.Color) (float64, float64) {
+func FillRectangle(gc GraphicContext, x, y, w, h float64, c color.Color) (float64, float64) {
 	gc.SetFillColor(c)
 	gc.BeginPath()
 	Rectangle(gc, x, y, w, h)
@@ -43,7 +43,7 @@ func FillRectangle(gc *draw2dimg.GraphicContext, x, y, w, h float64, c color.Col
 	return x + w, y + h
 }
 
-func Rectangle(gc *draw2dimg.GraphicContext, x, y, w, h float64) {
+func Rectangle(gc draw2d.PathBuilder, x, y, w, h float64) {
 	gc.MoveTo(x, y)
 	gc.LineTo(x+w, y)
 	gc.LineTo(x+w, y+h)
@@ -51,7 +51,7 @@ func Rectangle(gc *draw2dimg.GraphicContext, x, y, w, h float64) {
 	gc.Close()
 }
 
-func RelLine(gc *draw2dimg.GraphicContext, x, y float64, v ...float64) {
+func RelLine(gc draw2d.PathBuilder, x, y float64, v ...float64) {
 	gc.MoveTo(x, y)
 	for i := 0; i < len(v); i += 2 {
 		x += v[i]
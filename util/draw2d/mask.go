@@ -0,0 +1,90 @@
+package draw2d
+
+import (
+	"github.com/golang/freetype/raster"
+	"github.com/llgcode/draw2d/draw2dimg"
+	"image"
+)
+
+// Masker is implemented by a GraphicContext which can limit what it draws to a mask, used for clipping
+type Masker interface {
+	// SetMask limits drawing to where mask has coverage, in device space. A nil mask removes the limit.
+	SetMask(mask *image.Alpha)
+}
+
+// Bounded is implemented by a GraphicContext drawing onto an image, so off-screen drawing
+// can be limited to the area it could change
+type Bounded interface {
+	// DeviceBounds returns the area, in device space, drawing can change
+	DeviceBounds() image.Rectangle
+}
+
+// visible limits r, in device space, to the area gc can change, if it is Bounded
+func visible(gc GraphicContext, r image.Rectangle) image.Rectangle {
+	if b, ok := gc.(Bounded); ok {
+		return r.Intersect(b.DeviceBounds())
+	}
+	return r
+}
+
+// coverage returns the smallest rectangle containing all of mask with coverage
+func coverage(mask *image.Alpha) image.Rectangle {
+	var r image.Rectangle
+	for y := mask.Rect.Min.Y; y < mask.Rect.Max.Y; y++ {
+		row := mask.Pix[mask.PixOffset(mask.Rect.Min.X, y):][:mask.Rect.Dx()]
+		x0, x1 := -1, -1
+		for i, a := range row {
+			if a != 0 {
+				if x0 < 0 {
+					x0 = i
+				}
+				x1 = i
+			}
+		}
+		if x0 >= 0 {
+			r = r.Union(image.Rect(mask.Rect.Min.X+x0, y, mask.Rect.Min.X+x1+1, y+1))
+		}
+	}
+	return r
+}
+
+// MaskPainter is a draw2dimg.Painter which only paints where Mask has coverage, scaling the coverage
+// of each span by it. With no Mask it paints everything.
+type MaskPainter struct {
+	draw2dimg.Painter
+	Mask  *image.Alpha
+	spans []raster.Span // Reused between calls to Paint
+}
+
+func NewMaskPainter(p draw2dimg.Painter) *MaskPainter {
+	return &MaskPainter{Painter: p}
+}
+
+func (p *MaskPainter) Paint(ss []raster.Span, done bool) {
+	if p.Mask == nil {
+		p.Painter.Paint(ss, done)
+		return
+	}
+
+	mask := p.Mask
+	p.spans = p.spans[:0]
+	for _, s := range ss {
+		if !clipSpan(&s, mask.Rect) {
+			continue
+		}
+
+		// Split the span into runs with the same coverage in the mask
+		i := mask.PixOffset(s.X0, s.Y)
+		for x := s.X0; x < s.X1; {
+			x0, a := x, mask.Pix[i]
+			for x < s.X1 && mask.Pix[i] == a {
+				x++
+				i++
+			}
+			if a != 0 {
+				p.spans = append(p.spans, raster.Span{Y: s.Y, X0: x0, X1: x, Alpha: s.Alpha * uint32(a) / 0xff})
+			}
+		}
+	}
+	p.Painter.Paint(p.spans, done)
+}
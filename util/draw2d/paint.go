@@ -0,0 +1,264 @@
+package draw2d
+
+import (
+	"github.com/golang/freetype/raster"
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dbase"
+	"github.com/llgcode/draw2d/draw2dimg"
+	"github.com/peter-mount/go-anim/util/goexr/exr"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// Paint provides the colour of each point of a fill, for gradients and patterns.
+// Points are in the coordinates current when drawing, so a Paint moves with any transform.
+type Paint interface {
+	// At returns the premultiplied colour at x,y
+	At(x, y float64) color.RGBA64
+}
+
+// FillPaint fills the current path with p instead of the fill colour
+func FillPaint(gc GraphicContext, p Paint) {
+	paintPath(gc, p, false)
+}
+
+// StrokePaint strokes the current path with p instead of the stroke colour
+func StrokePaint(gc GraphicContext, p Paint) {
+	paintPath(gc, p, true)
+}
+
+// DrawPaint calls draw then replaces the colour of everything it drew with p, keeping its coverage.
+// This paints text or anything else drawn with solid colours. bounds limits the area drawn,
+// in the current coordinates.
+func DrawPaint(gc GraphicContext, bounds image.Rectangle, p Paint, draw func(GraphicContext)) {
+	ogc, img, origin := Offscreen(gc, bounds, 2)
+	if img == nil {
+		return
+	}
+	draw(ogc)
+
+	drawLayer(gc, colourise(gc.GetMatrixTransform(), alphaOf(img), origin, p), origin)
+}
+
+// Offscreen returns a GraphicContext drawing to a new image covering bounds, in the current coordinates of gc,
+// extended by overflow pixels on each side. The GraphicContext has the same transform, font and colours as gc,
+// with origin being the position of the image within gc's image. The image is from gc.NewImage so has the precision
+// of gc's image. If gc is Bounded the image only covers the part of bounds it can change, and is nil if that is empty.
+func Offscreen(gc GraphicContext, bounds image.Rectangle, overflow int) (GraphicContext, draw.Image, image.Point) {
+	tr := gc.GetMatrixTransform()
+	x0, y0, x1, y1 := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range []image.Point{bounds.Min, bounds.Max, {X: bounds.Min.X, Y: bounds.Max.Y}, {X: bounds.Max.X, Y: bounds.Min.Y}} {
+		x, y := tr.TransformPoint(float64(p.X), float64(p.Y))
+		x0, y0, x1, y1 = min(x0, x), min(y0, y), max(x1, x), max(y1, y)
+	}
+	r := image.Rect(int(math.Floor(x0))-overflow, int(math.Floor(y0))-overflow, int(math.Ceil(x1))+overflow, int(math.Ceil(y1))+overflow)
+	r = visible(gc, r)
+	if bounds.Empty() || r.Empty() {
+		return nil, nil, image.Point{}
+	}
+
+	img := gc.NewImage(image.Rect(0, 0, r.Dx(), r.Dy()))
+	ogc := gc.NewGraphicContext(img)
+	ogc.SetDPI(gc.GetDPI())
+	ogc.SetFontData(gc.GetFontData())
+	ogc.SetFontSize(gc.GetFontSize())
+	ogc.SetFillColor(gc.GetFillColor())
+	ogc.SetStrokeColor(gc.GetStrokeColor())
+	ogc.SetLineWidth(gc.GetLineWidth())
+	ogc.SetMatrixTransform(draw2d.NewTranslationMatrix(float64(-r.Min.X), float64(-r.Min.Y)))
+	ogc.ComposeMatrixTransform(tr)
+	return ogc, img, r.Min
+}
+
+// alphaOf returns the alpha channel of img
+func alphaOf(img image.Image) *image.Alpha {
+	mask := image.NewAlpha(img.Bounds())
+	draw.Draw(mask, mask.Rect, img, mask.Rect.Min, draw.Src)
+	return mask
+}
+
+// ScaleAlpha multiplies every pixel of a premultiplied image by opacity, keeping its precision
+func ScaleAlpha(img draw.Image, opacity float64) {
+	opacity = max(0, min(1, opacity))
+	switch i := img.(type) {
+	case *image.RGBA:
+		a := uint32(math.Round(opacity * 256))
+		for j, v := range i.Pix {
+			i.Pix[j] = uint8(uint32(v) * a >> 8)
+		}
+		return
+	case *exr.RGBAImage:
+		o := float32(opacity)
+		b := i.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				c := i.At(x, y).(exr.RGBAColor)
+				i.Set(x, y, exr.RGBAColor{R: c.R * o, G: c.G * o, B: c.B * o, A: c.A * o})
+			}
+		}
+		return
+	}
+
+	scale := func(v uint32) uint16 {
+		return uint16(math.Round(float64(v) * opacity))
+	}
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, a := img.At(x, y).RGBA()
+			img.Set(x, y, color.RGBA64{R: scale(r), G: scale(g), B: scale(bl), A: scale(a)})
+		}
+	}
+}
+
+// paintPath fills or strokes the current path with p, clearing the path as draw2d does
+func paintPath(gc GraphicContext, p Paint, stroke bool) {
+	defer gc.BeginPath()
+
+	// Find the area covered in device space, within what gc can change, so only it is rasterized
+	b := &extent{x0: math.Inf(1), y0: math.Inf(1), x1: math.Inf(-1), y1: math.Inf(-1)}
+	outline(gc, stroke, b)
+	if b.x0 > b.x1 {
+		return
+	}
+	r := visible(gc, image.Rect(int(math.Floor(b.x0)), int(math.Floor(b.y0)), int(math.Ceil(b.x1))+1, int(math.Ceil(b.y1))+1))
+	if r.Empty() {
+		return
+	}
+
+	ras := raster.NewRasterizer(r.Dx(), r.Dy())
+	ras.UseNonZeroWinding = stroke || gc.GetFillRule() == draw2d.FillRuleWinding
+	outline(gc, stroke, &translate{dx: float64(-r.Min.X), dy: float64(-r.Min.Y), next: draw2dimg.FtLineBuilder{Adder: ras}})
+
+	mask := image.NewAlpha(image.Rect(0, 0, r.Dx(), r.Dy()))
+	ras.Rasterize(raster.NewAlphaOverPainter(mask))
+
+	drawLayer(gc, colourise(gc.GetMatrixTransform(), mask, r.Min, p), r.Min)
+}
+
+// FillMask returns the coverage of filling path, transformed by tr, as an anti-aliased mask covering r in device space
+func FillMask(path *draw2d.Path, tr draw2d.Matrix, rule draw2d.FillRule, r image.Rectangle) *image.Alpha {
+	mask := image.NewAlpha(image.Rect(0, 0, r.Dx(), r.Dy()))
+	if r.Empty() {
+		return mask
+	}
+
+	ras := raster.NewRasterizer(r.Dx(), r.Dy())
+	ras.UseNonZeroWinding = rule == draw2d.FillRuleWinding
+	liner := draw2dbase.Transformer{Tr: tr, Flattener: &translate{dx: float64(-r.Min.X), dy: float64(-r.Min.Y), next: draw2dimg.FtLineBuilder{Adder: ras}}}
+	draw2dbase.Flatten(path, liner, tr.GetScale())
+	ras.Rasterize(raster.NewAlphaOverPainter(mask))
+
+	// The rasterizer works from 0,0 so move the mask into place
+	mask.Rect = r
+	return mask
+}
+
+// outline passes the current path to f in device space, converting it to the outline of its stroke if required
+func outline(gc GraphicContext, stroke bool, f draw2dbase.Flattener) {
+	tr := gc.GetMatrixTransform()
+	var liner draw2dbase.Flattener = draw2dbase.Transformer{Tr: tr, Flattener: f}
+	if stroke {
+		stroker := draw2dbase.NewLineStroker(gc.GetLineCap(), gc.GetLineJoin(), liner)
+		stroker.HalfLineWidth = gc.GetLineWidth() / 2
+		liner = stroker
+		if dash, offset := gc.GetLineDash(); len(dash) > 0 {
+			liner = draw2dbase.NewDashConverter(dash, offset, stroker)
+		}
+	}
+	path := gc.GetPath()
+	draw2dbase.Flatten(&path, liner, tr.GetScale())
+}
+
+// colourise returns an image of p where mask, positioned at origin in device space, has coverage
+// The image is 16-bit so paints keep their precision when drawn onto deeper images.
+func colourise(tr draw2d.Matrix, mask *image.Alpha, origin image.Point, p Paint) *image.RGBA64 {
+	inv := tr.Copy()
+	inv.Inverse()
+
+	b := mask.Bounds()
+	img := image.NewRGBA64(b)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			a := uint32(mask.AlphaAt(x, y).A)
+			if a == 0 {
+				continue
+			}
+			// Sample the paint at the center of the pixel
+			px, py := inv.TransformPoint(float64(origin.X+x)+0.5, float64(origin.Y+y)+0.5)
+			c := p.At(px, py)
+			img.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(uint32(c.R) * a / 0xff),
+				G: uint16(uint32(c.G) * a / 0xff),
+				B: uint16(uint32(c.B) * a / 0xff),
+				A: uint16(uint32(c.A) * a / 0xff),
+			})
+		}
+	}
+	return img
+}
+
+// drawLayer draws img over gc with its top left corner at origin in device space
+func drawLayer(gc GraphicContext, img image.Image, origin image.Point) {
+	gc.Save()
+	defer gc.Restore()
+	gc.SetMatrixTransform(draw2d.NewTranslationMatrix(float64(origin.X), float64(origin.Y)))
+	gc.DrawImage(img)
+}
+
+// extent is a draw2dbase.Flattener recording the extent of the points passed to it
+type extent struct {
+	x0, y0, x1, y1 float64
+}
+
+func (e *extent) MoveTo(x, y float64) {
+	e.LineTo(x, y)
+}
+
+func (e *extent) LineTo(x, y float64) {
+	e.x0, e.y0, e.x1, e.y1 = min(e.x0, x), min(e.y0, y), max(e.x1, x), max(e.y1, y)
+}
+
+func (e *extent) LineJoin() {}
+
+func (e *extent) Close() {}
+
+func (e *extent) End() {}
+
+// translate is a draw2dbase.Flattener moving each point by dx,dy before passing it on
+type translate struct {
+	dx, dy float64
+	next   draw2dbase.Flattener
+}
+
+func (t *translate) MoveTo(x, y float64) {
+	t.next.MoveTo(x+t.dx, y+t.dy)
+}
+
+func (t *translate) LineTo(x, y float64) {
+	t.next.LineTo(x+t.dx, y+t.dy)
+}
+
+func (t *translate) LineJoin() {
+	t.next.LineJoin()
+}
+
+func (t *translate) Close() {
+	t.next.Close()
+}
+
+func (t *translate) End() {
+	t.next.End()
+}
+
+// Bounds returns the extent of a path, in its own coordinates
+func Bounds(path *draw2d.Path) (x0, y0, x1, y1 float64) {
+	e := &extent{x0: math.Inf(1), y0: math.Inf(1), x1: math.Inf(-1), y1: math.Inf(-1)}
+	draw2dbase.Flatten(path, e, 1)
+	if e.x0 > e.x1 {
+		return 0, 0, 0, 0
+	}
+	return e.x0, e.y0, e.x1, e.y1
+}
@@ -0,0 +1,154 @@
+package draw2d
+
+import (
+	"github.com/golang/freetype/raster"
+	"github.com/llgcode/draw2d/draw2dimg"
+	"github.com/peter-mount/go-anim/util/goexr/exr"
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// NewPainter returns the draw2dimg.Painter which draws onto img at its own precision,
+// so 16-bit and floating point images are not reduced to 8 bits
+func NewPainter(img draw.Image) draw2dimg.Painter {
+	switch i := img.(type) {
+	case *image.RGBA:
+		return raster.NewRGBAPainter(i)
+	case *image.RGBA64:
+		return NewRGBA64Painter(i)
+	case *exr.RGBAImage:
+		return NewFloatPainter(i)
+	default:
+		return NewImagePainter(img)
+	}
+}
+
+// NewImageOf returns a new transparent image covering bounds with the same precision as img,
+// so 16-bit and floating point images are not reduced to 8 bits
+func NewImageOf(img image.Image, bounds image.Rectangle) draw.Image {
+	switch img.(type) {
+	case *image.RGBA64, *image.NRGBA64, *image.Gray16:
+		return image.NewRGBA64(bounds)
+	case *exr.RGBAImage:
+		return exr.NewFloat32(bounds)
+	default:
+		return image.NewRGBA(bounds)
+	}
+}
+
+// clipSpan limits a span to the bounds of an image, returning false if the span is outside it
+func clipSpan(s *raster.Span, b image.Rectangle) bool {
+	if s.Y < b.Min.Y || s.Y >= b.Max.Y {
+		return false
+	}
+	s.X0, s.X1 = max(s.X0, b.Min.X), min(s.X1, b.Max.X)
+	return s.X0 < s.X1
+}
+
+// RGBA64Painter paints spans over an *image.RGBA64 at 16 bits per channel
+type RGBA64Painter struct {
+	Image          *image.RGBA64
+	cr, cg, cb, ca uint32 // Premultiplied colour to paint the spans
+}
+
+func NewRGBA64Painter(img *image.RGBA64) *RGBA64Painter {
+	return &RGBA64Painter{Image: img}
+}
+
+func (p *RGBA64Painter) SetColor(c color.Color) {
+	p.cr, p.cg, p.cb, p.ca = c.RGBA()
+}
+
+func (p *RGBA64Painter) Paint(ss []raster.Span, _ bool) {
+	const m = 0xffff
+	img := p.Image
+	for _, s := range ss {
+		if !clipSpan(&s, img.Rect) {
+			continue
+		}
+		ma := s.Alpha
+		a := m - p.ca*ma/m
+		i0 := img.PixOffset(s.X0, s.Y)
+		i1 := i0 + (s.X1-s.X0)*8
+		for i := i0; i < i1; i += 8 {
+			for k, c := range [4]uint32{p.cr, p.cg, p.cb, p.ca} {
+				j := i + k*2
+				d := uint32(img.Pix[j])<<8 | uint32(img.Pix[j+1])
+				v := (d*a + c*ma) / m
+				img.Pix[j], img.Pix[j+1] = uint8(v>>8), uint8(v)
+			}
+		}
+	}
+}
+
+// FloatPainter paints spans over an exr.RGBAImage keeping floating point precision.
+// The image is in linear space. Colours which are exr.RGBAColor are used as-is so can be brighter than white,
+// others are sRGB so are linearised by exr.RGBAModel.
+type FloatPainter struct {
+	Image *exr.RGBAImage
+	c     exr.RGBAColor // Colour to paint the spans
+}
+
+func NewFloatPainter(img *exr.RGBAImage) *FloatPainter {
+	return &FloatPainter{Image: img}
+}
+
+func (p *FloatPainter) SetColor(c color.Color) {
+	p.c = exr.RGBAModel.Convert(c).(exr.RGBAColor)
+}
+
+func (p *FloatPainter) Paint(ss []raster.Span, _ bool) {
+	img := p.Image
+	for _, s := range ss {
+		if !clipSpan(&s, img.Bounds()) {
+			continue
+		}
+		ma := float32(s.Alpha) / 0xffff
+		a := 1 - p.c.A*ma
+		for x := s.X0; x < s.X1; x++ {
+			d := img.At(x, s.Y).(exr.RGBAColor)
+			img.Set(x, s.Y, exr.RGBAColor{
+				R: d.R*a + p.c.R*ma,
+				G: d.G*a + p.c.G*ma,
+				B: d.B*a + p.c.B*ma,
+				A: d.A*a + p.c.A*ma,
+			})
+		}
+	}
+}
+
+// ImagePainter paints spans over any draw.Image, at 16 bits per channel
+type ImagePainter struct {
+	Image          draw.Image
+	cr, cg, cb, ca uint32 // Premultiplied colour to paint the spans
+}
+
+func NewImagePainter(img draw.Image) *ImagePainter {
+	return &ImagePainter{Image: img}
+}
+
+func (p *ImagePainter) SetColor(c color.Color) {
+	p.cr, p.cg, p.cb, p.ca = c.RGBA()
+}
+
+func (p *ImagePainter) Paint(ss []raster.Span, _ bool) {
+	const m = 0xffff
+	img := p.Image
+	for _, s := range ss {
+		if !clipSpan(&s, img.Bounds()) {
+			continue
+		}
+		ma := s.Alpha
+		a := m - p.ca*ma/m
+		for x := s.X0; x < s.X1; x++ {
+			dr, dg, db, da := img.At(x, s.Y).RGBA()
+			img.Set(x, s.Y, color.RGBA64{
+				R: uint16((dr*a + p.cr*ma) / m),
+				G: uint16((dg*a + p.cg*ma) / m),
+				B: uint16((db*a + p.cb*ma) / m),
+				A: uint16((da*a + p.ca*ma) / m),
+			})
+		}
+	}
+}
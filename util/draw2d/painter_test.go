@@ -0,0 +1,119 @@
+package draw2d
+
+import (
+	"github.com/llgcode/draw2d/draw2dkit"
+	"github.com/peter-mount/go-anim/util/goexr/exr"
+	"image"
+	"image/color"
+	"image/draw"
+	"reflect"
+	"testing"
+)
+
+// fillRect fills a rectangle in the middle of img with c, drawn by NewImageGraphicContext
+func fillRect(img draw.Image, c color.Color) {
+	gc := NewImageGraphicContext(img)
+	gc.SetFillColor(c)
+	draw2dkit.Rectangle(gc, 2, 2, 8, 8)
+	gc.Fill()
+}
+
+func TestNewPainter_RGBA64(t *testing.T) {
+	img := image.NewRGBA64(image.Rect(0, 0, 10, 10))
+	// Not representable in 8 bits
+	want := color.RGBA64{R: 0x1234, G: 0x5678, B: 0x9abc, A: 0xffff}
+	fillRect(img, want)
+
+	if got := img.RGBA64At(5, 5); got != want {
+		t.Errorf("inside got %v want %v", got, want)
+	}
+	if got := img.RGBA64At(0, 0); got != (color.RGBA64{}) {
+		t.Errorf("outside got %v", got)
+	}
+}
+
+func TestNewPainter_Float(t *testing.T) {
+	img := exr.NewFloat32(image.Rect(0, 0, 10, 10))
+	// Brighter than white
+	want := exr.RGBAColor{R: 2, G: 0.25, B: 0.001, A: 1}
+	fillRect(img, want)
+
+	if got := img.At(5, 5).(exr.RGBAColor); got != want {
+		t.Errorf("inside got %v want %v", got, want)
+	}
+	if got := img.At(0, 0).(exr.RGBAColor); got != (exr.RGBAColor{}) {
+		t.Errorf("outside got %v", got)
+	}
+}
+
+func TestNewPainter_Image(t *testing.T) {
+	// Other images are drawn rather than panicking
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	fillRect(img, color.White)
+
+	if got := img.NRGBAAt(5, 5); got != (color.NRGBA{R: 255, G: 255, B: 255, A: 255}) {
+		t.Errorf("inside got %v", got)
+	}
+}
+
+func TestMaskPainter(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	gc := NewImageGraphicContext(img)
+
+	// Left half opaque, right half at half coverage
+	mask := image.NewAlpha(img.Rect)
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			mask.SetAlpha(x, y, color.Alpha{A: 0xff - uint8(x/5)*0x80})
+		}
+	}
+	mask.SetAlpha(5, 5, color.Alpha{})
+	gc.(Masker).SetMask(mask)
+
+	gc.SetFillColor(color.White)
+	draw2dkit.Rectangle(gc, 2, 2, 8, 8)
+	gc.Fill()
+
+	for _, test := range []struct {
+		x, y int
+		want uint8
+	}{{3, 3, 0xff}, {6, 3, 0x7f}, {5, 5, 0}, {0, 0, 0}} {
+		if got := img.RGBAAt(test.x, test.y).A; got != test.want {
+			t.Errorf("at %d,%d got alpha %d want %d", test.x, test.y, got, test.want)
+		}
+	}
+}
+
+func TestOffscreen(t *testing.T) {
+	// Off-screen images keep the precision of the image drawn onto
+	for _, img := range []draw.Image{image.NewRGBA(image.Rect(0, 0, 10, 10)), image.NewRGBA64(image.Rect(0, 0, 10, 10)), exr.NewFloat32(image.Rect(0, 0, 10, 10))} {
+		_, off, _ := Offscreen(NewImageGraphicContext(img), image.Rect(2, 2, 8, 8), 1)
+		if got, want := reflect.TypeOf(off), reflect.TypeOf(img); got != want {
+			t.Errorf("got %v want %v", got, want)
+		}
+	}
+}
+
+func TestOffscreen_Bounded(t *testing.T) {
+	gc := NewImageGraphicContext(image.NewRGBA(image.Rect(0, 0, 10, 10)))
+
+	// Off-screen images only cover the part of the destination which can change
+	if _, off, origin := Offscreen(gc, image.Rect(-100, 5, 100, 200), 1); off == nil || off.Bounds().Size() != image.Pt(10, 6) || origin != image.Pt(0, 4) {
+		t.Errorf("got %v at %v", off, origin)
+	}
+	if _, off, _ := Offscreen(gc, image.Rect(20, 20, 30, 30), 1); off != nil {
+		t.Errorf("got %v outside the image", off.Bounds())
+	}
+
+	// including any mask
+	mask := image.NewAlpha(image.Rect(0, 0, 10, 10))
+	mask.SetAlpha(3, 2, color.Alpha{A: 0x80})
+	mask.SetAlpha(5, 6, color.Alpha{A: 0xff})
+	gc.(Masker).SetMask(mask)
+	if got, want := gc.(Bounded).DeviceBounds(), image.Rect(3, 2, 6, 7); got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+	if _, off, origin := Offscreen(gc, image.Rect(0, 0, 10, 10), 0); off == nil || off.Bounds().Size() != image.Pt(3, 5) || origin != image.Pt(3, 2) {
+		t.Errorf("got %v at %v", off, origin)
+	}
+}
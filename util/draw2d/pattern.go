@@ -0,0 +1,52 @@
+package draw2d
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Pattern is a Paint taken from an image, optionally tiled to cover any area
+type Pattern struct {
+	img    image.Image
+	repeat bool
+	x, y   float64 // Position of the image's top left corner
+	scale  float64 // Size of each image pixel
+}
+
+// NewPattern returns a Pattern of img placed at 0,0, tiled if repeat is true
+func NewPattern(img image.Image, repeat bool) *Pattern {
+	return &Pattern{img: img, repeat: repeat, scale: 1}
+}
+
+// Offset moves the image so its top left corner is at x,y
+func (p *Pattern) Offset(x, y float64) *Pattern {
+	p.x, p.y = x, y
+	return p
+}
+
+// Scale sets the size of each image pixel, e.g. 2 to draw the image at twice its size
+func (p *Pattern) Scale(s float64) *Pattern {
+	if s > 0 {
+		p.scale = s
+	}
+	return p
+}
+
+func (p *Pattern) At(x, y float64) color.RGBA64 {
+	b := p.img.Bounds()
+	if b.Empty() {
+		return color.RGBA64{}
+	}
+
+	ix := int(math.Floor((x - p.x) / p.scale))
+	iy := int(math.Floor((y - p.y) / p.scale))
+	if p.repeat {
+		ix = ((ix % b.Dx()) + b.Dx()) % b.Dx()
+		iy = ((iy % b.Dy()) + b.Dy()) % b.Dy()
+	} else if ix < 0 || iy < 0 || ix >= b.Dx() || iy >= b.Dy() {
+		return color.RGBA64{}
+	}
+
+	return color.RGBA64Model.Convert(p.img.At(b.Min.X+ix, b.Min.Y+iy)).(color.RGBA64)
+}
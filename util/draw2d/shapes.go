@@ -0,0 +1,143 @@
+package draw2d
+
+import (
+	"github.com/llgcode/draw2d"
+	"math"
+)
+
+// toRad converts degrees to radians.
+// Angles in this package are in degrees, clockwise from the positive x axis as the y axis points down.
+const toRad = math.Pi / 180
+
+// RoundedRectangle adds a rectangle with corners of radius r to the path.
+// r is limited to half the width or height.
+func RoundedRectangle(path draw2d.PathBuilder, x, y, w, h, r float64) {
+	r = math.Max(0, math.Min(r, math.Min(math.Abs(w), math.Abs(h))/2))
+	if r == 0 {
+		Rectangle(path, x, y, w, h)
+		return
+	}
+
+	x2, y2 := x+w, y+h
+	path.MoveTo(x+r, y)
+	path.LineTo(x2-r, y)
+	path.ArcTo(x2-r, y+r, r, r, -math.Pi/2, math.Pi/2)
+	path.LineTo(x2, y2-r)
+	path.ArcTo(x2-r, y2-r, r, r, 0, math.Pi/2)
+	path.LineTo(x+r, y2)
+	path.ArcTo(x+r, y2-r, r, r, math.Pi/2, math.Pi/2)
+	path.LineTo(x, y+r)
+	path.ArcTo(x+r, y+r, r, r, math.Pi, math.Pi/2)
+	path.Close()
+}
+
+// Arc adds an elliptical arc centered on cx,cy starting at start degrees and sweeping through angle degrees.
+// If the path has a current point then a line is added from it to the start of the arc.
+func Arc(path draw2d.PathBuilder, cx, cy, rx, ry, start, angle float64) {
+	path.ArcTo(cx, cy, rx, ry, start*toRad, angle*toRad)
+}
+
+// Pie adds a closed segment of an ellipse, from its center around the arc and back
+func Pie(path draw2d.PathBuilder, cx, cy, rx, ry, start, angle float64) {
+	path.MoveTo(cx, cy)
+	Arc(path, cx, cy, rx, ry, start, angle)
+	path.Close()
+}
+
+// Ellipse adds an ellipse centered on cx,cy to the path
+func Ellipse(path draw2d.PathBuilder, cx, cy, rx, ry float64) {
+	path.MoveTo(cx+rx, cy)
+	path.ArcTo(cx, cy, rx, ry, 0, 2*math.Pi)
+	path.Close()
+}
+
+// Polygon adds a regular polygon with the given number of sides and radius centered on cx,cy.
+// The first vertex is at rotation degrees, so 0 has a vertex to the right and -90 one at the top.
+func Polygon(path draw2d.PathBuilder, cx, cy, r float64, sides int, rotation float64) {
+	if sides < 3 {
+		return
+	}
+	step := 2 * math.Pi / float64(sides)
+	for i := 0; i < sides; i++ {
+		x, y := polar(cx, cy, r, rotation*toRad+float64(i)*step)
+		if i == 0 {
+			path.MoveTo(x, y)
+		} else {
+			path.LineTo(x, y)
+		}
+	}
+	path.Close()
+}
+
+// Star adds a star with the given number of points centered on cx,cy.
+// The points are at radius outer and the vertices between them at radius inner,
+// the first point being at rotation degrees.
+func Star(path draw2d.PathBuilder, cx, cy, outer, inner float64, points int, rotation float64) {
+	if points < 2 {
+		return
+	}
+	step := math.Pi / float64(points)
+	for i := 0; i < points*2; i++ {
+		r := outer
+		if i%2 == 1 {
+			r = inner
+		}
+		x, y := polar(cx, cy, r, rotation*toRad+float64(i)*step)
+		if i == 0 {
+			path.MoveTo(x, y)
+		} else {
+			path.LineTo(x, y)
+		}
+	}
+	path.Close()
+}
+
+// Spline adds a smooth curve passing through each point, given as x,y pairs.
+// It uses a Catmull-Rom spline with the given tension, 0 being the default curve and 1 straight lines.
+// If closed is true then the curve returns smoothly to the first point.
+func Spline(path draw2d.PathBuilder, tension float64, closed bool, v ...float64) {
+	n := len(v) / 2
+	if n < 2 {
+		return
+	}
+
+	pt := func(i int) (float64, float64) {
+		switch {
+		case closed:
+			i = (i + n) % n
+		case i < 0:
+			i = 0
+		case i >= n:
+			i = n - 1
+		}
+		return v[i*2], v[i*2+1]
+	}
+
+	// Scale of the tangent at each point, 1/6 is the uniform Catmull-Rom spline
+	s := (1 - tension) / 6
+
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+
+	path.MoveTo(v[0], v[1])
+	for i := 0; i < segments; i++ {
+		x0, y0 := pt(i - 1)
+		x1, y1 := pt(i)
+		x2, y2 := pt(i + 1)
+		x3, y3 := pt(i + 2)
+		path.CubicCurveTo(
+			x1+(x2-x0)*s, y1+(y2-y0)*s,
+			x2-(x3-x1)*s, y2-(y3-y1)*s,
+			x2, y2,
+		)
+	}
+	if closed {
+		path.Close()
+	}
+}
+
+func polar(cx, cy, r, a float64) (float64, float64) {
+	return cx + r*math.Cos(a), cy + r*math.Sin(a)
+}
@@ -0,0 +1,44 @@
+package draw2d
+
+import (
+	"github.com/llgcode/draw2d"
+	"math"
+	"testing"
+)
+
+func TestPolygon(t *testing.T) {
+	p := &draw2d.Path{}
+	Polygon(p, 10, 10, 5, 4, -90)
+
+	want := []float64{10, 5, 15, 10, 10, 15, 5, 10}
+	if len(p.Points) != len(want) {
+		t.Fatalf("got %v", p.Points)
+	}
+	for i, v := range want {
+		if math.Abs(p.Points[i]-v) > 1e-9 {
+			t.Errorf("point %d got %v want %v", i/2, p.Points[i], v)
+		}
+	}
+}
+
+func TestSpline(t *testing.T) {
+	p := &draw2d.Path{}
+	Spline(p, 0, false, 0, 0, 10, 10, 20, 0)
+
+	// MoveTo then a cubic curve ending on each following point
+	if len(p.Components) != 3 || p.Components[1] != draw2d.CubicCurveToCmp {
+		t.Fatalf("got %v", p.Components)
+	}
+	if x, y := p.LastPoint(); x != 20 || y != 0 {
+		t.Errorf("got %v,%v", x, y)
+	}
+	if p.Points[6] != 10 || p.Points[7] != 10 {
+		t.Errorf("curve does not pass through 10,10: %v", p.Points)
+	}
+
+	p = &draw2d.Path{}
+	Spline(p, 0, true, 0, 0, 10, 10, 20, 0)
+	if len(p.Components) != 5 || p.Components[4] != draw2d.CloseCmp {
+		t.Errorf("closed got %v", p.Components)
+	}
+}
@@ -0,0 +1,51 @@
+package draw2d
+
+import (
+	"github.com/llgcode/draw2d"
+	"strings"
+)
+
+// ParseLineCap returns the draw2d.LineCap for "round", "butt" or "square", defaulting to draw2d.RoundCap
+func ParseLineCap(s string) draw2d.LineCap {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "butt":
+		return draw2d.ButtCap
+	case "square":
+		return draw2d.SquareCap
+	default:
+		return draw2d.RoundCap
+	}
+}
+
+// ParseLineJoin returns the draw2d.LineJoin for "round", "bevel" or "miter", defaulting to draw2d.RoundJoin
+func ParseLineJoin(s string) draw2d.LineJoin {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "bevel":
+		return draw2d.BevelJoin
+	case "miter", "mitre":
+		return draw2d.MiterJoin
+	default:
+		return draw2d.RoundJoin
+	}
+}
+
+// Dashed sets strokes to be dashed, dash being the lengths of alternate dashes and gaps.
+// No dash lengths restores solid strokes.
+func Dashed(gc draw2d.GraphicContext, dash ...float64) {
+	if len(dash) == 0 {
+		Solid(gc)
+		return
+	}
+	gc.SetLineDash(dash, 0)
+}
+
+// Dotted sets the line width and strokes to be dots the size of the line width, spaced gap apart
+func Dotted(gc draw2d.GraphicContext, lineWidth, gap float64) {
+	gc.SetLineWidth(lineWidth)
+	gc.SetLineDash([]float64{lineWidth, gap}, 0)
+}
+
+// Solid restores solid strokes
+func Solid(gc draw2d.GraphicContext) {
+	gc.SetLineDash(nil, 0)
+}
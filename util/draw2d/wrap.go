@@ -0,0 +1,132 @@
+package draw2d
+
+import (
+	"github.com/llgcode/draw2d"
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Wrap returns gc as a GraphicContext. If gc is not already one, such as a draw2d SVG or PDF context,
+// it is wrapped so its state is recorded as it is set. State set before it was wrapped is not known
+// so starts as draw2d's defaults. Off-screen drawing uses NewImageGraphicContext onto an *image.RGBA. A nil gc returns nil.
+func Wrap(gc draw2d.GraphicContext) GraphicContext {
+	if gc == nil {
+		return nil
+	}
+	if g, ok := gc.(GraphicContext); ok {
+		return g
+	}
+	return &wrapped{GraphicContext: gc, state: &wrappedState{
+		fillColor:   color.White,
+		strokeColor: color.Black,
+		fillRule:    draw2d.FillRuleEvenOdd,
+		lineWidth:   1,
+		cap:         draw2d.RoundCap,
+		join:        draw2d.RoundJoin,
+	}}
+}
+
+// wrapped is a draw2d.GraphicContext recording the state it does not expose
+type wrapped struct {
+	draw2d.GraphicContext
+	state *wrappedState
+}
+
+// wrappedState is the state of a wrapped GraphicContext, with that of each Save before it
+type wrappedState struct {
+	fillColor   color.Color
+	strokeColor color.Color
+	fillRule    draw2d.FillRule
+	lineWidth   float64
+	cap         draw2d.LineCap
+	join        draw2d.LineJoin
+	dash        []float64
+	dashOffset  float64
+	previous    *wrappedState
+}
+
+func (gc *wrapped) Save() {
+	s := *gc.state
+	s.previous = gc.state
+	gc.state = &s
+	gc.GraphicContext.Save()
+}
+
+func (gc *wrapped) Restore() {
+	if gc.state.previous != nil {
+		gc.state = gc.state.previous
+	}
+	gc.GraphicContext.Restore()
+}
+
+func (gc *wrapped) SetFillColor(c color.Color) {
+	gc.state.fillColor = c
+	gc.GraphicContext.SetFillColor(c)
+}
+
+func (gc *wrapped) SetStrokeColor(c color.Color) {
+	gc.state.strokeColor = c
+	gc.GraphicContext.SetStrokeColor(c)
+}
+
+func (gc *wrapped) SetFillRule(f draw2d.FillRule) {
+	gc.state.fillRule = f
+	gc.GraphicContext.SetFillRule(f)
+}
+
+func (gc *wrapped) SetLineWidth(lineWidth float64) {
+	gc.state.lineWidth = lineWidth
+	gc.GraphicContext.SetLineWidth(lineWidth)
+}
+
+func (gc *wrapped) SetLineCap(cap draw2d.LineCap) {
+	gc.state.cap = cap
+	gc.GraphicContext.SetLineCap(cap)
+}
+
+func (gc *wrapped) SetLineJoin(join draw2d.LineJoin) {
+	gc.state.join = join
+	gc.GraphicContext.SetLineJoin(join)
+}
+
+func (gc *wrapped) SetLineDash(dash []float64, dashOffset float64) {
+	gc.state.dash, gc.state.dashOffset = dash, dashOffset
+	gc.GraphicContext.SetLineDash(dash, dashOffset)
+}
+
+func (gc *wrapped) GetFillColor() color.Color {
+	return gc.state.fillColor
+}
+
+func (gc *wrapped) GetStrokeColor() color.Color {
+	return gc.state.strokeColor
+}
+
+func (gc *wrapped) GetFillRule() draw2d.FillRule {
+	return gc.state.fillRule
+}
+
+func (gc *wrapped) GetLineWidth() float64 {
+	return gc.state.lineWidth
+}
+
+func (gc *wrapped) GetLineCap() draw2d.LineCap {
+	return gc.state.cap
+}
+
+func (gc *wrapped) GetLineJoin() draw2d.LineJoin {
+	return gc.state.join
+}
+
+func (gc *wrapped) GetLineDash() ([]float64, float64) {
+	return gc.state.dash, gc.state.dashOffset
+}
+
+func (gc *wrapped) NewGraphicContext(img draw.Image) GraphicContext {
+	return NewImageGraphicContext(img)
+}
+
+func (gc *wrapped) NewImage(r image.Rectangle) draw.Image {
+	return image.NewRGBA(r)
+}
@@ -0,0 +1,31 @@
+package draw2d
+
+import (
+	"github.com/llgcode/draw2d/draw2dsvg"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	// A GraphicContext is returned as-is
+	gc := NewImageGraphicContext(image.NewRGBA(image.Rect(0, 0, 1, 1)))
+	if Wrap(gc) != gc {
+		t.Error("GraphicContext was wrapped")
+	}
+
+	// Others record their state, following Save and Restore
+	red := color.RGBA{R: 255, A: 255}
+	w := Wrap(draw2dsvg.NewGraphicContext(draw2dsvg.NewSvg()))
+	w.SetFillColor(red)
+	w.Save()
+	w.SetFillColor(color.Black)
+	w.SetLineWidth(3)
+	if w.GetFillColor() != color.Black || w.GetLineWidth() != 3 {
+		t.Errorf("got fill %v width %f", w.GetFillColor(), w.GetLineWidth())
+	}
+	w.Restore()
+	if w.GetFillColor() != red || w.GetLineWidth() != 1 {
+		t.Errorf("after Restore got fill %v width %f", w.GetFillColor(), w.GetLineWidth())
+	}
+}
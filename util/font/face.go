@@ -0,0 +1,205 @@
+package font
+
+import (
+	"github.com/golang/freetype/truetype"
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	xfont "golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"sync"
+)
+
+// face is a font the Shaper takes glyphs from.
+// Glyph indices are 0 if the font does not have a glyph.
+type face interface {
+	index(r rune) truetype.Index
+	advance(scale fixed.Int26_6, i truetype.Index) float64
+	kern(scale fixed.Int26_6, a, b truetype.Index) float64
+	// bounds returns the ink bounds of a glyph in pixels with y increasing down, ok is false if it has no ink
+	bounds(scale fixed.Int26_6, i truetype.Index) (left, top, right, bottom float64, ok bool)
+	// draw fills, or strokes, a glyph with its origin at x, y
+	draw(gc draw2d2.GraphicContext, g Glyph, x, y float64, stroke bool)
+	// extents returns the extents of the font at size
+	extents(size float64) Extents
+}
+
+// loadFace returns the face for fd, nil if it does not exist
+func loadFace(fd draw2d.FontData) face {
+	if f := DefaultRegistry.loadSfnt(fd); f != nil {
+		return f
+	}
+	if f := loadFont(fd); f != nil {
+		return ttFace{f}
+	}
+	return nil
+}
+
+// ttFace is a TrueType font drawn by draw2d, so glyphs use its glyph cache
+type ttFace struct {
+	font *truetype.Font
+}
+
+func (f ttFace) index(r rune) truetype.Index {
+	return f.font.Index(r)
+}
+
+func (f ttFace) advance(scale fixed.Int26_6, i truetype.Index) float64 {
+	return FUnitsToFloat64(f.font.HMetric(scale, i).AdvanceWidth)
+}
+
+func (f ttFace) kern(scale fixed.Int26_6, a, b truetype.Index) float64 {
+	return FUnitsToFloat64(f.font.Kern(scale, a, b))
+}
+
+func (f ttFace) bounds(scale fixed.Int26_6, i truetype.Index) (float64, float64, float64, float64, bool) {
+	var buf truetype.GlyphBuf
+	if err := buf.Load(f.font, scale, i, xfont.HintingNone); err != nil {
+		return 0, 0, 0, 0, false
+	}
+	b := buf.Bounds
+	if b.Min == b.Max {
+		return 0, 0, 0, 0, false
+	}
+	return FUnitsToFloat64(b.Min.X), -FUnitsToFloat64(b.Max.Y), FUnitsToFloat64(b.Max.X), -FUnitsToFloat64(b.Min.Y), true
+}
+
+func (f ttFace) draw(gc draw2d2.GraphicContext, g Glyph, x, y float64, stroke bool) {
+	if gc.GetFontData() != g.FontData {
+		gc.SetFontData(g.FontData)
+	}
+	if stroke {
+		gc.StrokeStringAt(string(g.Rune), x, y)
+	} else {
+		gc.FillStringAt(string(g.Rune), x, y)
+	}
+}
+
+func (f ttFace) extents(size float64) Extents {
+	upem := f.font.FUnitsPerEm()
+	b := f.font.Bounds(fixed.Int26_6(upem))
+	scale := size / float64(upem)
+	return Extents{
+		Ascent:  float64(b.Max.Y) * scale,
+		Descent: float64(b.Min.Y) * scale,
+		Height:  float64(b.Max.Y-b.Min.Y) * scale,
+	}
+}
+
+// sfntFace is a font drawn from its outlines, used for fonts draw2d cannot load like OpenType CFF fonts
+type sfntFace struct {
+	mutex sync.Mutex
+	font  *sfnt.Font
+	buf   sfnt.Buffer
+}
+
+func (f *sfntFace) index(r rune) truetype.Index {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	i, err := f.font.GlyphIndex(&f.buf, r)
+	if err != nil {
+		return 0
+	}
+	return truetype.Index(i)
+}
+
+func (f *sfntFace) advance(scale fixed.Int26_6, i truetype.Index) float64 {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	a, err := f.font.GlyphAdvance(&f.buf, sfnt.GlyphIndex(i), scale, xfont.HintingNone)
+	if err != nil {
+		return 0
+	}
+	return FUnitsToFloat64(a)
+}
+
+func (f *sfntFace) kern(scale fixed.Int26_6, a, b truetype.Index) float64 {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	k, err := f.font.Kern(&f.buf, sfnt.GlyphIndex(a), sfnt.GlyphIndex(b), scale, xfont.HintingNone)
+	if err != nil {
+		return 0
+	}
+	return FUnitsToFloat64(k)
+}
+
+func (f *sfntFace) bounds(scale fixed.Int26_6, i truetype.Index) (float64, float64, float64, float64, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	segs, err := f.font.LoadGlyph(&f.buf, sfnt.GlyphIndex(i), scale, nil)
+	if err != nil || len(segs) == 0 {
+		return 0, 0, 0, 0, false
+	}
+	b := segs.Bounds()
+	return FUnitsToFloat64(b.Min.X), FUnitsToFloat64(b.Min.Y), FUnitsToFloat64(b.Max.X), FUnitsToFloat64(b.Max.Y), true
+}
+
+func (f *sfntFace) draw(gc draw2d2.GraphicContext, g Glyph, x, y float64, stroke bool) {
+	path := f.path(g.Index, fixed.Int26_6(gc.GetFontSize()*float64(gc.GetDPI())*(64.0/72.0)))
+	if path == nil {
+		return
+	}
+
+	gc.Save()
+	defer gc.Restore()
+	gc.BeginPath()
+	gc.Translate(x, y)
+	if stroke {
+		gc.Stroke(path)
+	} else {
+		gc.Fill(path)
+	}
+}
+
+// path returns the outline of a glyph as a draw2d Path
+func (f *sfntFace) path(i truetype.Index, scale fixed.Int26_6) *draw2d.Path {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	segs, err := f.font.LoadGlyph(&f.buf, sfnt.GlyphIndex(i), scale, nil)
+	if err != nil || len(segs) == 0 {
+		return nil
+	}
+
+	p := &draw2d.Path{}
+	pt := func(i int, s sfnt.Segment) (float64, float64) {
+		return FUnitsToFloat64(s.Args[i].X), FUnitsToFloat64(s.Args[i].Y)
+	}
+	for _, s := range segs {
+		switch s.Op {
+		case sfnt.SegmentOpMoveTo:
+			if !p.IsEmpty() {
+				p.Close()
+			}
+			p.MoveTo(pt(0, s))
+		case sfnt.SegmentOpLineTo:
+			p.LineTo(pt(0, s))
+		case sfnt.SegmentOpQuadTo:
+			cx, cy := pt(0, s)
+			x, y := pt(1, s)
+			p.QuadCurveTo(cx, cy, x, y)
+		case sfnt.SegmentOpCubeTo:
+			c1x, c1y := pt(0, s)
+			c2x, c2y := pt(1, s)
+			x, y := pt(2, s)
+			p.CubicCurveTo(c1x, c1y, c2x, c2y, x, y)
+		}
+	}
+	p.Close()
+	return p
+}
+
+func (f *sfntFace) extents(size float64) Extents {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	upem := f.font.UnitsPerEm()
+	b, err := f.font.Bounds(&f.buf, fixed.Int26_6(upem), xfont.HintingNone)
+	if err != nil {
+		return Extents{}
+	}
+	scale := size / float64(upem)
+	return Extents{
+		Ascent:  float64(-b.Min.Y) * scale,
+		Descent: float64(-b.Max.Y) * scale,
+		Height:  float64(b.Max.Y-b.Min.Y) * scale,
+	}
+}
@@ -1,9 +1,9 @@
 package font
 
 import (
-	"github.com/golang/freetype/truetype"
+	"fmt"
 	"github.com/llgcode/draw2d"
-	"github.com/llgcode/draw2d/draw2dimg"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
 	"golang.org/x/image/math/fixed"
 	"math"
 	"strconv"
@@ -16,7 +16,7 @@ type Font interface {
 	Family() draw2d.FontFamily
 	Style() draw2d.FontStyle
 	FontData() draw2d.FontData
-	Extents() draw2dimg.FontExtents
+	Extents() Extents
 	// WithSize returns this Font but with the specified size
 	WithSize(float64) Font
 	// WithStyle returns this Font but with the specified style
@@ -24,10 +24,17 @@ type Font interface {
 	// WithFamily returns this F|ont but with the specified family
 	WithFamily(draw2d.FontFamily) Font
 	// Set the font in a GraphicContext
-	Set(*draw2dimg.GraphicContext)
+	Set(draw2d2.GraphicContext)
 	StringLength(s string) float64
 }
 
+// Extents are the vertical metrics of a Font
+type Extents struct {
+	Ascent  float64 // Distance the font extends above the baseline
+	Descent float64 // Distance the font extends below the baseline, as a negative value
+	Height  float64 // Distance from the lowest point of the font to the highest
+}
+
 type font struct {
 	name     string
 	size     float64
@@ -66,8 +73,11 @@ func (f *font) FontData() draw2d.FontData {
 	return f.fontData
 }
 
-func (f *font) Extents() draw2dimg.FontExtents {
-	return draw2dimg.Extents(draw2d.GetFont(f.fontData), f.size)
+func (f *font) Extents() Extents {
+	if face := loadFace(f.fontData); face != nil {
+		return face.extents(f.size)
+	}
+	return Extents{}
 }
 
 func (f *font) WithSize(size float64) Font {
@@ -82,28 +92,14 @@ func (f *font) WithFamily(family draw2d.FontFamily) Font {
 	return New(f.name, f.size, family, f.style)
 }
 
-func (f *font) Set(gc *draw2dimg.GraphicContext) {
+func (f *font) Set(gc draw2d2.GraphicContext) {
 	gc.SetFontData(f.fontData)
 	gc.SetFontSize(f.size)
 }
 
 func (f *font) StringLength(s string) float64 {
-	tf := draw2d.GetFont(f.fontData)
-	x := 0.0
-
-	// Size is font size * 92DPI * (64/72)
-	size := f.size * 92.0 * (64.0 / 72.0)
-
-	prev, hasPrev := truetype.Index(0), false
-	for _, fRune := range s {
-		index := tf.Index(fRune)
-		if hasPrev {
-			x += FUnitsToFloat64(tf.Kern(fixed.Int26_6(size), prev, index))
-		}
-		x += FUnitsToFloat64(tf.HMetric(fixed.Int26_6(size), index).AdvanceWidth)
-		prev, hasPrev = index, true
-	}
-	return x
+	// Size is font size at 92DPI
+	return DefaultShaper.Shape(f.fontData, f.size, 92, s).Width
 }
 
 func FUnitsToFloat64(x fixed.Int26_6) float64 {
@@ -111,36 +107,84 @@ func FUnitsToFloat64(x fixed.Int26_6) float64 {
 	return float64(scaled/256) + float64(scaled%256)/256.0
 }
 
+// ParseFont parses a font description, a space separated list of:
+//
+//	a font name, e.g. "luxi" for the bundled fonts or a family in the DefaultRegistry like "Open Sans"
+//	the size in points
+//	"sans", "serif" or "mono" for the family of the bundled fonts
+//	"bold", "italic" or a weight name like "light" or "semibold"
+//
+// Two numbers are the weight then the size, e.g. "Inter 600 24".
+// Weights apply to fonts in the registry, the bundled fonts use bold for weights of 600 or more.
 func ParseFont(s string) (Font, error) {
 	name := ""
 	size := 10.0
+	weight := 0
 	family := draw2d.FontFamilyMono
 	style := draw2d.FontStyleNormal
 
-	// TODO add some form of error handling here
-	for _, e := range strings.Split(s, " ") {
+	var numbers []float64
+	var words, names []string // words including and excluding family names
+	for _, e := range strings.Fields(s) {
 		if vf, err := strconv.ParseFloat(e, 64); err == nil {
-			// Set the size, enforce a minimum of 1 pixel
-			size = math.Max(1, vf)
-		} else {
-			switch e {
-			case "bold":
-				style = style | draw2d.FontStyleBold
-			case "italic":
-				style = style | draw2d.FontStyleItalic
-			case "sans":
-				family = draw2d.FontFamilySans
-			case "serif":
-				family = draw2d.FontFamilySerif
-			case "mono":
-				family = draw2d.FontFamilyMono
-			default:
+			numbers = append(numbers, vf)
+			continue
+		}
 
+		switch le := strings.ToLower(e); le {
+		case "bold":
+			style = style | draw2d.FontStyleBold
+		case "italic":
+			style = style | draw2d.FontStyleItalic
+		case "sans":
+			family = draw2d.FontFamilySans
+			words = append(words, e)
+		case "serif":
+			family = draw2d.FontFamilySerif
+			words = append(words, e)
+		case "mono":
+			family = draw2d.FontFamilyMono
+			words = append(words, e)
+		default:
+			if w, ok := weightNames[le]; ok {
+				weight = w
+			} else {
+				words = append(words, e)
+				names = append(names, e)
 				name = e
 			}
 		}
 	}
 
+	switch len(numbers) {
+	case 0:
+	case 1:
+		size = numbers[0]
+	default:
+		weight, size = int(numbers[0]), numbers[len(numbers)-1]
+	}
+	// Enforce a minimum of 1 pixel
+	size = math.Max(1, size)
+
+	if weight == 0 && style&draw2d.FontStyleBold != 0 {
+		weight = 700
+	}
+
+	// Families in the registry, which may contain sans etc. like "Open Sans"
+	for _, n := range []string{strings.Join(words, " "), strings.Join(names, " ")} {
+		if n != "" && DefaultRegistry.HasFamily(n) {
+			fd := FontData(n, weight, style&draw2d.FontStyleItalic != 0)
+			if loadFace(fd) == nil {
+				return nil, fmt.Errorf("font %q cannot be loaded", s)
+			}
+			return New(fd.Name, size, fd.Family, fd.Style), nil
+		}
+	}
+
+	if weight >= 600 {
+		style = style | draw2d.FontStyleBold
+	}
+
 	f := New(name, size, family, style)
 
 	// This tests the font exists, returns an error if it doesn't or is corrupted
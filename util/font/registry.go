@@ -0,0 +1,467 @@
+package font
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang/freetype/truetype"
+	"github.com/llgcode/draw2d"
+	"golang.org/x/image/font/sfnt"
+	"io/fs"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Face describes a single font within a Registry
+type Face struct {
+	Family    string     // Family name, the typographic family if the font has one
+	Subfamily string     // Subfamily name, e.g. "SemiBold Italic"
+	Weight    int        // Weight from 100 (thin) to 900 (black), 400 is regular
+	Italic    bool       // true if italic or oblique
+	File      string     // File containing the font
+	Index     int        // Index of the font within a collection, 0 for a single font
+	CFF       bool       // true if the glyphs are CFF outlines rather than TrueType
+	Axes      []Axis     // Variation axes, if this is a variable font
+	Instances []Instance // Named instances, if this is a variable font
+}
+
+// Axis is a variation axis of a variable font
+type Axis struct {
+	Tag     string // Axis tag, e.g. "wght" for weight
+	Min     float64
+	Default float64
+	Max     float64
+}
+
+// Instance is a named instance of a variable font, e.g. "SemiBold"
+type Instance struct {
+	Name   string
+	Coords map[string]float64 // Coordinates keyed by axis tag
+}
+
+// Variable returns true if the face is a variable font
+func (f *Face) Variable() bool {
+	return len(f.Axes) > 0
+}
+
+// Axis returns the variation axis with the given tag
+func (f *Face) Axis(tag string) (Axis, bool) {
+	for _, a := range f.Axes {
+		if a.Tag == tag {
+			return a, true
+		}
+	}
+	return Axis{}, false
+}
+
+// weight returns the weight this face is drawn at, the default of the wght axis for a variable font
+func (f *Face) weight() int {
+	if a, ok := f.Axis("wght"); ok {
+		return int(a.Default)
+	}
+	return f.Weight
+}
+
+// covers returns true if the face is a variable font whose wght axis includes weight
+func (f *Face) covers(weight int) bool {
+	a, ok := f.Axis("wght")
+	return ok && float64(weight) >= a.Min && float64(weight) <= a.Max
+}
+
+// outlines returns true if the face has to be drawn from its outlines rather than by draw2d.
+// draw2d only supports TrueType outlines and the first font in a collection.
+func (f *Face) outlines() bool {
+	return f.CFF || f.Index > 0
+}
+
+func (f *Face) key() string {
+	return f.File + "#" + strconv.Itoa(f.Index)
+}
+
+// Registry indexes fonts by their family, weight and style so they can be referred to by name,
+// e.g. "Inter 600 24" in ParseFont.
+//
+// It implements draw2d.FontCache, so fonts in the registry can be used anywhere draw2d.FontData is accepted.
+// The FontData name is the family name, optionally followed by ":" and the weight, e.g. "Inter:600".
+// Fonts not in the registry, like the bundled Luxi fonts, are passed to the fallback cache.
+//
+// OpenType fonts with CFF outlines are supported by the Shaper, so they can be used by the layout components
+// and the DrawString functions in the util package, but not by draw2d's own FillString functions.
+//
+// Glyphs of variable fonts are drawn using the default instance as the rasterisers do not apply variations,
+// so they are only matched at the default of their weight axis. Requesting another weight within the axis
+// logs that the weight cannot be honoured.
+type Registry struct {
+	mutex    sync.Mutex
+	families map[string][]*Face        // Faces keyed by lower case family name
+	names    map[string]string         // Family names keyed by lower case family name
+	ttFonts  map[string]*truetype.Font // Loaded TrueType fonts keyed by Face.key
+	faces    map[string]*sfntFace      // Loaded outline fonts keyed by Face.key
+	warned   map[string]bool           // Variable font weights already logged as not honoured
+	fallback draw2d.FontCache
+}
+
+// DefaultRegistry is the Registry used by ParseFont and the Shaper.
+// It is installed as draw2d's font cache with draw2d's default cache as the fallback,
+// so draw2d.SetFontFolder still sets the location of the bundled fonts.
+var DefaultRegistry = NewRegistry(draw2d.GetGlobalFontCache())
+
+func init() {
+	draw2d.SetFontCache(DefaultRegistry)
+}
+
+// NewRegistry creates an empty Registry. Fonts not in the registry are loaded from fallback, which may be nil.
+func NewRegistry(fallback draw2d.FontCache) *Registry {
+	return &Registry{
+		families: make(map[string][]*Face),
+		names:    make(map[string]string),
+		ttFonts:  make(map[string]*truetype.Font),
+		faces:    make(map[string]*sfntFace),
+		warned:   make(map[string]bool),
+		fallback: fallback,
+	}
+}
+
+// SystemFontDirs returns the directories fonts are usually installed in on this platform
+func SystemFontDirs() []string {
+	home, _ := os.UserHomeDir()
+	var dirs []string
+	switch runtime.GOOS {
+	case "darwin":
+		dirs = []string{"/System/Library/Fonts", "/Library/Fonts", filepath.Join(home, "Library/Fonts")}
+	case "windows":
+		dirs = []string{
+			filepath.Join(os.Getenv("WINDIR"), "Fonts"),
+			filepath.Join(os.Getenv("LOCALAPPDATA"), "Microsoft/Windows/Fonts"),
+		}
+	default:
+		dirs = []string{"/usr/share/fonts", "/usr/local/share/fonts",
+			filepath.Join(home, ".local/share/fonts"), filepath.Join(home, ".fonts")}
+	}
+	return dirs
+}
+
+// AddSystemFonts adds the fonts in SystemFontDirs, ignoring directories which do not exist
+func (r *Registry) AddSystemFonts() error {
+	for _, dir := range SystemFontDirs() {
+		if err := r.AddDir(dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddDir adds all fonts within a directory and its subdirectories.
+// Files which are not valid fonts are ignored.
+func (r *Registry) AddDir(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".ttf", ".otf", ".ttc", ".otc":
+			_ = r.AddFile(path)
+		}
+		return nil
+	})
+}
+
+// AddFile adds the fonts in a TrueType or OpenType font file or collection
+func (r *Registry) AddFile(fileName string) error {
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	offsets, err := fontOffsets(b)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	var faces []*Face
+	for i, offset := range offsets {
+		f, err := parseFace(b, i, offset)
+		if err != nil {
+			return fmt.Errorf("%s: %w", fileName, err)
+		}
+		f.File = fileName
+		faces = append(faces, f)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, f := range faces {
+		k := strings.ToLower(f.Family)
+		r.families[k] = append(r.families[k], f)
+		r.names[k] = f.Family
+	}
+	return nil
+}
+
+// parseFace reads the description of the index'th font within b
+func parseFace(b []byte, index, offset int) (*Face, error) {
+	c, err := sfnt.ParseCollection(b)
+	if err != nil {
+		return nil, err
+	}
+	font, err := c.Font(index)
+	if err != nil {
+		return nil, err
+	}
+	t, err := tables(b, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	name := func(ids ...sfnt.NameID) string {
+		for _, id := range ids {
+			if s, err := font.Name(nil, id); err == nil && s != "" {
+				return s
+			}
+		}
+		return ""
+	}
+
+	f := &Face{
+		Family:    name(sfnt.NameIDTypographicFamily, sfnt.NameIDFamily),
+		Subfamily: name(sfnt.NameIDTypographicSubfamily, sfnt.NameIDSubfamily),
+		Index:     index,
+		CFF:       t["CFF "] != nil || t["CFF2"] != nil,
+	}
+	if f.Family == "" {
+		return nil, errInvalidFont
+	}
+
+	var ok bool
+	if f.Weight, f.Italic, ok = os2(t["OS/2"]); !ok || f.Weight == 0 {
+		// No OS/2 table so derive from the subfamily name
+		f.Weight = parseWeight(f.Subfamily)
+		sf := strings.ToLower(f.Subfamily)
+		f.Italic = strings.Contains(sf, "italic") || strings.Contains(sf, "oblique")
+	}
+
+	axes, instances := fvar(t["fvar"])
+	f.Axes = axes
+	for _, in := range instances {
+		i := Instance{Name: name(sfnt.NameID(in.nameID)), Coords: make(map[string]float64)}
+		for j, a := range axes {
+			i.Coords[a.Tag] = in.coords[j]
+		}
+		f.Instances = append(f.Instances, i)
+	}
+
+	return f, nil
+}
+
+// weightNames maps weight names to their numeric weight
+var weightNames = map[string]int{
+	"thin":       100,
+	"hairline":   100,
+	"extralight": 200,
+	"ultralight": 200,
+	"light":      300,
+	"regular":    400,
+	"normal":     400,
+	"book":       400,
+	"medium":     500,
+	"semibold":   600,
+	"demibold":   600,
+	"bold":       700,
+	"extrabold":  800,
+	"ultrabold":  800,
+	"black":      900,
+	"heavy":      900,
+}
+
+// weightOrder is the keys of weightNames, longest first so "semibold" is matched before "bold"
+var weightOrder = func() []string {
+	names := make([]string, 0, len(weightNames))
+	for n := range weightNames {
+		names = append(names, n)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j]) || (len(names[i]) == len(names[j]) && names[i] < names[j])
+	})
+	return names
+}()
+
+// parseWeight returns the weight of a subfamily name like "SemiBold Italic", 400 if it has none
+func parseWeight(s string) int {
+	s = strings.NewReplacer(" ", "", "-", "").Replace(strings.ToLower(s))
+	for _, n := range weightOrder {
+		if strings.Contains(s, n) {
+			return weightNames[n]
+		}
+	}
+	return 400
+}
+
+// Families returns the names of the font families in the registry, sorted
+func (r *Registry) Families() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var families []string
+	for _, n := range r.names {
+		families = append(families, n)
+	}
+	sort.Strings(families)
+	return families
+}
+
+// Faces returns the faces within a family, nil if the family is not in the registry
+func (r *Registry) Faces(family string) []*Face {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]*Face(nil), r.families[strings.ToLower(family)]...)
+}
+
+// HasFamily returns true if the family is in the registry
+func (r *Registry) HasFamily(family string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.families[strings.ToLower(family)] != nil
+}
+
+// Match returns the face within a family which best matches a weight and style, nil if the family does not exist.
+//
+// Faces in the requested style are preferred, then the face with the nearest weight. When two weights are
+// equally near the lighter is used for weights below 500, otherwise the heavier, as CSS does.
+// Variable fonts are matched at their default weight.
+func (r *Registry) Match(family string, weight int, italic bool) *Face {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var best *Face
+	bestScore := math.MaxInt
+	for _, f := range r.families[strings.ToLower(family)] {
+		w := f.weight()
+		score := 0
+		switch {
+		case weight < w:
+			score = (w - weight) * 2
+			if weight < 500 {
+				score++
+			}
+		case weight > w:
+			score = (weight - w) * 2
+			if weight >= 500 {
+				score++
+			}
+		}
+		if f.Italic != italic {
+			score += 10000
+		}
+		if score < bestScore {
+			best, bestScore = f, score
+		}
+	}
+
+	if best != nil && best.weight() != weight && best.covers(weight) {
+		key := best.key() + ":" + strconv.Itoa(weight)
+		if !r.warned[key] {
+			r.warned[key] = true
+			log.Printf("%s: variable font cannot be drawn at weight %d, using %d", best.File, weight, best.weight())
+		}
+	}
+	return best
+}
+
+// FontData returns the draw2d FontData for a family in the registry at a weight and style
+func FontData(family string, weight int, italic bool) draw2d.FontData {
+	fd := draw2d.FontData{Name: family, Family: draw2d.FontFamilySans}
+	if weight != 400 && weight != 0 {
+		fd.Name = family + ":" + strconv.Itoa(weight)
+	}
+	if weight >= 600 {
+		fd.Style |= draw2d.FontStyleBold
+	}
+	if italic {
+		fd.Style |= draw2d.FontStyleItalic
+	}
+	return fd
+}
+
+// lookup returns the face for FontData, nil if it does not refer to a font in the registry
+func (r *Registry) lookup(fd draw2d.FontData) *Face {
+	family, weight := fd.Name, 400
+	if fd.Style&draw2d.FontStyleBold != 0 {
+		weight = 700
+	}
+	if i := strings.LastIndexByte(family, ':'); i >= 0 {
+		if w, err := strconv.Atoi(family[i+1:]); err == nil {
+			family, weight = family[:i], w
+		}
+	}
+	return r.Match(family, weight, fd.Style&draw2d.FontStyleItalic != 0)
+}
+
+// Load implements draw2d.FontCache
+func (r *Registry) Load(fd draw2d.FontData) (*truetype.Font, error) {
+	f := r.lookup(fd)
+	if f == nil {
+		if r.fallback == nil {
+			return nil, fmt.Errorf("font %q not found", fd.Name)
+		}
+		return r.fallback.Load(fd)
+	}
+
+	if f.outlines() {
+		return nil, fmt.Errorf("%s: font cannot be drawn by draw2d, use the font package's text functions", f.File)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if font, exists := r.ttFonts[f.key()]; exists {
+		return font, nil
+	}
+
+	b, err := os.ReadFile(f.File)
+	if err != nil {
+		return nil, err
+	}
+	font, err := truetype.Parse(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", f.File, err)
+	}
+	r.ttFonts[f.key()] = font
+	return font, nil
+}
+
+// Store implements draw2d.FontCache, passing the font to the fallback cache
+func (r *Registry) Store(fd draw2d.FontData, font *truetype.Font) {
+	if r.fallback != nil {
+		r.fallback.Store(fd, font)
+	}
+}
+
+// loadSfnt returns the face for FontData if it is a font in the registry which must be drawn from its outlines
+func (r *Registry) loadSfnt(fd draw2d.FontData) *sfntFace {
+	f := r.lookup(fd)
+	if f == nil || !f.outlines() {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if face, exists := r.faces[f.key()]; exists {
+		return face
+	}
+
+	var face *sfntFace
+	if b, err := os.ReadFile(f.File); err == nil {
+		if c, err := sfnt.ParseCollection(b); err == nil {
+			if font, err := c.Font(f.Index); err == nil {
+				face = &sfntFace{font: font}
+			}
+		}
+	}
+	// Cache failures as well so a broken font is not reloaded for every glyph
+	r.faces[f.key()] = face
+	return face
+}
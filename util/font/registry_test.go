@@ -0,0 +1,138 @@
+package font
+
+import (
+	"encoding/binary"
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestRegistry_Match(t *testing.T) {
+	r := NewRegistry(nil)
+	if err := r.AddDir("../../lib/font"); err != nil {
+		t.Fatal(err)
+	}
+	if !r.HasFamily("luxi sans") {
+		t.Fatalf("luxi sans not found in %v", r.Families())
+	}
+
+	tests := []struct {
+		weight     int
+		italic     bool
+		wantWeight int
+		wantItalic bool
+	}{
+		{weight: 400, wantWeight: 400},
+		{weight: 300, wantWeight: 400},
+		{weight: 600, wantWeight: 700},
+		{weight: 900, wantWeight: 700},
+		{weight: 450, wantWeight: 400},
+		{weight: 700, italic: true, wantWeight: 700, wantItalic: true},
+	}
+	for _, tt := range tests {
+		f := r.Match("Luxi Sans", tt.weight, tt.italic)
+		if f == nil {
+			t.Fatalf("%d no match", tt.weight)
+		}
+		if f.Weight != tt.wantWeight || f.Italic != tt.wantItalic {
+			t.Errorf("%d %v got %d %v (%s) want %d %v", tt.weight, tt.italic, f.Weight, f.Italic, f.Subfamily, tt.wantWeight, tt.wantItalic)
+		}
+	}
+
+	if f := r.Match("missing", 400, false); f != nil {
+		t.Errorf("got %v for missing family", f)
+	}
+}
+
+func TestRegistry_CFF(t *testing.T) {
+	draw2d.SetFontFolder("../../lib/font")
+	if err := DefaultRegistry.AddFile("testdata/CFFTest.otf"); err != nil {
+		t.Fatal(err)
+	}
+
+	faces := DefaultRegistry.Faces("cfftest")
+	if len(faces) != 1 || !faces[0].CFF || faces[0].Weight != 400 {
+		t.Fatalf("got %+v", faces)
+	}
+
+	f, err := ParseFont("CFFTest 600 24")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Name() != "CFFTest:600" || f.Size() != 24 {
+		t.Errorf("got %q %f", f.Name(), f.Size())
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 100, 50))
+	gc := draw2d2.NewImageGraphicContext(img)
+	gc.SetFillColor(color.Black)
+	f.Set(gc)
+
+	if w := FillStringAt(gc, "01", 10, 40); w <= 0 {
+		t.Errorf("width %f", w)
+	}
+	inked := 0
+	for i := 3; i < len(img.Pix); i += 4 {
+		if img.Pix[i] != 0 {
+			inked++
+		}
+	}
+	if inked == 0 {
+		t.Error("nothing drawn")
+	}
+
+	// Luxi is not in the registry so is still loaded from the font folder
+	if _, err := ParseFont("luxi 600 12"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFvar(t *testing.T) {
+	// fvar with a single wght axis and one named instance
+	b := make([]byte, 16+20+8)
+	be := binary.BigEndian
+	be.PutUint16(b[0:], 1)
+	be.PutUint16(b[4:], 16)
+	be.PutUint16(b[8:], 1)
+	be.PutUint16(b[10:], 20)
+	be.PutUint16(b[12:], 1)
+	be.PutUint16(b[14:], 8)
+	copy(b[16:], "wght")
+	be.PutUint32(b[20:], 100<<16)
+	be.PutUint32(b[24:], 400<<16)
+	be.PutUint32(b[28:], 900<<16)
+	be.PutUint16(b[36:], 258)
+	be.PutUint32(b[40:], 600<<16)
+
+	axes, instances := fvar(b)
+	if len(axes) != 1 || axes[0] != (Axis{Tag: "wght", Min: 100, Default: 400, Max: 900}) {
+		t.Errorf("got axes %+v", axes)
+	}
+	if len(instances) != 1 || instances[0].nameID != 258 || instances[0].coords[0] != 600 {
+		t.Errorf("got instances %+v", instances)
+	}
+
+	f := &Face{Weight: 400, Axes: axes}
+	if w := f.weight(); w != 400 {
+		t.Errorf("got weight %d", w)
+	}
+	if !f.covers(600) || f.covers(950) {
+		t.Errorf("wrong coverage of %+v", axes)
+	}
+}
+
+func TestRegistry_MatchVariable(t *testing.T) {
+	// A variable font is only matched at its default weight as other weights are drawn at the default
+	variable := &Face{Family: "Var", Weight: 400, Axes: []Axis{{Tag: "wght", Min: 100, Default: 400, Max: 900}}}
+	bold := &Face{Family: "Var", Weight: 700}
+	r := NewRegistry(nil)
+	r.families["var"] = []*Face{variable, bold}
+
+	for weight, want := range map[int]*Face{400: variable, 500: variable, 600: bold, 900: bold} {
+		if f := r.Match("var", weight, false); f != want {
+			t.Errorf("%d got %+v want %+v", weight, f, want)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package font
+
+import (
+	"github.com/golang/freetype/truetype"
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"golang.org/x/image/math/fixed"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Glyph is a single shaped glyph
+type Glyph struct {
+	FontData draw2d.FontData // Font containing the glyph
+	Index    truetype.Index  // Index of the glyph within the font
+	Rune     rune            // Rune the glyph represents, the ligature for ligatures
+	X        float64         // Position of the glyph from the start of the string
+	Advance  float64         // Advance width of the glyph
+	face     face
+}
+
+// Shaped is a string converted into positioned glyphs
+type Shaped struct {
+	Glyphs []Glyph
+	Width  float64 // Total advance width
+	scale  fixed.Int26_6
+}
+
+// Shaper converts strings into glyphs.
+//
+// Glyphs missing from the requested font are taken from the first font in the fallback chain which has them,
+// so symbols like ° or → and non-Latin text render even if the main font does not include them.
+// Kerning is applied between glyphs of the same font, and the standard f-ligatures are used when
+// the font has them, except in monospaced fonts.
+type Shaper struct {
+	mutex     sync.Mutex
+	fallbacks []draw2d.FontData
+	ligatures bool
+}
+
+// DefaultShaper is used by the package level functions
+var DefaultShaper = NewShaper()
+
+// NewShaper creates a Shaper with ligatures enabled and the default fallback chain
+func NewShaper() *Shaper {
+	return &Shaper{
+		ligatures: true,
+		fallbacks: []draw2d.FontData{
+			{Name: "freemono", Family: draw2d.FontFamilyMono},
+		},
+	}
+}
+
+// SetFallbacks replaces the fallback chain with fonts in the form accepted by ParseFont, e.g. "dejavu sans".
+// Fonts which fail to load are returned as an error but the remainder are still used.
+func (s *Shaper) SetFallbacks(fonts ...string) error {
+	var fallbacks []draw2d.FontData
+	var err error
+	for _, n := range fonts {
+		f, err1 := ParseFont(n)
+		if err1 != nil {
+			err = err1
+			continue
+		}
+		fallbacks = append(fallbacks, f.FontData())
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.fallbacks = fallbacks
+	return err
+}
+
+// Ligatures enables or disables the use of ligatures
+func (s *Shaper) Ligatures(enabled bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.ligatures = enabled
+}
+
+// ligatures in order of preference, longest first
+var ligatures = []struct {
+	seq string
+	r   rune
+}{
+	{"ffi", 'ﬃ'},
+	{"ffl", 'ﬄ'},
+	{"ff", 'ﬀ'},
+	{"fi", 'ﬁ'},
+	{"fl", 'ﬂ'},
+}
+
+// loadFont loads a font from the global font cache, returning nil if it does not exist.
+// Unlike draw2d.GetFont this does not log missing fonts, as fallback fonts may not have every style.
+func loadFont(fd draw2d.FontData) *truetype.Font {
+	f, err := draw2d.GetGlobalFontCache().Load(fd)
+	if err != nil {
+		return nil
+	}
+	return f
+}
+
+// Exists returns true if the font exists, either in the DefaultRegistry or draw2d's font cache
+func Exists(fd draw2d.FontData) bool {
+	return loadFace(fd) != nil
+}
+
+// HasGlyph returns true if the font has a glyph for r
+func HasGlyph(f *truetype.Font, r rune) bool {
+	return f != nil && f.Index(r) != 0
+}
+
+// Shape converts s into glyphs using the font fd at size points and dpi resolution
+func (s *Shaper) Shape(fd draw2d.FontData, size float64, dpi int, text string) *Shaped {
+	s.mutex.Lock()
+	fallbacks, useLigatures := s.fallbacks, s.ligatures && fd.Family != draw2d.FontFamilyMono
+	s.mutex.Unlock()
+
+	primary := loadFace(fd)
+	sh := &Shaped{scale: fixed.Int26_6(size * float64(dpi) * (64.0 / 72.0))}
+
+	has := func(f face, r rune) bool {
+		return f != nil && f.index(r) != 0
+	}
+
+	// find returns the font to use for a rune, the primary font if no font has it
+	find := func(r rune) (draw2d.FontData, face) {
+		if has(primary, r) || r < ' ' {
+			return fd, primary
+		}
+		for _, fb := range fallbacks {
+			fb.Style = fd.Style
+			if f := loadFace(fb); has(f, r) {
+				return fb, f
+			}
+			// Try the regular style as the fallback may not have the style
+			fb.Style = draw2d.FontStyleNormal
+			if f := loadFace(fb); has(f, r) {
+				return fb, f
+			}
+		}
+		return fd, primary
+	}
+
+	x := 0.0
+	var prev *Glyph
+	for text != "" {
+		r, n := utf8.DecodeRuneInString(text)
+
+		if useLigatures && r == 'f' && has(primary, r) {
+			for _, l := range ligatures {
+				if strings.HasPrefix(text, l.seq) && has(primary, l.r) {
+					r, n = l.r, len(l.seq)
+					break
+				}
+			}
+		}
+		text = text[n:]
+
+		gfd, f := find(r)
+		if f == nil {
+			continue
+		}
+
+		g := Glyph{FontData: gfd, face: f, Index: f.index(r), Rune: r}
+		if prev != nil && prev.face == f {
+			x += f.kern(sh.scale, prev.Index, g.Index)
+		}
+		g.X = x
+		g.Advance = f.advance(sh.scale, g.Index)
+		x += g.Advance
+
+		sh.Glyphs = append(sh.Glyphs, g)
+		prev = &sh.Glyphs[len(sh.Glyphs)-1]
+	}
+
+	sh.Width = x
+	return sh
+}
+
+// Bounds returns the pixel bounds of the ink of the shaped string, with the baseline at y=0
+// so top will usually be negative, the same as draw2d's GetStringBounds.
+func (sh *Shaped) Bounds() (left, top, right, bottom float64) {
+	found := false
+	for _, g := range sh.Glyphs {
+		l, t, r, b, ok := g.face.bounds(sh.scale, g.Index)
+		if !ok {
+			// No ink, e.g. a space
+			continue
+		}
+		l, r = l+g.X, r+g.X
+		if !found {
+			left, top, right, bottom, found = l, t, r, b, true
+		} else {
+			left, top, right, bottom = min(left, l), min(top, t), max(right, r), max(bottom, b)
+		}
+	}
+	return left, top, right, bottom
+}
+
+// String returns the text represented by the glyphs, with ligatures
+func (sh *Shaped) String() string {
+	var sb strings.Builder
+	for _, g := range sh.Glyphs {
+		sb.WriteRune(g.Rune)
+	}
+	return sb.String()
+}
+
+// Fill draws the shaped string with its baseline starting at x, y, returning its width
+func (sh *Shaped) Fill(gc draw2d2.GraphicContext, x, y float64) float64 {
+	return sh.draw(gc, x, y, false)
+}
+
+// Stroke draws the outline of the shaped string with its baseline starting at x, y, returning its width
+func (sh *Shaped) Stroke(gc draw2d2.GraphicContext, x, y float64) float64 {
+	return sh.draw(gc, x, y, true)
+}
+
+func (sh *Shaped) draw(gc draw2d2.GraphicContext, x, y float64, stroke bool) float64 {
+	current := gc.GetFontData()
+	defer gc.SetFontData(current)
+
+	for _, g := range sh.Glyphs {
+		g.face.draw(gc, g, x+g.X, y, stroke)
+	}
+	return sh.Width
+}
+
+// ShapeString shapes s with the current font of a GraphicContext using the DefaultShaper
+func ShapeString(gc draw2d2.GraphicContext, s string) *Shaped {
+	return DefaultShaper.Shape(gc.GetFontData(), gc.GetFontSize(), gc.GetDPI(), s)
+}
+
+// StringBounds is a replacement for draw2d's GetStringBounds which uses the DefaultShaper
+func StringBounds(gc draw2d2.GraphicContext, s string) (left, top, right, bottom float64) {
+	return ShapeString(gc, s).Bounds()
+}
+
+// FillStringAt is a replacement for draw2d's FillStringAt which uses the DefaultShaper
+func FillStringAt(gc draw2d2.GraphicContext, s string, x, y float64) float64 {
+	return ShapeString(gc, s).Fill(gc, x, y)
+}
+
+// StrokeStringAt is a replacement for draw2d's StrokeStringAt which uses the DefaultShaper
+func StrokeStringAt(gc draw2d2.GraphicContext, s string, x, y float64) float64 {
+	return ShapeString(gc, s).Stroke(gc, x, y)
+}
@@ -0,0 +1,59 @@
+package font
+
+import (
+	"github.com/llgcode/draw2d"
+	"testing"
+)
+
+func TestShaper_Shape(t *testing.T) {
+	draw2d.SetFontFolder("../../lib/font")
+
+	sans := draw2d.FontData{Name: "luxi", Family: draw2d.FontFamilySans}
+	mono := draw2d.FontData{Name: "luxi", Family: draw2d.FontFamilyMono}
+
+	t.Run("kerning", func(t *testing.T) {
+		s := NewShaper()
+		av := s.Shape(sans, 20, 72, "AV").Width
+		a := s.Shape(sans, 20, 72, "A").Width
+		v := s.Shape(sans, 20, 72, "V").Width
+		if av >= a+v {
+			t.Errorf("AV not kerned, got %f want < %f", av, a+v)
+		}
+	})
+
+	t.Run("ligatures", func(t *testing.T) {
+		s := NewShaper()
+		if got := s.Shape(sans, 20, 72, "fit").String(); got != "ﬁt" {
+			t.Errorf("got %q want %q", got, "ﬁt")
+		}
+		if got := s.Shape(mono, 20, 72, "fit").String(); got != "fit" {
+			t.Errorf("mono got %q want %q", got, "fit")
+		}
+
+		s.Ligatures(false)
+		if got := s.Shape(sans, 20, 72, "fit").String(); got != "fit" {
+			t.Errorf("disabled got %q want %q", got, "fit")
+		}
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		s := NewShaper()
+		sh := s.Shape(sans, 20, 72, "N→S")
+		if len(sh.Glyphs) != 3 {
+			t.Fatalf("got %d glyphs want 3", len(sh.Glyphs))
+		}
+		for i, want := range []string{"luxi", "freemono", "luxi"} {
+			if g := sh.Glyphs[i]; g.FontData.Name != want || g.Index == 0 {
+				t.Errorf("glyph %d %q from %q index %d, want from %q", i, g.Rune, g.FontData.Name, g.Index, want)
+			}
+		}
+
+		// Without fallbacks the primary font's missing glyph is used
+		if err := s.SetFallbacks(); err != nil {
+			t.Fatal(err)
+		}
+		if g := s.Shape(sans, 20, 72, "→").Glyphs[0]; g.FontData.Name != "luxi" || g.Index != 0 {
+			t.Errorf("got %q index %d want luxi index 0", g.FontData.Name, g.Index)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package font
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var errInvalidFont = errors.New("invalid font")
+
+// tables returns the offset and length of each table in the font at offset within an sfnt file or collection
+func tables(b []byte, offset int) (map[string][]byte, error) {
+	if len(b) < offset+12 {
+		return nil, errInvalidFont
+	}
+	n := int(binary.BigEndian.Uint16(b[offset+4:]))
+	if len(b) < offset+12+n*16 {
+		return nil, errInvalidFont
+	}
+
+	t := make(map[string][]byte, n)
+	for i := 0; i < n; i++ {
+		rec := b[offset+12+i*16:]
+		start, length := int(binary.BigEndian.Uint32(rec[8:])), int(binary.BigEndian.Uint32(rec[12:]))
+		if start < 0 || length < 0 || start+length > len(b) {
+			return nil, errInvalidFont
+		}
+		t[string(rec[:4])] = b[start : start+length]
+	}
+	return t, nil
+}
+
+// fontOffsets returns the offset of each font within an sfnt file, more than one for a collection
+func fontOffsets(b []byte) ([]int, error) {
+	if len(b) < 12 {
+		return nil, errInvalidFont
+	}
+	if string(b[:4]) != "ttcf" {
+		return []int{0}, nil
+	}
+
+	n := int(binary.BigEndian.Uint32(b[8:]))
+	if len(b) < 12+n*4 {
+		return nil, errInvalidFont
+	}
+	offsets := make([]int, n)
+	for i := range offsets {
+		offsets[i] = int(binary.BigEndian.Uint32(b[12+i*4:]))
+	}
+	return offsets, nil
+}
+
+// os2 returns the weight class and italic flag from an OS/2 table
+func os2(t []byte) (weight int, italic, ok bool) {
+	if len(t) < 64 {
+		return 0, false, false
+	}
+	selection := binary.BigEndian.Uint16(t[62:])
+	// bit 0 italic, bit 9 oblique
+	return int(binary.BigEndian.Uint16(t[4:])), selection&0x201 != 0, true
+}
+
+// fvarInstance is a named instance in an fvar table
+type fvarInstance struct {
+	nameID uint16
+	coords []float64
+}
+
+// fvar returns the axes and named instances from an fvar table
+func fvar(t []byte) ([]Axis, []fvarInstance) {
+	if len(t) < 16 {
+		return nil, nil
+	}
+	axesOffset := int(binary.BigEndian.Uint16(t[4:]))
+	axisCount := int(binary.BigEndian.Uint16(t[8:]))
+	axisSize := int(binary.BigEndian.Uint16(t[10:]))
+	instanceCount := int(binary.BigEndian.Uint16(t[12:]))
+	instanceSize := int(binary.BigEndian.Uint16(t[14:]))
+
+	if axisSize < 20 || instanceSize < 4+axisCount*4 ||
+		len(t) < axesOffset+axisCount*axisSize+instanceCount*instanceSize {
+		return nil, nil
+	}
+
+	axes := make([]Axis, axisCount)
+	for i := range axes {
+		a := t[axesOffset+i*axisSize:]
+		axes[i] = Axis{
+			Tag:     string(a[:4]),
+			Min:     fixed16(a[4:]),
+			Default: fixed16(a[8:]),
+			Max:     fixed16(a[12:]),
+		}
+	}
+
+	instances := make([]fvarInstance, instanceCount)
+	base := axesOffset + axisCount*axisSize
+	for i := range instances {
+		in := t[base+i*instanceSize:]
+		instances[i].nameID = binary.BigEndian.Uint16(in)
+		for j := 0; j < axisCount; j++ {
+			instances[i].coords = append(instances[i].coords, fixed16(in[4+j*4:]))
+		}
+	}
+	return axes, instances
+}
+
+// fixed16 converts a 16.16 fixed point number
+func fixed16(b []byte) float64 {
+	return float64(int32(binary.BigEndian.Uint32(b))) / 65536
+}
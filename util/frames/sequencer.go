@@ -58,6 +58,18 @@ func (fs *FrameSet) Next() *Frame {
 	return f
 }
 
+// Filter returns a new FrameSet containing just the frames which match the supplied predicate.
+// This does not consume the frames in this FrameSet.
+func (fs *FrameSet) Filter(f func(*Frame) bool) *FrameSet {
+	r := &FrameSet{}
+	for _, frame := range fs.frames {
+		if frame != nil && f(frame) {
+			r.frames = append(r.frames, frame)
+		}
+	}
+	return r
+}
+
 // Sequence returns a FrameSet containing the frames to be rendered.
 // This is the same as SequenceIn except it uses the local TimeZone.
 //
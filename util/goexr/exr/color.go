@@ -65,15 +65,26 @@ func (c RGBAColor) RGBA() (r, g, b, a uint32) {
 	return
 }
 
+// rgbaModel converts a color to an RGBAColor. Colors other than RGBAColor are in sRGB space,
+// so are linearised by reversing the gamma correction performed by RGBA. White remains 1.
 func rgbaModel(c color.Color) color.Color {
 	if _, ok := c.(RGBAColor); ok {
 		return c
 	}
 	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return RGBAColor{}
+	}
+
+	// Linearise the color without its alpha pre-multiplication, then apply it again
+	fa := float64(a) / 0xFFFF
+	linear := func(v uint32) float32 {
+		return float32(math.Pow(float64(v)/float64(a), 1/gammaFactor) * fa)
+	}
 	return RGBAColor{
-		R: float32(r) / float32(0xFFFF),
-		G: float32(g) / float32(0xFFFF),
-		B: float32(b) / float32(0xFFFF),
-		A: float32(a) / float32(0xFFFF),
+		R: linear(r),
+		G: linear(g),
+		B: linear(b),
+		A: float32(fa),
 	}
 }
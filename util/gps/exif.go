@@ -0,0 +1,89 @@
+package gps
+
+import (
+	"errors"
+	"fmt"
+	time2 "github.com/peter-mount/go-anim/util/time"
+	"github.com/rwcarlsen/goexif/exif"
+	"io"
+	"os"
+	"time"
+)
+
+// ErrNoPosition is returned by ReadExif when an image has no GPS position
+var ErrNoPosition = errors.New("no gps position")
+
+// ReadExif returns the GPS position from the EXIF data within an image.
+// The returned Point has the time the image was taken if it's available.
+func ReadExif(r io.Reader) (Point, error) {
+	x, err := exif.Decode(r)
+	switch {
+	case errors.Is(err, io.EOF):
+		// No EXIF data within the image
+		return Point{}, ErrNoPosition
+	case err != nil && exif.IsCriticalError(err):
+		return Point{}, err
+	}
+
+	lat, lon, err := x.LatLong()
+	if exif.IsTagNotPresentError(err) {
+		return Point{}, ErrNoPosition
+	}
+	if err != nil {
+		return Point{}, err
+	}
+
+	p := Point{Latitude: lat, Longitude: lon}
+
+	if t, err := x.DateTime(); err == nil {
+		p.Time = t
+	}
+
+	if tag, err := x.Get(exif.GPSAltitude); err == nil {
+		if n, d, err := tag.Rat2(0); err == nil && d != 0 {
+			p.Elevation = float64(n) / float64(d)
+			// A reference of 1 means below sea level
+			if ref, err := x.Get(exif.GPSAltitudeRef); err == nil {
+				if v, err := ref.Int(0); err == nil && v == 1 {
+					p.Elevation = -p.Elevation
+				}
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// LoadExif returns a Track from the EXIF data of a set of images.
+//
+// The time of each Point is taken from the file name, as used by frames.Sequence,
+// falling back to the EXIF timestamp if the name is not a time.
+// Images without GPS data are ignored, any other error is returned.
+func LoadExif(files []string, loc *time.Location) (*Track, error) {
+	t := NewTrack("")
+	for _, fileName := range files {
+		p, err := loadExif(fileName)
+		if errors.Is(err, ErrNoPosition) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fileName, err)
+		}
+
+		if ft := time2.TimeFromFileNameIn(fileName, loc); !ft.IsZero() {
+			p.Time = ft
+		}
+
+		t.Add(p)
+	}
+	return t.SortByTime(), nil
+}
+
+func loadExif(fileName string) (Point, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return Point{}, err
+	}
+	defer f.Close()
+	return ReadExif(f)
+}
@@ -0,0 +1,110 @@
+package gps
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testGPX = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk>
+    <name>Walk</name>
+    <trkseg>
+      <trkpt lat="51.5000" lon="-0.1000"><ele>10</ele><time>2024-06-01T12:00:00Z</time></trkpt>
+      <trkpt lat="51.5010" lon="-0.1000"><ele>20</ele><time>2024-06-01T12:01:00Z</time></trkpt>
+    </trkseg>
+    <trkseg>
+      <trkpt lat="51.5010" lon="-0.0990"><ele>30</ele><time>2024-06-01T12:02:00Z</time></trkpt>
+    </trkseg>
+  </trk>
+  <rte>
+    <name>Route</name>
+    <rtept lat="51.6" lon="-0.2"/>
+  </rte>
+</gpx>`
+
+func TestReadGPX(t *testing.T) {
+	tracks, err := ReadGPX(strings.NewReader(testGPX))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracks) != 2 {
+		t.Fatalf("got %d tracks want 2", len(tracks))
+	}
+
+	trk := tracks[0]
+	if trk.Name() != "Walk" || trk.Len() != 3 || !trk.IsTimed() {
+		t.Errorf("unexpected track %q %d %v", trk.Name(), trk.Len(), trk.IsTimed())
+	}
+	if tracks[1].IsTimed() {
+		t.Errorf("route should not be timed")
+	}
+
+	p, ok := trk.At(time.Date(2024, 6, 1, 12, 0, 30, 0, time.UTC))
+	if !ok || math.Abs(p.Latitude-51.5005) > 1e-9 || math.Abs(p.Elevation-15) > 1e-9 {
+		t.Errorf("unexpected point %v", p)
+	}
+
+	if l := len(trk.Until(time.Date(2024, 6, 1, 12, 1, 30, 0, time.UTC))); l != 3 {
+		t.Errorf("Until got %d points want 3", l)
+	}
+
+	// 0.001 degrees of latitude is approximately 111m
+	if d := Distance(trk.points[0], trk.points[1]); math.Abs(d-111.2) > 0.5 {
+		t.Errorf("distance got %f", d)
+	}
+}
+
+func TestProjection(t *testing.T) {
+	trk := NewTrack("").
+		Add(Point{Latitude: 0, Longitude: 0}).
+		Add(Point{Latitude: 1, Longitude: 2})
+
+	p := TrackProjection(trk, 100, 100, 10)
+
+	// Longitude range is wider, so it fills the width and is centered vertically
+	x0, y0 := p.Project(0, 0)
+	x1, y1 := p.Project(1, 2)
+	if math.Abs(x0-10) > 1e-6 || math.Abs(x1-90) > 1e-6 {
+		t.Errorf("x got %f,%f", x0, x1)
+	}
+	if y1 >= y0 || math.Abs((y0+y1)/2-50) > 1e-6 {
+		t.Errorf("y got %f,%f", y0, y1)
+	}
+}
+
+func TestLoadExif(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		fileName := filepath.Join(dir, name)
+		if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return fileName
+	}
+
+	// An image without EXIF data has no position so is ignored
+	noExif := write("noexif.jpg", "\xff\xd8\xff\xd9")
+	track, err := LoadExif([]string{noExif}, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if track.Len() != 0 {
+		t.Errorf("got %d points", track.Len())
+	}
+
+	// A corrupt image is an error
+	corrupt := write("corrupt.tiff", "II*\x00\xff\xff\xff\xff")
+	if _, err := LoadExif([]string{noExif, corrupt}, time.UTC); err == nil {
+		t.Error("expected error for corrupt image")
+	}
+
+	if _, err := LoadExif([]string{filepath.Join(dir, "missing.jpg")}, time.UTC); !os.IsNotExist(errors.Unwrap(err)) {
+		t.Errorf("expected not exist, got %v", err)
+	}
+}
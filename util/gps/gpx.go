@@ -0,0 +1,78 @@
+package gps
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// gpx is the subset of the GPX 1.1 schema needed to read tracks and routes
+type gpx struct {
+	Tracks []struct {
+		Name     string `xml:"name"`
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+	Routes []struct {
+		Name   string     `xml:"name"`
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+}
+
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Ele  float64 `xml:"ele"`
+	Time string  `xml:"time"`
+}
+
+func (p gpxPoint) point() Point {
+	r := Point{Latitude: p.Lat, Longitude: p.Lon, Elevation: p.Ele}
+	if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(p.Time)); err == nil {
+		r.Time = t
+	}
+	return r
+}
+
+// ReadGPX reads all tracks and routes from a GPX file.
+// Each track is returned as a single Track with its segments joined together.
+func ReadGPX(r io.Reader) ([]*Track, error) {
+	var g gpx
+	if err := xml.NewDecoder(r).Decode(&g); err != nil {
+		return nil, err
+	}
+
+	var tracks []*Track
+	for _, trk := range g.Tracks {
+		t := NewTrack(trk.Name)
+		for _, seg := range trk.Segments {
+			for _, p := range seg.Points {
+				t.Add(p.point())
+			}
+		}
+		tracks = append(tracks, t)
+	}
+
+	for _, rte := range g.Routes {
+		t := NewTrack(rte.Name)
+		for _, p := range rte.Points {
+			t.Add(p.point())
+		}
+		tracks = append(tracks, t)
+	}
+
+	return tracks, nil
+}
+
+// LoadGPX loads all tracks and routes from a GPX file
+func LoadGPX(fileName string) ([]*Track, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadGPX(f)
+}
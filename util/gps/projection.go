@@ -0,0 +1,68 @@
+package gps
+
+import (
+	"math"
+)
+
+// Projection maps latitude and longitude onto a rectangle using the Web Mercator projection,
+// scaled so that a Track fits within it whilst keeping its aspect ratio.
+type Projection struct {
+	scale  float64 // pixels per projected unit
+	x0, y0 float64 // projected coordinates of the top left corner
+}
+
+// mercator returns the projected coordinates of a position, with y increasing southwards
+func mercator(lat, lon float64) (float64, float64) {
+	const rad = math.Pi / 180
+	// Clamp to the limits of Web Mercator
+	lat = math.Max(-85.05112878, math.Min(85.05112878, lat))
+	return lon * rad, -math.Log(math.Tan(math.Pi/4 + lat*rad/2))
+}
+
+// NewProjection returns a Projection which fits the bounds within a rectangle of width w and height h
+func NewProjection(minLat, minLon, maxLat, maxLon, w, h float64) Projection {
+	x0, y1 := mercator(minLat, minLon)
+	x1, y0 := mercator(maxLat, maxLon)
+	dx, dy := x1-x0, y1-y0
+
+	var s float64
+	switch {
+	case dx <= 0 && dy <= 0:
+		// Single point so pick an arbitrary scale
+		s = 1
+	case dx <= 0:
+		s = h / dy
+	case dy <= 0:
+		s = w / dx
+	default:
+		s = math.Min(w/dx, h/dy)
+	}
+
+	// Center the bounds within the rectangle
+	return Projection{
+		scale: s,
+		x0:    (x0+x1)/2 - w/s/2,
+		y0:    (y0+y1)/2 - h/s/2,
+	}
+}
+
+// TrackProjection returns a Projection which fits the Track within a rectangle of width w and height h
+// leaving a margin of pad pixels around it
+func TrackProjection(t *Track, w, h, pad float64) Projection {
+	minLat, minLon, maxLat, maxLon := t.Bounds()
+	p := NewProjection(minLat, minLon, maxLat, maxLon, math.Max(1, w-pad-pad), math.Max(1, h-pad-pad))
+	p.x0 -= pad / p.scale
+	p.y0 -= pad / p.scale
+	return p
+}
+
+// Project returns the coordinates within the rectangle of a position
+func (p Projection) Project(lat, lon float64) (float64, float64) {
+	x, y := mercator(lat, lon)
+	return (x - p.x0) * p.scale, (y - p.y0) * p.scale
+}
+
+// ProjectPoint returns the coordinates within the rectangle of a Point
+func (p Projection) ProjectPoint(pt Point) (float64, float64) {
+	return p.Project(pt.Latitude, pt.Longitude)
+}
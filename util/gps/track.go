@@ -0,0 +1,149 @@
+// Package gps handles GPS tracks, either from the EXIF data within a set of images or from GPX files.
+package gps
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Point is a single GPS position
+type Point struct {
+	Time      time.Time // Time of the position, zero if unknown
+	Latitude  float64   // Latitude in degrees, positive is north
+	Longitude float64   // Longitude in degrees, positive is east
+	Elevation float64   // Elevation in metres
+}
+
+// Track is a sequence of Points
+type Track struct {
+	name   string
+	points []Point
+}
+
+func NewTrack(name string) *Track {
+	return &Track{name: name}
+}
+
+func (t *Track) Name() string {
+	return t.name
+}
+
+// Add a Point to the Track
+func (t *Track) Add(p Point) *Track {
+	t.points = append(t.points, p)
+	return t
+}
+
+// Len returns the number of Points in the Track
+func (t *Track) Len() int {
+	return len(t.points)
+}
+
+// Points returns the Points in the Track
+func (t *Track) Points() []Point {
+	return t.points
+}
+
+// IsTimed returns true if every Point in the track has a time
+func (t *Track) IsTimed() bool {
+	for _, p := range t.points {
+		if p.Time.IsZero() {
+			return false
+		}
+	}
+	return len(t.points) > 0
+}
+
+// SortByTime sorts the Points by time
+func (t *Track) SortByTime() *Track {
+	sort.SliceStable(t.points, func(i, j int) bool {
+		return t.points[i].Time.Before(t.points[j].Time)
+	})
+	return t
+}
+
+// Bounds returns the min and max latitude and longitude of the Track
+func (t *Track) Bounds() (minLat, minLon, maxLat, maxLon float64) {
+	if len(t.points) == 0 {
+		return
+	}
+	minLat, minLon = math.Inf(1), math.Inf(1)
+	maxLat, maxLon = math.Inf(-1), math.Inf(-1)
+	for _, p := range t.points {
+		minLat, maxLat = math.Min(minLat, p.Latitude), math.Max(maxLat, p.Latitude)
+		minLon, maxLon = math.Min(minLon, p.Longitude), math.Max(maxLon, p.Longitude)
+	}
+	return
+}
+
+// index returns the index of the first Point after t, assuming the Track is sorted by time
+func (t *Track) index(tm time.Time) int {
+	return sort.Search(len(t.points), func(i int) bool {
+		return t.points[i].Time.After(tm)
+	})
+}
+
+// At returns the position at a specific time, interpolating between the Points either side.
+// Times outside the Track return the first or last Point.
+// The returned bool is false if the Track is empty.
+func (t *Track) At(tm time.Time) (Point, bool) {
+	l := len(t.points)
+	if l == 0 {
+		return Point{}, false
+	}
+
+	i := t.index(tm)
+	switch {
+	case i == 0:
+		return t.points[0], true
+	case i == l:
+		return t.points[l-1], true
+	}
+
+	p0, p1 := t.points[i-1], t.points[i]
+	d := p1.Time.Sub(p0.Time)
+	if d <= 0 {
+		return p1, true
+	}
+	f := float64(tm.Sub(p0.Time)) / float64(d)
+	return Point{
+		Time:      tm,
+		Latitude:  p0.Latitude + (p1.Latitude-p0.Latitude)*f,
+		Longitude: p0.Longitude + (p1.Longitude-p0.Longitude)*f,
+		Elevation: p0.Elevation + (p1.Elevation-p0.Elevation)*f,
+	}, true
+}
+
+// Until returns the Points up to and including the position at a specific time
+func (t *Track) Until(tm time.Time) []Point {
+	i := t.index(tm)
+	r := append([]Point{}, t.points[:i]...)
+	if i > 0 && i < len(t.points) {
+		if p, ok := t.At(tm); ok {
+			r = append(r, p)
+		}
+	}
+	return r
+}
+
+// Distance returns the length of the Track in metres
+func (t *Track) Distance() float64 {
+	d := 0.0
+	for i := 1; i < len(t.points); i++ {
+		d += Distance(t.points[i-1], t.points[i])
+	}
+	return d
+}
+
+// earthRadius is the mean radius of the Earth in metres
+const earthRadius = 6371008.8
+
+// Distance returns the great circle distance in metres between two Points
+func Distance(a, b Point) float64 {
+	const rad = math.Pi / 180
+	lat1, lat2 := a.Latitude*rad, b.Latitude*rad
+	dLat, dLon := lat2-lat1, (b.Longitude-a.Longitude)*rad
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
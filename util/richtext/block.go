@@ -0,0 +1,405 @@
+package richtext
+
+import (
+	"github.com/llgcode/draw2d"
+	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/font"
+	"image/color"
+	"strings"
+	"unicode"
+)
+
+// Align is the horizontal alignment of lines within a Block
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+	AlignJustify // Stretch each wrapped line to the width, except the last line of a paragraph
+)
+
+// ParseAlign parses "left", "center", "right" or "justify", defaulting to AlignLeft
+func ParseAlign(s string) Align {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "center", "centre":
+		return AlignCenter
+	case "right":
+		return AlignRight
+	case "justify":
+		return AlignJustify
+	default:
+		return AlignLeft
+	}
+}
+
+// Options for laying out a Block
+type Options struct {
+	Width      float64 // Width to wrap lines to, 0 for no wrapping
+	Align      Align   // Alignment of each line
+	LineHeight float64 // Line height as a multiple of the height of the fonts in the line, 0 for 1
+	MaxLines   int     // Maximum number of lines, 0 for no limit. Truncated or overflowing lines end with Ellipsis
+	Ellipsis   string  // Appended to truncated text, defaults to "…"
+}
+
+// Block is text laid out into lines, ready to be drawn
+type Block struct {
+	Lines  []*Line
+	Width  float64 // Width of the widest line, or Options.Width if set
+	Height float64 // Total height of all lines
+	align  Align
+}
+
+// Line is a single line within a Block
+type Line struct {
+	Width    float64 // Width of the content of the line
+	Baseline float64 // Position of the baseline from the top of the Block
+	Ascent   float64 // Height above the baseline
+	Descent  float64 // Depth below the baseline
+	items    []*item
+	end      bool // true if the line ends a paragraph
+}
+
+// item is a word or space within a line
+type item struct {
+	text    string
+	style   *resolved
+	x       float64
+	width   float64
+	space   bool
+	newline bool
+}
+
+// resolved is a Style resolved against the base font, ready to draw
+type resolved struct {
+	fontData draw2d.FontData
+	exists   bool    // false if neither the font nor the base font exist
+	size     float64 // Size in points
+	ascent   float64 // Ascent in pixels, including any shift
+	descent  float64 // Descent in pixels, including any shift
+	shift    float64 // Baseline shift in pixels
+	colour   color.Color
+}
+
+func resolve(gc draw2d2.GraphicContext, base font.Font, s Style) *resolved {
+	fd := base.FontData()
+	if s.Name != "" {
+		fd.Name = s.Name
+	}
+	if s.HasFamily {
+		fd.Family = s.Family
+	}
+	if s.Bold {
+		fd.Style |= draw2d.FontStyleBold
+	}
+	if s.Italic {
+		fd.Style |= draw2d.FontStyleItalic
+	}
+
+	size := base.Size()
+	if s.Points > 0 {
+		size = s.Points
+	}
+	size *= s.Scale
+
+	if !font.Exists(fd) {
+		// Fall back to the base font if the requested one does not exist
+		fd = base.FontData()
+	}
+
+	dpi := float64(gc.GetDPI())
+	r := &resolved{
+		fontData: fd,
+		exists:   font.Exists(fd),
+		size:     size,
+		shift:    s.Shift * base.Size() * dpi / 72,
+		colour:   s.Colour,
+	}
+
+	if r.exists {
+		ext := font.New(fd.Name, size*dpi/72, fd.Family, fd.Style).Extents()
+		r.ascent = ext.Ascent + r.shift
+		r.descent = -ext.Descent - r.shift
+	}
+	return r
+}
+
+// advance returns the width of s once shaped
+func (r *resolved) advance(gc draw2d2.GraphicContext, s string) float64 {
+	if !r.exists {
+		return 0
+	}
+	return font.DefaultShaper.Shape(r.fontData, r.size, gc.GetDPI(), s).Width
+}
+
+// Layout lays out spans with the base font, wrapping and aligning them as defined by opts
+func Layout(gc draw2d2.GraphicContext, spans []Span, base font.Font, opts Options) *Block {
+	if opts.LineHeight <= 0 {
+		opts.LineHeight = 1
+	}
+	if opts.Ellipsis == "" {
+		opts.Ellipsis = "…"
+	}
+
+	items := split(gc, spans, base)
+	baseStyle := resolve(gc, base, Style{Scale: 1})
+
+	var lines []*Line
+	line := &Line{}
+	var pending []*item // spaces waiting for the next word
+
+	finish := func(end bool) {
+		line.end = end
+		lines = append(lines, line)
+		line, pending = &Line{}, nil
+	}
+
+	for _, it := range items {
+		switch {
+		case it.newline:
+			finish(true)
+
+		case it.space:
+			if len(line.items) > 0 {
+				pending = append(pending, it)
+			}
+
+		default:
+			spaces := 0.0
+			for _, p := range pending {
+				spaces += p.width
+			}
+			// Only break at spaces, so styled parts of a word like m/s<sup>2</sup> stay together
+			if opts.Width > 0 && len(pending) > 0 && line.Width+spaces+it.width > opts.Width {
+				finish(false)
+			}
+			for _, p := range pending {
+				line.add(p)
+			}
+			pending = nil
+			line.add(it)
+		}
+	}
+	finish(true)
+
+	if opts.MaxLines > 0 {
+		if len(lines) > opts.MaxLines {
+			lines = lines[:opts.MaxLines]
+			lines[len(lines)-1].truncate(gc, base, opts)
+		}
+		// Truncate lines overflowing the width, which happens when a word is wider than the width
+		for _, l := range lines {
+			if opts.Width > 0 && l.Width > opts.Width {
+				l.truncate(gc, base, opts)
+			}
+		}
+	}
+
+	b := &Block{Lines: lines, Width: opts.Width, align: opts.Align}
+	y := 0.0
+	for _, l := range lines {
+		l.metrics(baseStyle)
+		h := l.Ascent + l.Descent
+		y += l.Ascent + (h*opts.LineHeight-h)/2
+		l.Baseline = y
+		y += l.Descent + (h*opts.LineHeight-h)/2
+		if opts.Width == 0 {
+			b.Width = max(b.Width, l.Width)
+		}
+	}
+	b.Height = y
+
+	if opts.Align == AlignJustify && opts.Width > 0 {
+		for _, l := range lines {
+			if !l.end {
+				l.justify(opts.Width)
+			}
+		}
+	}
+
+	return b
+}
+
+// split splits spans into words, spaces and line breaks
+func split(gc draw2d2.GraphicContext, spans []Span, base font.Font) []*item {
+	var items []*item
+	for _, span := range spans {
+		style := resolve(gc, base, span.Style)
+		text := span.Text
+		for text != "" {
+			r := []rune(text)[0]
+			var n int
+			switch {
+			case r == '\n':
+				items = append(items, &item{newline: true, style: style})
+				text = text[1:]
+				continue
+			case unicode.IsSpace(r):
+				n = strings.IndexFunc(text, func(r rune) bool { return !unicode.IsSpace(r) || r == '\n' })
+			default:
+				n = strings.IndexFunc(text, unicode.IsSpace)
+			}
+			if n < 0 {
+				n = len(text)
+			}
+			it := &item{text: text[:n], style: style, space: unicode.IsSpace(r)}
+			if it.space {
+				it.text = " "
+			}
+			it.width = style.advance(gc, it.text)
+			items = append(items, it)
+			text = text[n:]
+		}
+	}
+	return items
+}
+
+func (l *Line) add(it *item) {
+	it.x = l.Width
+	l.Width += it.width
+	l.items = append(l.items, it)
+}
+
+// metrics calculates the ascent and descent of the line, using the base style for empty lines
+func (l *Line) metrics(base *resolved) {
+	l.Ascent, l.Descent = base.ascent, base.descent
+	if len(l.items) > 0 {
+		l.Ascent, l.Descent = 0, 0
+	}
+	for _, it := range l.items {
+		l.Ascent = max(l.Ascent, it.style.ascent)
+		l.Descent = max(l.Descent, it.style.descent)
+	}
+}
+
+// justify distributes the space remaining in the line between its spaces
+func (l *Line) justify(width float64) {
+	spaces := 0
+	for _, it := range l.items {
+		if it.space {
+			spaces++
+		}
+	}
+	if spaces == 0 || l.Width >= width {
+		return
+	}
+
+	extra := (width - l.Width) / float64(spaces)
+	x := 0.0
+	for _, it := range l.items {
+		it.x = x
+		if it.space {
+			it.width += extra
+		}
+		x += it.width
+	}
+	l.Width = width
+}
+
+// truncate ends the line with an ellipsis, removing content so that it fits within the width
+func (l *Line) truncate(gc draw2d2.GraphicContext, base font.Font, opts Options) {
+	style := resolve(gc, base, Style{Scale: 1})
+	if n := len(l.items); n > 0 {
+		style = l.items[n-1].style
+	}
+	ellipsis := &item{text: opts.Ellipsis, style: style}
+	ellipsis.width = style.advance(gc, ellipsis.text)
+
+	if opts.Width > 0 {
+		for len(l.items) > 0 && l.Width+ellipsis.width > opts.Width {
+			last := l.items[len(l.items)-1]
+			if len(l.items) == 1 && !last.space {
+				// A single word so remove characters instead
+				r := []rune(last.text)
+				if len(r) <= 1 {
+					l.items, l.Width = nil, 0
+					break
+				}
+				last.text = string(r[:len(r)-1])
+				l.Width -= last.width
+				last.width = last.style.advance(gc, last.text)
+				l.Width += last.width
+				continue
+			}
+			l.items = l.items[:len(l.items)-1]
+			l.Width -= last.width
+		}
+	}
+
+	// Don't leave a trailing space before the ellipsis
+	for len(l.items) > 0 && l.items[len(l.items)-1].space {
+		l.Width -= l.items[len(l.items)-1].width
+		l.items = l.items[:len(l.items)-1]
+	}
+
+	l.add(ellipsis)
+	l.end = true
+}
+
+// offset returns the position of a line from the left of the Block
+func (b *Block) offset(l *Line) float64 {
+	switch b.align {
+	case AlignCenter:
+		return (b.Width - l.Width) / 2
+	case AlignRight:
+		return b.Width - l.Width
+	default:
+		return 0
+	}
+}
+
+// String returns the text of the line
+func (l *Line) String() string {
+	var sb strings.Builder
+	for _, it := range l.items {
+		sb.WriteString(it.text)
+	}
+	return sb.String()
+}
+
+// Draw the Block with its top left corner at x, y
+func (b *Block) Draw(gc draw2d2.GraphicContext, x, y float64) {
+	b.DrawWithEffects(gc, x, y, nil)
+}
+
+// DrawWithEffects draws the Block with its top left corner at x, y, with text effects.
+// A plate is drawn once behind the whole Block.
+func (b *Block) DrawWithEffects(gc draw2d2.GraphicContext, x, y float64, e *util.TextEffects) {
+	if e.HasPlate() {
+		var r util.Rectangle
+		for i, l := range b.Lines {
+			lx := x + b.offset(l)
+			lr := util.Rect(lx, y+l.Baseline-l.Ascent, lx+l.Width, y+l.Baseline+l.Descent)
+			if i == 0 {
+				r = lr
+			} else {
+				r = r.Add(lr)
+			}
+		}
+		e.DrawPlate(gc, r)
+		e = e.WithoutPlate()
+	}
+
+	gc.Save()
+	defer gc.Restore()
+
+	fill := gc.GetFillColor()
+	for _, l := range b.Lines {
+		lx := x + b.offset(l)
+
+		for _, it := range l.items {
+			if it.space {
+				continue
+			}
+			gc.SetFontData(it.style.fontData)
+			gc.SetFontSize(it.style.size)
+			if it.style.colour != nil {
+				gc.SetFillColor(it.style.colour)
+			} else {
+				gc.SetFillColor(fill)
+			}
+			e.FillStringAt(gc, it.text, lx+it.x, y+l.Baseline-it.style.shift)
+		}
+	}
+}
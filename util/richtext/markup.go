@@ -0,0 +1,189 @@
+// Package richtext renders text made up of runs in different styles, described with a small markup syntax.
+//
+// The markup uses tags similar to html:
+//
+//	<b>bold</b>, <i>italic</i>
+//	<color=red>colour</color>, also <colour=#ff0000>
+//	<size=150%>relative size</size>, <size=1.5em> or <size=24> for 24 points
+//	<font=luxi sans>font name and/or family</font>
+//	m/s<sup>2</sup>, H<sub>2</sub>O
+//	<br> for a line break
+//
+// The entities &lt; &gt; and &amp; can be used for literal <, > and &.
+package richtext
+
+import (
+	"fmt"
+	"github.com/llgcode/draw2d"
+	color2 "github.com/peter-mount/go-anim/util/color"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// Style of a Span of text, relative to the base font it is rendered with
+type Style struct {
+	Bold      bool              // Bold
+	Italic    bool              // Italic
+	Name      string            // Font name, "" for the name of the base font
+	Family    draw2d.FontFamily // Font family, used when HasFamily is set
+	HasFamily bool              // true if Family is set
+	Points    float64           // Size in points, 0 for the size of the base font
+	Scale     float64           // Scale applied to the size
+	Shift     float64           // Baseline shift in em of the base font, positive is up
+	Colour    color.Color       // Colour, nil for the current fill colour
+}
+
+// Span is a run of text in a single Style
+type Span struct {
+	Text  string
+	Style Style
+}
+
+var entities = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+
+// Escape escapes s so it is rendered as is when included in markup
+func Escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// Plain returns s as a single Span in the default Style
+func Plain(s string) []Span {
+	return []Span{{Text: s, Style: Style{Scale: 1}}}
+}
+
+// Parse parses markup into a slice of Span's. Unclosed tags are closed at the end of the markup.
+func Parse(s string) ([]Span, error) {
+	type entry struct {
+		tag   string
+		style Style
+	}
+	stack := []entry{{style: Style{Scale: 1}}}
+	var spans []Span
+
+	add := func(text string) {
+		if text != "" {
+			spans = append(spans, Span{Text: entities.Replace(text), Style: stack[len(stack)-1].style})
+		}
+	}
+
+	for s != "" {
+		i := strings.IndexByte(s, '<')
+		if i < 0 {
+			add(s)
+			break
+		}
+		add(s[:i])
+		s = s[i+1:]
+
+		j := strings.IndexByte(s, '>')
+		if j < 0 {
+			return nil, fmt.Errorf("unterminated tag %q", "<"+s)
+		}
+		tag := strings.TrimSpace(s[:j])
+		s = s[j+1:]
+
+		name, value, _ := strings.Cut(tag, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "colour" || name == "/colour" {
+			name = strings.Replace(name, "colour", "color", 1)
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		if name == "br" || name == "br/" {
+			add("\n")
+			continue
+		}
+
+		if strings.HasPrefix(name, "/") {
+			name = strings.TrimPrefix(name, "/")
+			if len(stack) == 1 || stack[len(stack)-1].tag != name {
+				return nil, fmt.Errorf("unexpected </%s>", name)
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		style, err := apply(stack[len(stack)-1].style, name, value)
+		if err != nil {
+			return nil, err
+		}
+		stack = append(stack, entry{tag: name, style: style})
+	}
+
+	return spans, nil
+}
+
+// apply returns style modified by a tag
+func apply(style Style, name, value string) (Style, error) {
+	switch name {
+	case "b":
+		style.Bold = true
+
+	case "i":
+		style.Italic = true
+
+	case "color":
+		c, err := color2.ParseColour(value)
+		if err != nil {
+			return style, fmt.Errorf("invalid colour %q", value)
+		}
+		style.Colour = c
+
+	case "size":
+		if err := style.setSize(value); err != nil {
+			return style, err
+		}
+
+	case "font":
+		for _, e := range strings.Fields(value) {
+			switch e {
+			case "sans":
+				style.Family, style.HasFamily = draw2d.FontFamilySans, true
+			case "serif":
+				style.Family, style.HasFamily = draw2d.FontFamilySerif, true
+			case "mono":
+				style.Family, style.HasFamily = draw2d.FontFamilyMono, true
+			default:
+				style.Name = e
+			}
+		}
+
+	case "sup":
+		style.Shift += 0.4 * style.Scale
+		style.Scale *= 0.6
+
+	case "sub":
+		style.Shift -= 0.2 * style.Scale
+		style.Scale *= 0.6
+
+	default:
+		return style, fmt.Errorf("unsupported tag <%s>", name)
+	}
+	return style, nil
+}
+
+// setSize sets the size from a percentage, em or size in points
+func (s *Style) setSize(value string) error {
+	scale := 0.01
+	switch {
+	case strings.HasSuffix(value, "%"):
+		value = strings.TrimSuffix(value, "%")
+	case strings.HasSuffix(value, "em"):
+		value, scale = strings.TrimSuffix(value, "em"), 1
+	default:
+		scale = 0
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || f <= 0 {
+		return fmt.Errorf("invalid size %q", value)
+	}
+
+	if scale > 0 {
+		s.Scale *= f * scale
+	} else {
+		s.Points = f
+	}
+	return nil
+}
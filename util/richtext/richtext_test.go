@@ -0,0 +1,127 @@
+package richtext
+
+import (
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/font"
+	"image"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		markup  string
+		want    []string
+		wantErr bool
+	}{
+		{markup: "plain", want: []string{"plain"}},
+		{markup: "a <b>bold</b> word", want: []string{"a ", "bold", " word"}},
+		{markup: "m/s<sup>2</sup>", want: []string{"m/s", "2"}},
+		{markup: "1 &lt; 2 &amp;&amp; 3 &gt; 2", want: []string{"1 < 2 && 3 > 2"}},
+		{markup: "a<br>b", want: []string{"a", "\n", "b"}},
+		{markup: "<color=red><i>unclosed", want: []string{"unclosed"}},
+		{markup: "<b>bad</i>", wantErr: true},
+		{markup: "<blink>no</blink>", wantErr: true},
+		{markup: "<size=abc>no</size>", wantErr: true},
+		{markup: "<b", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.markup, func(t *testing.T) {
+			spans, err := Parse(tt.markup)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, s := range spans {
+				got = append(got, s.Text)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Style(t *testing.T) {
+	spans, err := Parse(`<b><size=200%>big</size></b><font=luxi sans><sup>up</sup></font><colour=#ff0000>red</colour>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := spans[0].Style; !s.Bold || s.Scale != 2 {
+		t.Errorf("big got %+v", s)
+	}
+	if s := spans[1].Style; s.Name != "luxi" || !s.HasFamily || s.Family != draw2d.FontFamilySans || s.Shift <= 0 || s.Scale >= 1 {
+		t.Errorf("up got %+v", s)
+	}
+	if s := spans[2].Style; s.Colour == nil {
+		t.Errorf("red got %+v", s)
+	}
+}
+
+func TestLayout(t *testing.T) {
+	draw2d.SetFontFolder("../../lib/font")
+	gc := draw2d2.NewImageGraphicContext(image.NewRGBA(image.Rect(0, 0, 10, 10)))
+	base, err := font.ParseFont("luxi 20 mono")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// luxi mono is fixed width so we can calculate where lines wrap
+	charWidth := Layout(gc, Plain("x"), base, Options{}).Width
+
+	tests := []struct {
+		name   string
+		markup string
+		opts   Options
+		want   []string
+	}{
+		{name: "no wrap", markup: "the quick brown fox", want: []string{"the quick brown fox"}},
+		{name: "wrap", markup: "the quick brown fox", opts: Options{Width: charWidth * 10}, want: []string{"the quick", "brown fox"}},
+		{name: "break", markup: "the<br>quick", want: []string{"the", "quick"}},
+		{name: "glued", markup: "speed 10 m/s<sup>2</sup>", opts: Options{Width: charWidth * 10}, want: []string{"speed 10", "m/s2"}},
+		{name: "ellipsis", markup: "the quick brown fox", opts: Options{Width: charWidth * 10, MaxLines: 1}, want: []string{"the quick…"}},
+		{name: "ellipsis word", markup: "jumped", opts: Options{Width: charWidth * 4, MaxLines: 1}, want: []string{"jum…"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spans, err := Parse(tt.markup)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b := Layout(gc, spans, base, tt.opts)
+			var got []string
+			for _, l := range b.Lines {
+				got = append(got, l.String())
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got %q want %q", got, tt.want)
+			}
+			if b.Height <= 0 {
+				t.Errorf("height %f", b.Height)
+			}
+		})
+	}
+}
+
+func TestLayout_Justify(t *testing.T) {
+	draw2d.SetFontFolder("../../lib/font")
+	gc := draw2d2.NewImageGraphicContext(image.NewRGBA(image.Rect(0, 0, 10, 10)))
+	base, err := font.ParseFont("luxi 20")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := Layout(gc, Plain("the quick brown fox jumped over the lazy dog"), base, Options{Width: 200, Align: AlignJustify})
+	if len(b.Lines) < 2 {
+		t.Fatalf("expected multiple lines got %d", len(b.Lines))
+	}
+	for i, l := range b.Lines[:len(b.Lines)-1] {
+		if l.Width != 200 {
+			t.Errorf("line %d width %f", i, l.Width)
+		}
+	}
+	if last := b.Lines[len(b.Lines)-1]; last.Width >= 200 {
+		t.Errorf("last line justified %f", last.Width)
+	}
+}
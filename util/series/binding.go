@@ -0,0 +1,46 @@
+package series
+
+import "time"
+
+// Binding links one or more Series to a function which accepts their values,
+// e.g. the Args of a layout component.
+type Binding struct {
+	series []*Series
+	set    func(...any)
+}
+
+// Bindings is a collection of Binding's which are updated together
+type Bindings struct {
+	bindings []Binding
+}
+
+// Bind adds a Binding which will call set with the values of each Series, in order, on Update
+func (b *Bindings) Bind(set func(...any), series ...*Series) {
+	b.bindings = append(b.bindings, Binding{series: series, set: set})
+}
+
+// IsEmpty returns true if there are no Binding's
+func (b *Bindings) IsEmpty() bool {
+	return len(b.bindings) == 0
+}
+
+// Update all Binding's with the values of their Series at a specific time.
+// A Binding is not updated if any of its Series are empty.
+func (b *Bindings) Update(t time.Time) {
+	for _, binding := range b.bindings {
+		binding.Update(t)
+	}
+}
+
+// Update the Binding with the values of its Series at a specific time.
+func (b Binding) Update(t time.Time) {
+	args := make([]any, len(b.series))
+	for i, s := range b.series {
+		v, ok := s.At(t)
+		if !ok {
+			return
+		}
+		args[i] = v
+	}
+	b.set(args...)
+}
@@ -0,0 +1,63 @@
+package series
+
+import (
+	"sort"
+	"time"
+)
+
+// DataSet is a collection of named Series, usually loaded from a single file
+type DataSet struct {
+	series map[string]*Series
+}
+
+func NewDataSet() *DataSet {
+	return &DataSet{series: make(map[string]*Series)}
+}
+
+// Get returns the named Series or nil if it does not exist
+func (d *DataSet) Get(name string) *Series {
+	return d.series[name]
+}
+
+// Contains returns true if the named Series exists
+func (d *DataSet) Contains(name string) bool {
+	_, exists := d.series[name]
+	return exists
+}
+
+// Series returns the named Series, creating it if it does not exist
+func (d *DataSet) Series(name string) *Series {
+	s, exists := d.series[name]
+	if !exists {
+		s = New(name)
+		d.series[name] = s
+	}
+	return s
+}
+
+// Names returns the names of all Series in the DataSet, sorted
+func (d *DataSet) Names() []string {
+	var r []string
+	for n := range d.series {
+		r = append(r, n)
+	}
+	sort.Strings(r)
+	return r
+}
+
+// SetInterpolation sets the Interpolation of every Series in the DataSet
+func (d *DataSet) SetInterpolation(i Interpolation) *DataSet {
+	for _, s := range d.series {
+		s.SetInterpolation(i)
+	}
+	return d
+}
+
+// At returns the value of the named Series at a specific time.
+// The returned bool is false if the Series does not exist or is empty.
+func (d *DataSet) At(name string, t time.Time) (float64, bool) {
+	if s, exists := d.series[name]; exists {
+		return s.At(t)
+	}
+	return 0, false
+}
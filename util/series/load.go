@@ -0,0 +1,165 @@
+package series
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	time2 "github.com/peter-mount/go-anim/util/time"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Load a DataSet from a file. The format is determined from the file extension,
+// ".csv" for CSV or ".json", ".jsonl" or ".ndjson" for JSON Lines.
+//
+// timeField is the name of the column or field containing the timestamp,
+// loc the timezone to use when a timestamp does not include one.
+func Load(fileName, timeField string, loc *time.Location) (*DataSet, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return ReadCSV(f, timeField, loc)
+	case ".json", ".jsonl", ".ndjson":
+		return ReadJSONLines(f, timeField, loc)
+	default:
+		return nil, fmt.Errorf("unsupported file type %q", fileName)
+	}
+}
+
+// ReadCSV reads a DataSet from CSV. The first row must be a header naming each column.
+// Every column other than timeField becomes a Series. Cells which are empty or not numeric are ignored.
+func ReadCSV(r io.Reader, timeField string, loc *time.Location) (*DataSet, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	tc := -1
+	for i, h := range header {
+		header[i] = strings.TrimSpace(h)
+		if header[i] == timeField {
+			tc = i
+		}
+	}
+	if tc < 0 {
+		return nil, fmt.Errorf("time column %q not found", timeField)
+	}
+
+	ds := NewDataSet()
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return ds, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if tc >= len(rec) {
+			continue
+		}
+
+		t, err := parseTime(rec[tc], loc)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		for i, v := range rec {
+			if i != tc && i < len(header) {
+				if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+					ds.Series(header[i]).Add(t, f)
+				}
+			}
+		}
+	}
+}
+
+// ReadJSONLines reads a DataSet from JSON Lines, one JSON object per line.
+// Every numeric field other than timeField becomes a Series. Nested objects are
+// flattened so {"wind":{"speed":3}} becomes the Series "wind.speed".
+func ReadJSONLines(r io.Reader, timeField string, loc *time.Location) (*DataSet, error) {
+	ds := NewDataSet()
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		s := strings.TrimSpace(sc.Text())
+		if s == "" {
+			continue
+		}
+
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(s), &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		tv, exists := rec[timeField]
+		if !exists {
+			return nil, fmt.Errorf("line %d: time field %q not found", line, timeField)
+		}
+
+		t, err := parseTime(tv, loc)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		delete(rec, timeField)
+		addFields(ds, t, "", rec)
+	}
+
+	return ds, sc.Err()
+}
+
+func addFields(ds *DataSet, t time.Time, prefix string, rec map[string]any) {
+	for k, v := range rec {
+		switch val := v.(type) {
+		case float64:
+			ds.Series(prefix+k).Add(t, val)
+		case bool:
+			f := 0.0
+			if val {
+				f = 1
+			}
+			ds.Series(prefix+k).Add(t, f)
+		case map[string]any:
+			addFields(ds, t, prefix+k+".", val)
+		}
+	}
+}
+
+// parseTime parses a timestamp which is either a string in one of the formats supported by
+// time.ParseTimeIn, RFC3339 with fractional seconds or a number of seconds since the unix epoch.
+func parseTime(v any, loc *time.Location) (time.Time, error) {
+	switch val := v.(type) {
+	case float64:
+		sec, frac := math.Modf(val)
+		return time.Unix(int64(sec), int64(frac*1e9)).In(loc), nil
+
+	case string:
+		val = strings.TrimSpace(val)
+		if t := time2.ParseTimeIn(val, loc); !t.IsZero() {
+			return t, nil
+		}
+		if t, err := time.ParseInLocation(time.RFC3339Nano, val, loc); err == nil {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", val)
+
+	default:
+		return time.Time{}, fmt.Errorf("invalid timestamp %v", v)
+	}
+}
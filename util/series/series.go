@@ -0,0 +1,190 @@
+// Package series provides time series data which can be interpolated to the time of a frame.
+package series
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Interpolation defines how a Series returns values between its points
+type Interpolation uint8
+
+const (
+	Linear  Interpolation = iota // Linear interpolation between the two points either side
+	Step                         // Value of the last point at or before the time
+	Nearest                      // Value of the point closest to the time
+)
+
+var interpolationNames = [...]string{
+	Linear:  "linear",
+	Step:    "step",
+	Nearest: "nearest",
+}
+
+func (i Interpolation) String() string {
+	if int(i) < len(interpolationNames) {
+		return interpolationNames[i]
+	}
+	return "unknown"
+}
+
+// ParseInterpolation returns the Interpolation from its name
+func ParseInterpolation(s string) (Interpolation, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for i, n := range interpolationNames {
+		if n == s {
+			return Interpolation(i), nil
+		}
+	}
+	return Linear, fmt.Errorf("unsupported interpolation %q", s)
+}
+
+// Point is a single value at a specific time
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Series is a named sequence of Points ordered by time
+type Series struct {
+	name          string
+	interpolation Interpolation
+	points        []Point
+	sorted        bool
+}
+
+func New(name string) *Series {
+	return &Series{name: name, sorted: true}
+}
+
+func (s *Series) Name() string {
+	return s.name
+}
+
+func (s *Series) Interpolation() Interpolation {
+	return s.interpolation
+}
+
+func (s *Series) SetInterpolation(i Interpolation) *Series {
+	s.interpolation = i
+	return s
+}
+
+// Add a value to the series. Values do not need to be added in time order.
+func (s *Series) Add(t time.Time, v float64) *Series {
+	if l := len(s.points); l > 0 && t.Before(s.points[l-1].Time) {
+		s.sorted = false
+	}
+	s.points = append(s.points, Point{Time: t, Value: v})
+	return s
+}
+
+func (s *Series) sort() {
+	if !s.sorted {
+		sort.SliceStable(s.points, func(i, j int) bool {
+			return s.points[i].Time.Before(s.points[j].Time)
+		})
+		s.sorted = true
+	}
+}
+
+// Len returns the number of points in the series
+func (s *Series) Len() int {
+	return len(s.points)
+}
+
+// Points returns the points in the series in time order
+func (s *Series) Points() []Point {
+	s.sort()
+	return s.points
+}
+
+// Start returns the time of the first point
+func (s *Series) Start() time.Time {
+	s.sort()
+	if len(s.points) == 0 {
+		return time.Time{}
+	}
+	return s.points[0].Time
+}
+
+// End returns the time of the last point
+func (s *Series) End() time.Time {
+	s.sort()
+	if len(s.points) == 0 {
+		return time.Time{}
+	}
+	return s.points[len(s.points)-1].Time
+}
+
+// MinMax returns the min and max values within the series
+func (s *Series) MinMax() (float64, float64) {
+	if len(s.points) == 0 {
+		return 0, 0
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, p := range s.points {
+		lo, hi = math.Min(lo, p.Value), math.Max(hi, p.Value)
+	}
+	return lo, hi
+}
+
+// At returns the value of the series at a specific time using the series Interpolation.
+//
+// Times before the first or after the last point return the value of that point.
+// The returned bool is false if the series is empty.
+func (s *Series) At(t time.Time) (float64, bool) {
+	s.sort()
+
+	l := len(s.points)
+	if l == 0 {
+		return 0, false
+	}
+
+	// Index of first point after t
+	i := sort.Search(l, func(i int) bool {
+		return s.points[i].Time.After(t)
+	})
+
+	switch {
+	case i == 0:
+		return s.points[0].Value, true
+	case i == l:
+		return s.points[l-1].Value, true
+	}
+
+	p0, p1 := s.points[i-1], s.points[i]
+	switch s.interpolation {
+	case Step:
+		return p0.Value, true
+
+	case Nearest:
+		if t.Sub(p0.Time) <= p1.Time.Sub(t) {
+			return p0.Value, true
+		}
+		return p1.Value, true
+
+	default:
+		d := p1.Time.Sub(p0.Time)
+		if d <= 0 {
+			return p1.Value, true
+		}
+		f := float64(t.Sub(p0.Time)) / float64(d)
+		return p0.Value + (p1.Value-p0.Value)*f, true
+	}
+}
+
+// Between returns a new Series containing just the points between two times inclusive
+func (s *Series) Between(from, to time.Time) *Series {
+	s.sort()
+	r := New(s.name).SetInterpolation(s.interpolation)
+	for _, p := range s.points {
+		if !p.Time.Before(from) && !p.Time.After(to) {
+			r.points = append(r.points, p)
+		}
+	}
+	return r
+}
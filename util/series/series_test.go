@@ -0,0 +1,109 @@
+package series
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testTime(sec int) time.Time {
+	return time.Date(2024, 6, 1, 12, 0, sec, 0, time.UTC)
+}
+
+func TestSeries_At(t *testing.T) {
+	s := New("test").
+		Add(testTime(10), 10).
+		Add(testTime(0), 0).
+		Add(testTime(20), 30)
+
+	tests := []struct {
+		interp Interpolation
+		sec    int
+		want   float64
+	}{
+		{interp: Linear, sec: -5, want: 0},
+		{interp: Linear, sec: 0, want: 0},
+		{interp: Linear, sec: 5, want: 5},
+		{interp: Linear, sec: 15, want: 20},
+		{interp: Linear, sec: 25, want: 30},
+		{interp: Step, sec: 5, want: 0},
+		{interp: Step, sec: 19, want: 10},
+		{interp: Step, sec: 20, want: 30},
+		{interp: Nearest, sec: 4, want: 0},
+		{interp: Nearest, sec: 6, want: 10},
+		{interp: Nearest, sec: 16, want: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.interp.String(), func(t *testing.T) {
+			s.SetInterpolation(tt.interp)
+			got, ok := s.At(testTime(tt.sec))
+			if !ok || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("at %d got %f,%v want %f", tt.sec, got, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := New("empty").At(testTime(0)); ok {
+		t.Errorf("empty series returned ok")
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	data := `time,temp,wind
+2024-06-01T12:00:00Z,10.5,3
+2024-06-01T12:01:00Z,11.5,
+2024-06-01T12:02:00Z,12.5,5
+`
+	ds, err := ReadCSV(strings.NewReader(data), "time", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Join(ds.Names(), ","); n != "temp,wind" {
+		t.Errorf("got names %q", n)
+	}
+	if l := ds.Get("wind").Len(); l != 2 {
+		t.Errorf("wind got %d points want 2", l)
+	}
+	if v, _ := ds.At("temp", testTime(30)); v != 11 {
+		t.Errorf("temp got %f want 11", v)
+	}
+	if _, err := ReadCSV(strings.NewReader(data), "missing", time.UTC); err == nil {
+		t.Errorf("expected error for missing time column")
+	}
+}
+
+func TestReadJSONLines(t *testing.T) {
+	data := `{"ts":1717243200,"temp":10,"wind":{"speed":2,"dir":180}}
+
+{"ts":"2024-06-01T12:01:00Z","temp":12,"wind":{"speed":4,"dir":190},"station":"abc"}
+`
+	ds, err := ReadJSONLines(strings.NewReader(data), "ts", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Join(ds.Names(), ","); n != "temp,wind.dir,wind.speed" {
+		t.Errorf("got names %q", n)
+	}
+	if v, _ := ds.At("wind.speed", testTime(30)); v != 3 {
+		t.Errorf("wind.speed got %f want 3", v)
+	}
+}
+
+func TestBindings_Update(t *testing.T) {
+	ds := NewDataSet()
+	ds.Series("a").Add(testTime(0), 1).Add(testTime(10), 3)
+	ds.Series("b").Add(testTime(0), 5)
+
+	var got []any
+	var b Bindings
+	b.Bind(func(a ...any) { got = a }, ds.Get("a"), ds.Get("b"))
+	b.Update(testTime(5))
+
+	if len(got) != 2 || got[0] != 2.0 || got[1] != 5.0 {
+		t.Errorf("got %v", got)
+	}
+}
@@ -2,19 +2,21 @@ package util
 
 import (
 	"fmt"
-	"github.com/llgcode/draw2d/draw2dimg"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/font"
 	"image"
 	"math"
 	"strconv"
 	"strings"
 )
 
-func GetStringBounds(gc *draw2dimg.GraphicContext, s string) Rectangle {
-	sl, st, sr, sb := gc.GetStringBounds(s)
+// GetStringBounds returns the bounds of a string, shaped with kerning, ligatures and font fallback
+func GetStringBounds(gc draw2d2.GraphicContext, s string) Rectangle {
+	sl, st, sr, sb := font.StringBounds(gc, s)
 	return Rect(sl, st, sr, sb)
 }
 
-func StringSize(gc *draw2dimg.GraphicContext, s string, a ...interface{}) Rectangle {
+func StringSize(gc draw2d2.GraphicContext, s string, a ...interface{}) Rectangle {
 	var rect Rectangle
 	for i, str := range strings.Split(fmt.Sprintf(s, a...), "\n") {
 		rect1 := GetStringBounds(gc, str)
@@ -31,28 +33,30 @@ func FitString(l, t, r, b, sl, st, sr, sb float64) (float64, float64, float64, f
 	return math.Min(l, sl), math.Min(t, st), math.Max(r, sr), math.Max(b, sb)
 }
 
-func DrawStringLeft(gc *draw2dimg.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
-	for _, str := range strings.Split(fmt.Sprintf(s, a...), "\n") {
-		_, st, _, sb := gc.GetStringBounds(str)
-		gc.FillStringAt(str, x, y+(sb-st)/2)
-		y = y - st + sb
-	}
-	return y
+func DrawStringLeft(gc draw2d2.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
+	return (*TextEffects)(nil).DrawStringLeft(gc, x, y, s, a...)
 }
 
-func DrawStringCenter(gc *draw2dimg.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
-	for _, str := range strings.Split(fmt.Sprintf(s, a...), "\n") {
-		sl, st, sr, sb := gc.GetStringBounds(str)
-		gc.FillStringAt(str, x-(sr-sl)/2, y+(sb-st)/2)
-		y = y - st + sb
-	}
-	return y
+func DrawStringCenter(gc draw2d2.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
+	return (*TextEffects)(nil).DrawStringCenter(gc, x, y, s, a...)
+}
+
+func DrawStringRight(gc draw2d2.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
+	return (*TextEffects)(nil).DrawStringRight(gc, x, y, s, a...)
 }
 
-func DrawStringRight(gc *draw2dimg.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
-	for _, str := range strings.Split(fmt.Sprintf(s, a...), "\n") {
-		sl, st, sr, sb := gc.GetStringBounds(str)
-		gc.FillStringAt(str, x-(sr-sl), y+(sb-st)/2)
+// drawString draws each line of s aligned about x, returning the y coordinate after the last line
+func (e *TextEffects) drawString(gc draw2d2.GraphicContext, a Alignment, x, y float64, s string) float64 {
+	for _, str := range strings.Split(s, "\n") {
+		sl, st, sr, sb := font.StringBounds(gc, str)
+		lx := x
+		switch a {
+		case CenterAlignment:
+			lx = x - (sr-sl)/2
+		case RightAlignment:
+			lx = x - (sr - sl)
+		}
+		e.FillStringAt(gc, str, lx, y+(sb-st)/2)
 		y = y - st + sb
 	}
 	return y
@@ -66,6 +70,18 @@ const (
 	RightAlignment
 )
 
+// ParseAlignment returns the Alignment for "left", "center" or "right", defaulting to LeftAlignment
+func ParseAlignment(s string) Alignment {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "right":
+		return RightAlignment
+	case "center":
+		return CenterAlignment
+	default:
+		return LeftAlignment
+	}
+}
+
 // Fill fills the provided string based on this Alignment.
 // If the string contains "\n" then it will be split and rendered as multiple lines.
 //
@@ -73,8 +89,8 @@ const (
 // bounds 		image.Rectangle of the area to contain the string
 // lineSpacing 	space to add between lines
 // format,args	passed to fmt.Sprintf() before rendering
-func (a Alignment) Fill(gc *draw2dimg.GraphicContext, bounds image.Rectangle, lineSpacing float64, format string, args ...interface{}) float64 {
-	return a.Metrics(gc, bounds, lineSpacing, fmt.Sprintf(format, args...)).Fill(gc)
+func (a Alignment) Fill(gc draw2d2.GraphicContext, bounds image.Rectangle, lineSpacing float64, format string, args ...interface{}) float64 {
+	return a.Metrics(gc, bounds, lineSpacing, "%s", fmt.Sprintf(format, args...)).Fill(gc)
 }
 
 // Stroke the string based on this Alignment.
@@ -84,8 +100,8 @@ func (a Alignment) Fill(gc *draw2dimg.GraphicContext, bounds image.Rectangle, li
 // bounds 		image.Rectangle of the area to contain the string
 // lineSpacing 	space to add between lines
 // format,args	passed to fmt.Sprintf() before rendering
-func (a Alignment) Stroke(gc *draw2dimg.GraphicContext, bounds image.Rectangle, lineSpacing float64, format string, args ...interface{}) float64 {
-	return a.Metrics(gc, bounds, lineSpacing, fmt.Sprintf(format, args...)).Stroke(gc)
+func (a Alignment) Stroke(gc draw2d2.GraphicContext, bounds image.Rectangle, lineSpacing float64, format string, args ...interface{}) float64 {
+	return a.Metrics(gc, bounds, lineSpacing, "%s", fmt.Sprintf(format, args...)).Stroke(gc)
 }
 
 // FillStroke fills then strokes the string based on this Alignment.
@@ -95,8 +111,8 @@ func (a Alignment) Stroke(gc *draw2dimg.GraphicContext, bounds image.Rectangle,
 // bounds 		image.Rectangle of the area to contain the string
 // lineSpacing 	space to add between lines
 // format,args	passed to fmt.Sprintf() before rendering
-func (a Alignment) FillStroke(gc *draw2dimg.GraphicContext, bounds image.Rectangle, lineSpacing float64, format string, args ...interface{}) float64 {
-	return a.Metrics(gc, bounds, lineSpacing, fmt.Sprintf(format, args...)).FillStroke(gc)
+func (a Alignment) FillStroke(gc draw2d2.GraphicContext, bounds image.Rectangle, lineSpacing float64, format string, args ...interface{}) float64 {
+	return a.Metrics(gc, bounds, lineSpacing, "%s", fmt.Sprintf(format, args...)).FillStroke(gc)
 }
 
 type AlignmentMetrics struct {
@@ -107,6 +123,7 @@ type AlignmentMetrics struct {
 	BaseLines     []float64       // baseline for each line
 	Widths        []float64       // Width of each line
 	Lines         []string        // Line strings
+	Effects       *TextEffects    // Effects to fill the text with, nil for none
 	xFunc         func(int) float64
 }
 
@@ -137,7 +154,7 @@ func (m *AlignmentMetrics) Merge(b *AlignmentMetrics) {
 	b.MaxLineHeight = m.MaxLineHeight
 }
 
-func (a Alignment) Metrics(gc *draw2dimg.GraphicContext, bounds image.Rectangle, lineSpacing float64, format string, args ...interface{}) *AlignmentMetrics {
+func (a Alignment) Metrics(gc draw2d2.GraphicContext, bounds image.Rectangle, lineSpacing float64, format string, args ...interface{}) *AlignmentMetrics {
 	lineSpacing = max(0, lineSpacing)
 
 	m := &AlignmentMetrics{
@@ -146,7 +163,7 @@ func (a Alignment) Metrics(gc *draw2dimg.GraphicContext, bounds image.Rectangle,
 	}
 
 	for _, line := range m.Lines {
-		l, t, r, b := gc.GetStringBounds(line)
+		l, t, r, b := font.StringBounds(gc, line)
 		m.MaxLineHeight = max(m.MaxLineHeight, b-t)
 		m.MaxLineWidth = max(m.MaxLineWidth, r-l)
 		m.BaseLines = append(m.BaseLines, -t) // t is always <=0
@@ -191,18 +208,39 @@ func (m *AlignmentMetrics) centerX(i int) float64 {
 
 func (m *AlignmentMetrics) rightX(i int) float64 { return float64(m.Bounds.Dx()) - m.Widths[i] }
 
-// Fill fills the string defined in this AlignmentMetrics into the supplied GraphicContext
-func (m *AlignmentMetrics) Fill(gc *draw2dimg.GraphicContext) float64 {
-	return m.paint(gc.FillStringAt)
+// TextBounds returns the area covered by the lines of text, relative to the origin they are drawn at
+func (m *AlignmentMetrics) TextBounds() Rectangle {
+	var r Rectangle
+	for i := range m.Lines {
+		x, y := m.xFunc(i), float64(i)*m.MaxLineHeight
+		lr := Rect(x, y, x+m.Widths[i], y+m.MaxLineHeight)
+		if i == 0 {
+			r = lr
+		} else {
+			r = r.Add(lr)
+		}
+	}
+	return r
+}
+
+// Fill fills the string defined in this AlignmentMetrics into the supplied GraphicContext, with any Effects.
+// A plate is drawn once behind all lines.
+func (m *AlignmentMetrics) Fill(gc draw2d2.GraphicContext) float64 {
+	e := m.Effects
+	if e.HasPlate() {
+		e.DrawPlate(gc, m.TextBounds())
+		e = e.WithoutPlate()
+	}
+	return m.paint(func(s string, x, y float64) float64 { return e.FillStringAt(gc, s, x, y) })
 }
 
 // Stroke the string defined in this AlignmentMetrics into the supplied GraphicContext
-func (m *AlignmentMetrics) Stroke(gc *draw2dimg.GraphicContext) float64 {
-	return m.paint(gc.StrokeStringAt)
+func (m *AlignmentMetrics) Stroke(gc draw2d2.GraphicContext) float64 {
+	return m.paint(func(s string, x, y float64) float64 { return font.StrokeStringAt(gc, s, x, y) })
 }
 
 // FillStroke first fills then strokes the string defined in this AlignmentMetrics into the supplied GraphicContext
-func (m *AlignmentMetrics) FillStroke(gc *draw2dimg.GraphicContext) float64 {
+func (m *AlignmentMetrics) FillStroke(gc draw2d2.GraphicContext) float64 {
 	_ = m.Fill(gc)
 	return m.Stroke(gc)
 }
@@ -0,0 +1,126 @@
+package svg
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// namedColours are the common CSS colour keywords
+var namedColours = map[string]color.RGBA{
+	"black":   {0, 0, 0, 255},
+	"silver":  {192, 192, 192, 255},
+	"gray":    {128, 128, 128, 255},
+	"grey":    {128, 128, 128, 255},
+	"white":   {255, 255, 255, 255},
+	"maroon":  {128, 0, 0, 255},
+	"red":     {255, 0, 0, 255},
+	"purple":  {128, 0, 128, 255},
+	"fuchsia": {255, 0, 255, 255},
+	"magenta": {255, 0, 255, 255},
+	"green":   {0, 128, 0, 255},
+	"lime":    {0, 255, 0, 255},
+	"olive":   {128, 128, 0, 255},
+	"yellow":  {255, 255, 0, 255},
+	"navy":    {0, 0, 128, 255},
+	"blue":    {0, 0, 255, 255},
+	"teal":    {0, 128, 128, 255},
+	"aqua":    {0, 255, 255, 255},
+	"cyan":    {0, 255, 255, 255},
+	"orange":  {255, 165, 0, 255},
+	"gold":    {255, 215, 0, 255},
+	"pink":    {255, 192, 203, 255},
+	"brown":   {165, 42, 42, 255},
+	"skyblue": {135, 206, 235, 255},
+
+	"lightgray":  {211, 211, 211, 255},
+	"lightgrey":  {211, 211, 211, 255},
+	"darkgray":   {169, 169, 169, 255},
+	"darkgrey":   {169, 169, 169, 255},
+	"dimgray":    {105, 105, 105, 255},
+	"dimgrey":    {105, 105, 105, 255},
+	"whitesmoke": {245, 245, 245, 255},
+	"lightblue":  {173, 216, 230, 255},
+	"darkblue":   {0, 0, 139, 255},
+	"steelblue":  {70, 130, 180, 255},
+	"darkgreen":  {0, 100, 0, 255},
+	"darkred":    {139, 0, 0, 255},
+	"darkorange": {255, 140, 0, 255},
+}
+
+// parseColour parses a CSS colour: a keyword, currentColor, #rgb, #rrggbb, #rrggbbaa, rgb() or rgba()
+func parseColour(s string, current color.Color) (color.Color, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if s == "currentcolor" {
+		return current, true
+	}
+
+	if c, ok := namedColours[s]; ok {
+		return c, true
+	}
+
+	if strings.HasPrefix(s, "#") {
+		return parseHex(s[1:])
+	}
+
+	if f, args, ok := strings.Cut(s, "("); ok && (f == "rgb" || f == "rgba") {
+		args = strings.TrimSuffix(strings.TrimSpace(args), ")")
+		v := strings.FieldsFunc(args, func(r rune) bool { return r == ',' || r == ' ' || r == '/' })
+		if len(v) < 3 {
+			return nil, false
+		}
+		var c [4]uint8
+		c[3] = 255
+		for i := 0; i < len(v) && i < 4; i++ {
+			pc := strings.HasSuffix(v[i], "%")
+			f, err := strconv.ParseFloat(strings.TrimSuffix(v[i], "%"), 64)
+			if err != nil {
+				return nil, false
+			}
+			switch {
+			case pc:
+				f *= 2.55
+			case i == 3:
+				f *= 255
+			}
+			c[i] = uint8(math.Round(math.Max(0, math.Min(255, f))))
+		}
+		return rgba(c), true
+	}
+
+	return nil, false
+}
+
+func parseHex(s string) (color.Color, bool) {
+	// Expand the short forms
+	if len(s) == 3 || len(s) == 4 {
+		var b strings.Builder
+		for _, r := range s {
+			b.WriteRune(r)
+			b.WriteRune(r)
+		}
+		s = b.String()
+	}
+	if len(s) == 6 {
+		s += "ff"
+	}
+	if len(s) != 8 {
+		return nil, false
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, false
+	}
+	return rgba([4]uint8{uint8(v >> 24), uint8(v >> 16), uint8(v >> 8), uint8(v)}), true
+}
+
+// rgba returns the colour of non-premultiplied components
+func rgba(c [4]uint8) color.Color {
+	if c[3] == 255 {
+		return color.RGBA{R: c[0], G: c[1], B: c[2], A: 255}
+	}
+	return color.NRGBA{R: c[0], G: c[1], B: c[2], A: c[3]}
+}
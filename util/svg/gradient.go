@@ -0,0 +1,138 @@
+package svg
+
+import (
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"image/color"
+	"strings"
+)
+
+// gradient is a gradient element with those it inherits from with href
+type gradient struct {
+	chain []*node
+}
+
+func (r *renderer) gradient(id string) *gradient {
+	g := &gradient{}
+	for n := r.doc.ids[id]; n != nil && len(g.chain) < maxUseDepth; n = r.doc.ids[strings.TrimPrefix(n.attrs["href"], "#")] {
+		if n.name != "linearGradient" && n.name != "radialGradient" {
+			break
+		}
+		g.chain = append(g.chain, n)
+	}
+	if len(g.chain) == 0 {
+		return nil
+	}
+	return g
+}
+
+// attr returns the first value of an attribute along the chain
+func (g *gradient) attr(name string) string {
+	for _, n := range g.chain {
+		if v := n.attr(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// coord returns a coordinate, with percentages relative to size
+func (g *gradient) coord(name string, def, size float64) float64 {
+	v := g.attr(name)
+	if v == "" {
+		return def
+	}
+	f, ok := parseLength(v)
+	if !ok {
+		return def
+	}
+	if strings.HasSuffix(v, "%") {
+		return f / 100 * size
+	}
+	return f
+}
+
+// stops returns the stop elements of the first gradient in the chain which has them
+func (g *gradient) stops() []*node {
+	for _, n := range g.chain {
+		var stops []*node
+		for _, c := range n.children {
+			if c.name == "stop" {
+				stops = append(stops, c)
+			}
+		}
+		if len(stops) > 0 {
+			return stops
+		}
+	}
+	return nil
+}
+
+// paint returns the Paint of the gradient with the given id for the shape built by build, nil if there is none.
+// alpha is applied to every stop.
+func (r *renderer) paint(id string, build func(draw2d.PathBuilder), alpha float64) draw2d2.Paint {
+	g := r.gradient(id)
+	if g == nil {
+		return nil
+	}
+
+	stops := g.stops()
+	if len(stops) == 0 {
+		return nil
+	}
+
+	grad := draw2d2.NewGradient().Spread(draw2d2.ParseSpread(g.attr("spreadMethod")))
+	last := 0.0
+	for _, s := range stops {
+		offset := opacity(s.attr("offset"), 0)
+		// Offsets never decrease
+		last = max(last, offset)
+
+		c, ok := parseColour(s.attr("stop-color"), color.Black)
+		if !ok {
+			c = color.Black
+		}
+		grad.Stop(last, withOpacity(c, alpha*opacity(s.attr("stop-opacity"), 1)))
+	}
+
+	// Gradient coordinates are either a fraction of the bounding box of the shape or in user space
+	m := draw2d.NewIdentityMatrix()
+	w, h := r.doc.viewBox[2], r.doc.viewBox[3]
+	if g.attr("gradientUnits") != "userSpaceOnUse" {
+		p := &draw2d.Path{}
+		build(p)
+		x0, y0, x1, y1 := draw2d2.Bounds(p)
+		if x1 <= x0 || y1 <= y0 {
+			return nil
+		}
+		m = draw2d.NewTranslationMatrix(x0, y0)
+		m.Scale(x1-x0, y1-y0)
+		w, h = 1, 1
+	}
+	if t := g.attr("gradientTransform"); t != "" {
+		m.Compose(parseTransform(t))
+	}
+
+	var p draw2d2.Paint
+	if g.chain[0].name == "radialGradient" {
+		p = grad.Radial(g.coord("cx", 0.5*w, w), g.coord("cy", 0.5*h, h), g.coord("r", 0.5*w, w))
+	} else {
+		p = grad.Linear(g.coord("x1", 0, w), g.coord("y1", 0, h), g.coord("x2", w, w), g.coord("y2", 0, h))
+	}
+
+	if m.IsIdentity() {
+		return p
+	}
+	m.Inverse()
+	return &transformed{paint: p, inverse: m}
+}
+
+// transformed is a Paint defined in another coordinate system
+type transformed struct {
+	paint   draw2d2.Paint
+	inverse draw2d.Matrix // Maps user space to the coordinates of paint
+}
+
+func (t *transformed) At(x, y float64) color.RGBA64 {
+	return t.paint.At(t.inverse.TransformPoint(x, y))
+}
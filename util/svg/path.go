@@ -0,0 +1,318 @@
+package svg
+
+import (
+	"github.com/llgcode/draw2d"
+	"math"
+	"strconv"
+)
+
+// pathScanner splits path data into commands and numbers
+type pathScanner struct {
+	s   string
+	pos int
+}
+
+func (p *pathScanner) skipSeparators() {
+	for p.pos < len(p.s) {
+		switch p.s[p.pos] {
+		case ' ', ',', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// command returns the next command letter, 0 if the next token is a number or at the end
+func (p *pathScanner) command() byte {
+	p.skipSeparators()
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	c := p.s[p.pos]
+	if (c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') && c != 'e' && c != 'E' {
+		p.pos++
+		return c
+	}
+	return 0
+}
+
+// more returns true if a number follows
+func (p *pathScanner) more() bool {
+	p.skipSeparators()
+	if p.pos >= len(p.s) {
+		return false
+	}
+	c := p.s[p.pos]
+	return c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9')
+}
+
+// number returns the next number. Numbers need not be separated, e.g. "1.5.5" is 1.5 and .5, and "1-2" is 1 and -2
+func (p *pathScanner) number() (float64, bool) {
+	p.skipSeparators()
+	start := p.pos
+	i := p.pos
+	if i < len(p.s) && (p.s[i] == '-' || p.s[i] == '+') {
+		i++
+	}
+	dot, exp := false, false
+scan:
+	for ; i < len(p.s); i++ {
+		c := p.s[i]
+		switch {
+		case c >= '0' && c <= '9':
+		case c == '.' && !dot && !exp:
+			dot = true
+		case (c == 'e' || c == 'E') && !exp:
+			exp = true
+			if i+1 < len(p.s) && (p.s[i+1] == '-' || p.s[i+1] == '+') {
+				i++
+			}
+		default:
+			break scan
+		}
+	}
+	v, err := strconv.ParseFloat(p.s[start:i], 64)
+	if err != nil {
+		return 0, false
+	}
+	p.pos = i
+	return v, true
+}
+
+// flag returns an arc flag, which may be a single digit not separated from what follows
+func (p *pathScanner) flag() (bool, bool) {
+	p.skipSeparators()
+	if p.pos < len(p.s) && (p.s[p.pos] == '0' || p.s[p.pos] == '1') {
+		p.pos++
+		return p.s[p.pos-1] == '1', true
+	}
+	return false, false
+}
+
+// numbers reads n numbers, returning false if they are not all present
+func (p *pathScanner) numbers(v []float64) bool {
+	for i := range v {
+		f, ok := p.number()
+		if !ok {
+			return false
+		}
+		v[i] = f
+	}
+	return true
+}
+
+// parsePath adds path data to path. Parsing stops at the first error, keeping what was parsed, as browsers do.
+func parsePath(path draw2d.PathBuilder, d string) {
+	p := &pathScanner{s: d}
+
+	var (
+		x, y     float64 // Current point
+		sx, sy   float64 // Start of the current sub path
+		cx, cy   float64 // Last control point, for smooth curves
+		last     byte    // Last command, in upper case
+		cmd      byte
+		v        [7]float64
+		hasStart bool
+	)
+
+	for {
+		if c := p.command(); c != 0 {
+			cmd = c
+		} else if !p.more() || cmd == 0 {
+			return
+		}
+
+		upper := cmd &^ 0x20
+		if !hasStart && upper != 'M' {
+			// Path data must start with a move
+			return
+		}
+
+		rel := cmd >= 'a'
+		ox, oy := 0.0, 0.0
+		if rel {
+			ox, oy = x, y
+		}
+
+		switch upper {
+		case 'M':
+			if !p.numbers(v[:2]) {
+				return
+			}
+			x, y = ox+v[0], oy+v[1]
+			sx, sy = x, y
+			path.MoveTo(x, y)
+			hasStart = true
+			// Following pairs are implicit line commands
+			if rel {
+				cmd = 'l'
+			} else {
+				cmd = 'L'
+			}
+
+		case 'Z':
+			path.Close()
+			x, y = sx, sy
+			// A following command other than move starts at the start of the closed path
+			path.MoveTo(x, y)
+			// Numbers cannot follow so stop if no command does
+			cmd = 0
+
+		case 'L':
+			if !p.numbers(v[:2]) {
+				return
+			}
+			x, y = ox+v[0], oy+v[1]
+			path.LineTo(x, y)
+
+		case 'H':
+			if !p.numbers(v[:1]) {
+				return
+			}
+			x = ox + v[0]
+			path.LineTo(x, y)
+
+		case 'V':
+			if !p.numbers(v[:1]) {
+				return
+			}
+			y = oy + v[0]
+			path.LineTo(x, y)
+
+		case 'C', 'S':
+			x1, y1 := x, y
+			n := 6
+			if upper == 'S' {
+				n = 4
+				// Reflect the previous control point
+				if last == 'C' || last == 'S' {
+					x1, y1 = 2*x-cx, 2*y-cy
+				}
+			}
+			if !p.numbers(v[:n]) {
+				return
+			}
+			if upper == 'C' {
+				x1, y1 = ox+v[0], oy+v[1]
+			}
+			cx, cy = ox+v[n-4], oy+v[n-3]
+			x, y = ox+v[n-2], oy+v[n-1]
+			path.CubicCurveTo(x1, y1, cx, cy, x, y)
+
+		case 'Q', 'T':
+			if upper == 'T' {
+				if last == 'Q' || last == 'T' {
+					cx, cy = 2*x-cx, 2*y-cy
+				} else {
+					cx, cy = x, y
+				}
+				if !p.numbers(v[:2]) {
+					return
+				}
+				x, y = ox+v[0], oy+v[1]
+			} else {
+				if !p.numbers(v[:4]) {
+					return
+				}
+				cx, cy = ox+v[0], oy+v[1]
+				x, y = ox+v[2], oy+v[3]
+			}
+			path.QuadCurveTo(cx, cy, x, y)
+
+		case 'A':
+			if !p.numbers(v[:3]) {
+				return
+			}
+			large, ok1 := p.flag()
+			sweep, ok2 := p.flag()
+			if !ok1 || !ok2 || !p.numbers(v[3:5]) {
+				return
+			}
+			x2, y2 := ox+v[3], oy+v[4]
+			arc(path, x, y, v[0], v[1], v[2], large, sweep, x2, y2)
+			x, y = x2, y2
+
+		default:
+			return
+		}
+
+		last = upper
+	}
+}
+
+// arc adds an elliptical arc from x1,y1 to x2,y2 as cubic curves, converting from the endpoint parameterisation
+// used by SVG as described in the SVG specification, appendix B.2.4
+func arc(path draw2d.PathBuilder, x1, y1, rx, ry, rotation float64, large, sweep bool, x2, y2 float64) {
+	if x1 == x2 && y1 == y2 {
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		path.LineTo(x2, y2)
+		return
+	}
+
+	phi := rotation * math.Pi / 180
+	sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+
+	// Step 1, the midpoint in the rotated coordinates
+	dx, dy := (x1-x2)/2, (y1-y2)/2
+	x1p := cosPhi*dx + sinPhi*dy
+	y1p := -sinPhi*dx + cosPhi*dy
+
+	// Scale up the radii if they are too small to reach
+	if l := x1p*x1p/(rx*rx) + y1p*y1p/(ry*ry); l > 1 {
+		l = math.Sqrt(l)
+		rx, ry = rx*l, ry*l
+	}
+
+	// Step 2, the center in the rotated coordinates
+	num := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	den := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+	c := math.Sqrt(math.Max(0, num/den))
+	if large == sweep {
+		c = -c
+	}
+	cxp, cyp := c*rx*y1p/ry, -c*ry*x1p/rx
+
+	// Step 3, the center
+	cx := cosPhi*cxp - sinPhi*cyp + (x1+x2)/2
+	cy := sinPhi*cxp + cosPhi*cyp + (y1+y2)/2
+
+	// Step 4, the start angle and sweep
+	theta := math.Atan2((y1p-cyp)/ry, (x1p-cxp)/rx)
+	delta := math.Atan2((-y1p-cyp)/ry, (-x1p-cxp)/rx) - theta
+	if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	} else if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+
+	// Approximate with a cubic curve for each quarter or less
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(n)
+	k := 4.0 / 3 * math.Tan(step/4)
+
+	point := func(a float64) (float64, float64, float64, float64) {
+		cosA, sinA := math.Cos(a), math.Sin(a)
+		// Point and derivative on the unrotated ellipse
+		px, py := rx*cosA, ry*sinA
+		tx, ty := -rx*sinA, ry*cosA
+		return cx + cosPhi*px - sinPhi*py, cy + sinPhi*px + cosPhi*py,
+			cosPhi*tx - sinPhi*ty, sinPhi*tx + cosPhi*ty
+	}
+
+	a := theta
+	px, py, tx, ty := point(a)
+	for i := 0; i < n; i++ {
+		a += step
+		qx, qy, ux, uy := point(a)
+		if i == n-1 {
+			// Finish exactly on the end point
+			qx, qy = x2, y2
+		}
+		path.CubicCurveTo(px+k*tx, py+k*ty, qx-k*ux, qy-k*uy, qx, qy)
+		px, py, tx, ty = qx, qy, ux, uy
+	}
+}
@@ -0,0 +1,206 @@
+package svg
+
+import (
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"math"
+	"strings"
+)
+
+// maxUseDepth limits how deeply use elements are followed, so a use referring to itself does not loop
+const maxUseDepth = 8
+
+// renderer draws the elements of a Document
+type renderer struct {
+	doc   *Document
+	gc    draw2d2.GraphicContext
+	depth int // Depth of use elements being drawn
+}
+
+// group draws the children of n
+func (r *renderer) group(n *node, s style) {
+	for _, c := range n.children {
+		r.element(c, s)
+	}
+}
+
+// element draws n and its children with the style inherited from its parent
+func (r *renderer) element(n *node, parent style) {
+	switch n.name {
+	case "defs", "linearGradient", "radialGradient", "symbol", "title", "desc", "metadata", "style",
+		"clipPath", "mask", "pattern", "filter", "marker", "text":
+		return
+	}
+	if hidden(n) {
+		return
+	}
+
+	s := parent.apply(n)
+
+	gc := r.gc
+	gc.Save()
+	defer gc.Restore()
+	if t := n.attr("transform"); t != "" {
+		gc.ComposeMatrixTransform(parseTransform(t))
+	}
+
+	switch n.name {
+	case "svg":
+		// A nested svg is positioned at x,y and may have its own view box
+		if n != r.doc.root {
+			gc.Translate(n.number("x", 0), n.number("y", 0))
+			vb := parseNumbers(n.attr("viewBox"))
+			w, h := n.number("width", 0), n.number("height", 0)
+			if len(vb) == 4 && vb[2] > 0 && vb[3] > 0 && w > 0 && h > 0 {
+				gc.Scale(w/vb[2], h/vb[3])
+				gc.Translate(-vb[0], -vb[1])
+			}
+		}
+		r.group(n, s)
+
+	case "g", "a", "switch":
+		r.group(n, s)
+
+	case "use":
+		target := r.doc.ids[strings.TrimPrefix(n.attrs["href"], "#")]
+		if target == nil || r.depth >= maxUseDepth {
+			return
+		}
+		r.depth++
+		defer func() { r.depth-- }()
+		gc.Translate(n.number("x", 0), n.number("y", 0))
+		if target.name == "symbol" {
+			r.group(target, s.apply(target))
+		} else {
+			r.element(target, s)
+		}
+
+	default:
+		if build := shape(n); build != nil {
+			r.draw(s, build)
+		}
+	}
+}
+
+// shape returns a function adding the geometry of a shape element to a path, nil if n is not a shape
+func shape(n *node) func(draw2d.PathBuilder) {
+	switch n.name {
+	case "path":
+		d := n.attrs["d"]
+		return func(p draw2d.PathBuilder) {
+			parsePath(p, d)
+		}
+
+	case "rect":
+		x, y := n.number("x", 0), n.number("y", 0)
+		w, h := n.number("width", 0), n.number("height", 0)
+		if w <= 0 || h <= 0 {
+			return nil
+		}
+		rx, okx := parseLength(n.attr("rx"))
+		ry, oky := parseLength(n.attr("ry"))
+		// Either radius defaults to the other
+		if !okx {
+			rx = ry
+		}
+		if !oky {
+			ry = rx
+		}
+		rx, ry = math.Max(0, math.Min(rx, w/2)), math.Max(0, math.Min(ry, h/2))
+		return func(p draw2d.PathBuilder) {
+			if rx == 0 || ry == 0 {
+				draw2d2.Rectangle(p, x, y, w, h)
+				return
+			}
+			p.MoveTo(x+rx, y)
+			p.LineTo(x+w-rx, y)
+			p.ArcTo(x+w-rx, y+ry, rx, ry, -math.Pi/2, math.Pi/2)
+			p.LineTo(x+w, y+h-ry)
+			p.ArcTo(x+w-rx, y+h-ry, rx, ry, 0, math.Pi/2)
+			p.LineTo(x+rx, y+h)
+			p.ArcTo(x+rx, y+h-ry, rx, ry, math.Pi/2, math.Pi/2)
+			p.LineTo(x, y+ry)
+			p.ArcTo(x+rx, y+ry, rx, ry, math.Pi, math.Pi/2)
+			p.Close()
+		}
+
+	case "circle", "ellipse":
+		cx, cy := n.number("cx", 0), n.number("cy", 0)
+		rx, ry := n.number("rx", 0), n.number("ry", 0)
+		if n.name == "circle" {
+			rx = n.number("r", 0)
+			ry = rx
+		}
+		if rx <= 0 || ry <= 0 {
+			return nil
+		}
+		return func(p draw2d.PathBuilder) {
+			draw2d2.Ellipse(p, cx, cy, rx, ry)
+		}
+
+	case "line":
+		x1, y1 := n.number("x1", 0), n.number("y1", 0)
+		x2, y2 := n.number("x2", 0), n.number("y2", 0)
+		return func(p draw2d.PathBuilder) {
+			p.MoveTo(x1, y1)
+			p.LineTo(x2, y2)
+		}
+
+	case "polyline", "polygon":
+		v := parseNumbers(n.attrs["points"])
+		if len(v) < 4 {
+			return nil
+		}
+		closed := n.name == "polygon"
+		return func(p draw2d.PathBuilder) {
+			p.MoveTo(v[0], v[1])
+			for i := 2; i+1 < len(v); i += 2 {
+				p.LineTo(v[i], v[i+1])
+			}
+			if closed {
+				p.Close()
+			}
+		}
+
+	default:
+		return nil
+	}
+}
+
+// draw fills then strokes a shape
+func (r *renderer) draw(s style, build func(draw2d.PathBuilder)) {
+	gc := r.gc
+
+	if !s.fill.none {
+		gc.SetFillRule(s.fillRule)
+		gc.BeginPath()
+		build(gc)
+		o := s.opacity * s.fillOpacity
+		if s.fill.url != "" {
+			if p := r.paint(s.fill.url, build, o); p != nil {
+				draw2d2.FillPaint(gc, p)
+			}
+		} else {
+			gc.SetFillColor(withOpacity(s.fill.colour, o))
+			gc.Fill()
+		}
+	}
+
+	if !s.stroke.none && s.strokeWidth > 0 {
+		gc.SetLineWidth(s.strokeWidth)
+		gc.SetLineCap(s.lineCap)
+		gc.SetLineJoin(s.lineJoin)
+		gc.SetLineDash(s.dash, s.dashOffset)
+		gc.BeginPath()
+		build(gc)
+		o := s.opacity * s.strokeOpacity
+		if s.stroke.url != "" {
+			if p := r.paint(s.stroke.url, build, o); p != nil {
+				draw2d2.StrokePaint(gc, p)
+			}
+		} else {
+			gc.SetStrokeColor(withOpacity(s.stroke.colour, o))
+			gc.Stroke()
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package svg
+
+import (
+	"github.com/llgcode/draw2d"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// paintSpec is the value of a fill or stroke property
+type paintSpec struct {
+	none   bool
+	colour color.Color // Colour if not a url
+	url    string      // Id of a gradient
+}
+
+// style holds the inherited presentation properties of an element
+type style struct {
+	fill          paintSpec
+	stroke        paintSpec
+	colour        color.Color // currentColor
+	fillOpacity   float64
+	strokeOpacity float64
+	opacity       float64 // Product of the opacity of the element and its ancestors
+	strokeWidth   float64
+	fillRule      draw2d.FillRule
+	lineCap       draw2d.LineCap
+	lineJoin      draw2d.LineJoin
+	dash          []float64
+	dashOffset    float64
+}
+
+func defaultStyle() style {
+	return style{
+		fill:          paintSpec{colour: color.Black},
+		stroke:        paintSpec{none: true},
+		colour:        color.Black,
+		fillOpacity:   1,
+		strokeOpacity: 1,
+		opacity:       1,
+		strokeWidth:   1,
+		fillRule:      draw2d.FillRuleWinding,
+		lineCap:       draw2d.ButtCap,
+		lineJoin:      draw2d.MiterJoin,
+	}
+}
+
+// apply returns the style of n, inheriting s
+func (s style) apply(n *node) style {
+	if v := n.attr("color"); v != "" && v != "inherit" {
+		if c, ok := parseColour(v, s.colour); ok {
+			s.colour = c
+		}
+	}
+	if v := n.attr("fill"); v != "" && v != "inherit" {
+		s.fill = parsePaint(v, s.colour)
+	}
+	if v := n.attr("stroke"); v != "" && v != "inherit" {
+		s.stroke = parsePaint(v, s.colour)
+	}
+
+	s.fillOpacity = opacity(n.attr("fill-opacity"), s.fillOpacity)
+	s.strokeOpacity = opacity(n.attr("stroke-opacity"), s.strokeOpacity)
+	// opacity is not inherited but applies to everything within the element
+	s.opacity *= opacity(n.attr("opacity"), 1)
+
+	if v, ok := parseLength(n.attr("stroke-width")); ok {
+		s.strokeWidth = v
+	}
+
+	switch n.attr("fill-rule") {
+	case "evenodd":
+		s.fillRule = draw2d.FillRuleEvenOdd
+	case "nonzero":
+		s.fillRule = draw2d.FillRuleWinding
+	}
+
+	switch n.attr("stroke-linecap") {
+	case "butt":
+		s.lineCap = draw2d.ButtCap
+	case "round":
+		s.lineCap = draw2d.RoundCap
+	case "square":
+		s.lineCap = draw2d.SquareCap
+	}
+
+	switch n.attr("stroke-linejoin") {
+	case "miter", "miter-clip", "arcs":
+		s.lineJoin = draw2d.MiterJoin
+	case "round":
+		s.lineJoin = draw2d.RoundJoin
+	case "bevel":
+		s.lineJoin = draw2d.BevelJoin
+	}
+
+	if v := n.attr("stroke-dasharray"); v == "none" {
+		s.dash = nil
+	} else if v != "" && v != "inherit" {
+		s.dash = parseNumbers(v)
+		// An odd number of values is repeated to give an even number
+		if len(s.dash)%2 == 1 {
+			s.dash = append(s.dash, s.dash...)
+		}
+	}
+	if v, ok := parseLength(n.attr("stroke-dashoffset")); ok {
+		s.dashOffset = v
+	}
+
+	return s
+}
+
+// hidden returns true if n is not displayed
+func hidden(n *node) bool {
+	return n.attr("display") == "none" || n.attr("visibility") == "hidden"
+}
+
+func opacity(s string, def float64) float64 {
+	if s == "" || s == "inherit" {
+		return def
+	}
+	pc := strings.HasSuffix(s, "%")
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil {
+		return def
+	}
+	if pc {
+		v /= 100
+	}
+	return math.Max(0, math.Min(1, v))
+}
+
+// parsePaint parses a fill or stroke value
+func parsePaint(s string, current color.Color) paintSpec {
+	if s == "none" || s == "transparent" {
+		return paintSpec{none: true}
+	}
+	if strings.HasPrefix(s, "url(") {
+		id, _, _ := strings.Cut(strings.TrimPrefix(s, "url("), ")")
+		id = strings.Trim(strings.TrimSpace(id), `'"`)
+		return paintSpec{url: strings.TrimPrefix(id, "#")}
+	}
+	if c, ok := parseColour(s, current); ok {
+		return paintSpec{colour: c}
+	}
+	return paintSpec{none: true}
+}
+
+// withOpacity returns c with its alpha scaled by o
+func withOpacity(c color.Color, o float64) color.Color {
+	if o >= 1 {
+		return c
+	}
+	r, g, b, a := c.RGBA()
+	return color.RGBA64{
+		R: uint16(float64(r) * o),
+		G: uint16(float64(g) * o),
+		B: uint16(float64(b) * o),
+		A: uint16(float64(a) * o),
+	}
+}
+
+// parseLength parses a number with an optional unit, converting absolute units to pixels
+func parseLength(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+
+	scale := 1.0
+	for _, u := range []struct {
+		suffix string
+		scale  float64
+	}{
+		{"px", 1},
+		{"pt", 96.0 / 72},
+		{"pc", 16},
+		{"mm", 96 / 25.4},
+		{"cm", 96 / 2.54},
+		{"in", 96},
+		{"%", 1}, // Not resolved, callers check for this where it matters
+	} {
+		if strings.HasSuffix(s, u.suffix) {
+			s, scale = strings.TrimSuffix(s, u.suffix), u.scale
+			break
+		}
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, false
+	}
+	return v * scale, true
+}
+
+// parseNumbers parses a list of numbers separated by whitespace or commas
+func parseNumbers(s string) []float64 {
+	var r []float64
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	}) {
+		if v, ok := parseLength(f); ok {
+			r = append(r, v)
+		}
+	}
+	return r
+}
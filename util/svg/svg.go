@@ -0,0 +1,177 @@
+// Package svg renders a subset of SVG, enough for logos and icons, as vectors so they stay crisp at any scale.
+//
+// Supported are the svg, g, defs, use, path, rect, circle, ellipse, line, polyline and polygon elements,
+// transforms, presentation attributes and style properties for fills and strokes, and linear and radial gradients.
+// Text, filters, masks, clipping and CSS style sheets are not supported.
+package svg
+
+import (
+	"encoding/xml"
+	"fmt"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// Document is a parsed SVG document
+type Document struct {
+	root          *node
+	ids           map[string]*node // Elements by id
+	width, height float64          // Intrinsic size
+	viewBox       [4]float64       // min-x, min-y, width and height of the coordinate system
+	preserve      bool             // Keep the aspect ratio when scaled
+}
+
+// node is an element within the document
+type node struct {
+	name     string
+	attrs    map[string]string
+	children []*node
+}
+
+// attr returns an attribute, with any property of the same name in the style attribute taking precedence
+func (n *node) attr(name string) string {
+	if s, ok := n.attrs["style"]; ok {
+		for _, p := range strings.Split(s, ";") {
+			if k, v, ok := strings.Cut(p, ":"); ok && strings.TrimSpace(k) == name {
+				return strings.TrimSpace(v)
+			}
+		}
+	}
+	return strings.TrimSpace(n.attrs[name])
+}
+
+// number returns a numeric attribute, def if absent or invalid
+func (n *node) number(name string, def float64) float64 {
+	if v, ok := parseLength(n.attr(name)); ok {
+		return v
+	}
+	return def
+}
+
+// Load reads an SVG document from a file
+func Load(fileName string) (*Document, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	d, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileName, err)
+	}
+	return d, nil
+}
+
+// Parse reads an SVG document
+func Parse(r io.Reader) (*Document, error) {
+	d := &Document{ids: make(map[string]*node)}
+
+	dec := xml.NewDecoder(r)
+	// Most SVG files are UTF-8 but some declare other encodings which can be read as-is for attributes
+	dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	// Entities like &ns_svg; are common in exported files
+	dec.Strict = false
+
+	var stack []*node
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{name: t.Name.Local, attrs: make(map[string]string)}
+			for _, a := range t.Attr {
+				// Keep xlink:href as href
+				n.attrs[a.Name.Local] = a.Value
+			}
+			if id := n.attrs["id"]; id != "" {
+				d.ids[id] = n
+			}
+			if len(stack) > 0 {
+				p := stack[len(stack)-1]
+				p.children = append(p.children, n)
+			} else if d.root == nil {
+				d.root = n
+			}
+			stack = append(stack, n)
+
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	if d.root == nil || d.root.name != "svg" {
+		return nil, fmt.Errorf("not an svg document")
+	}
+
+	d.size()
+	return d, nil
+}
+
+// size determines the intrinsic size and coordinate system from the root element
+func (d *Document) size() {
+	r := d.root
+	d.width, d.height = r.number("width", 0), r.number("height", 0)
+
+	vb := parseNumbers(r.attr("viewBox"))
+	if len(vb) == 4 && vb[2] > 0 && vb[3] > 0 {
+		copy(d.viewBox[:], vb)
+	}
+
+	// Without a size use the view box, a percentage size also needs it so treat that the same way
+	if strings.HasSuffix(r.attr("width"), "%") || d.width <= 0 {
+		d.width = d.viewBox[2]
+	}
+	if strings.HasSuffix(r.attr("height"), "%") || d.height <= 0 {
+		d.height = d.viewBox[3]
+	}
+	// Neither so use the SVG default viewport
+	if d.width <= 0 || d.height <= 0 {
+		d.width, d.height = 300, 150
+	}
+	if d.viewBox[2] <= 0 || d.viewBox[3] <= 0 {
+		d.viewBox = [4]float64{0, 0, d.width, d.height}
+	}
+
+	d.preserve = !strings.HasPrefix(r.attr("preserveAspectRatio"), "none")
+}
+
+// Size returns the intrinsic size of the document
+func (d *Document) Size() (float64, float64) {
+	return d.width, d.height
+}
+
+// Draw draws the document scaled into the rectangle at x,y of size w*h.
+// Unless the document says otherwise its aspect ratio is kept, centering it within the rectangle.
+func (d *Document) Draw(gc draw2d2.GraphicContext, x, y, w, h float64) {
+	vb := d.viewBox
+	sx, sy := w/vb[2], h/vb[3]
+	if d.preserve {
+		s := math.Min(sx, sy)
+		x += (w - vb[2]*s) / 2
+		y += (h - vb[3]*s) / 2
+		sx, sy = s, s
+	}
+
+	gc.Save()
+	defer gc.Restore()
+	gc.Translate(x, y)
+	gc.Scale(sx, sy)
+	gc.Translate(-vb[0], -vb[1])
+
+	r := &renderer{doc: d, gc: gc}
+	r.group(d.root, defaultStyle())
+}
@@ -0,0 +1,140 @@
+package svg
+
+import (
+	"github.com/llgcode/draw2d"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"image"
+	"image/color"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		d    string
+		want []draw2d.PathCmp
+		pts  []float64
+	}{
+		{"M10 20L30 40", []draw2d.PathCmp{draw2d.MoveToCmp, draw2d.LineToCmp}, []float64{10, 20, 30, 40}},
+		// Implicit lines after a move, relative to the current point
+		{"m10,20 5,5 5-5", []draw2d.PathCmp{draw2d.MoveToCmp, draw2d.LineToCmp, draw2d.LineToCmp}, []float64{10, 20, 15, 25, 20, 20}},
+		{"M0 0H10V10h-10z", []draw2d.PathCmp{draw2d.MoveToCmp, draw2d.LineToCmp, draw2d.LineToCmp, draw2d.LineToCmp, draw2d.CloseCmp, draw2d.MoveToCmp}, []float64{0, 0, 10, 0, 10, 10, 0, 10, 0, 0}},
+		// Numbers need not be separated
+		{"M.5.5l1-1", []draw2d.PathCmp{draw2d.MoveToCmp, draw2d.LineToCmp}, []float64{0.5, 0.5, 1.5, -0.5}},
+		// Parsing stops at an error keeping what was parsed
+		{"M0 0L10 10L5", []draw2d.PathCmp{draw2d.MoveToCmp, draw2d.LineToCmp}, []float64{0, 0, 10, 10}},
+		// Must start with a move
+		{"L10 10", nil, nil},
+	}
+	for _, test := range tests {
+		p := &draw2d.Path{}
+		parsePath(p, test.d)
+		if !slices.Equal(p.Components, test.want) || !equalPoints(p.Points, test.pts) {
+			t.Errorf("%q got %v %v want %v %v", test.d, p.Components, p.Points, test.want, test.pts)
+		}
+	}
+}
+
+func TestParsePath_Arc(t *testing.T) {
+	// A half circle of radius 10 with flags not separated from the end point
+	p := &draw2d.Path{}
+	parsePath(p, "M0 0A10 10 0 0110 0")
+	n := len(p.Points)
+	if x, y := p.Points[n-2], p.Points[n-1]; x != 10 || y != 0 {
+		t.Errorf("arc ends at %v,%v", x, y)
+	}
+	for _, c := range p.Components[1:] {
+		if c != draw2d.CubicCurveToCmp {
+			t.Fatalf("arc got %v", p.Components)
+		}
+	}
+}
+
+func TestParseTransform(t *testing.T) {
+	tests := []struct {
+		s            string
+		x, y         float64
+		wantX, wantY float64
+	}{
+		{"translate(10 20)", 1, 1, 11, 21},
+		{"scale(2)", 1, 3, 2, 6},
+		{"translate(10,0) scale(2)", 1, 1, 12, 2},
+		{"rotate(90)", 1, 0, 0, 1},
+		{"rotate(90 10 10)", 10, 0, 20, 10},
+		{"matrix(1 0 0 1 5 6)", 0, 0, 5, 6},
+	}
+	for _, test := range tests {
+		x, y := parseTransform(test.s).TransformPoint(test.x, test.y)
+		if math.Abs(x-test.wantX) > 1e-9 || math.Abs(y-test.wantY) > 1e-9 {
+			t.Errorf("%q got %v,%v want %v,%v", test.s, x, y, test.wantX, test.wantY)
+		}
+	}
+}
+
+func TestParseColour(t *testing.T) {
+	tests := []struct {
+		s    string
+		want color.RGBA
+	}{
+		{"red", color.RGBA{R: 255, A: 255}},
+		{"#0f0", color.RGBA{G: 255, A: 255}},
+		{"#0000ff", color.RGBA{B: 255, A: 255}},
+		{"rgb(255, 0, 0)", color.RGBA{R: 255, A: 255}},
+		{"rgb(100%,100%,100%)", color.RGBA{R: 255, G: 255, B: 255, A: 255}},
+		{"currentColor", color.RGBA{R: 1, G: 2, B: 3, A: 255}},
+	}
+	for _, test := range tests {
+		c, ok := parseColour(test.s, color.RGBA{R: 1, G: 2, B: 3, A: 255})
+		if !ok || color.RGBAModel.Convert(c) != test.want {
+			t.Errorf("%q got %v %v want %v", test.s, c, ok, test.want)
+		}
+	}
+}
+
+func TestDocument_Draw(t *testing.T) {
+	doc, err := Parse(strings.NewReader(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 10 10">
+  <defs>
+    <linearGradient id="g"><stop offset="0" stop-color="red"/><stop offset="1" stop-color="red"/></linearGradient>
+  </defs>
+  <rect width="5" height="10" fill="#00f"/>
+  <g transform="translate(5 0)"><rect width="5" height="10" style="fill:url(#g)"/></g>
+</svg>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, h := doc.Size(); w != 10 || h != 10 {
+		t.Errorf("size got %v,%v", w, h)
+	}
+
+	// Drawn at 10x the size, and centered vertically as it keeps its aspect ratio
+	img := image.NewRGBA(image.Rect(0, 0, 100, 200))
+	doc.Draw(draw2d2.NewImageGraphicContext(img), 0, 0, 100, 200)
+
+	tests := []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{25, 100, color.RGBA{B: 255, A: 255}},
+		{75, 100, color.RGBA{R: 255, A: 255}},
+		{25, 20, color.RGBA{}},
+	}
+	for _, test := range tests {
+		if got := img.RGBAAt(test.x, test.y); got != test.want {
+			t.Errorf("at %d,%d got %v want %v", test.x, test.y, got, test.want)
+		}
+	}
+}
+
+func equalPoints(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
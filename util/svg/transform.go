@@ -0,0 +1,57 @@
+package svg
+
+import (
+	"github.com/llgcode/draw2d"
+	"math"
+	"strings"
+)
+
+// parseTransform parses a transform attribute, e.g. "translate(10,20) rotate(45)".
+// Transforms are applied right to left so the result maps element coordinates to those of its parent.
+func parseTransform(s string) draw2d.Matrix {
+	m := draw2d.NewIdentityMatrix()
+	for {
+		name, rest, ok := strings.Cut(s, "(")
+		if !ok {
+			return m
+		}
+		args, next, _ := strings.Cut(rest, ")")
+		s = next
+
+		v := parseNumbers(args)
+		arg := func(i int, def float64) float64 {
+			if i < len(v) {
+				return v[i]
+			}
+			return def
+		}
+
+		var t draw2d.Matrix
+		switch strings.TrimSpace(strings.Trim(name, ", \t\r\n")) {
+		case "matrix":
+			if len(v) != 6 {
+				continue
+			}
+			copy(t[:], v)
+		case "translate":
+			t = draw2d.NewTranslationMatrix(arg(0, 0), arg(1, 0))
+		case "scale":
+			t = draw2d.NewScaleMatrix(arg(0, 1), arg(1, arg(0, 1)))
+		case "rotate":
+			a := arg(0, 0) * math.Pi / 180
+			cx, cy := arg(1, 0), arg(2, 0)
+			t = draw2d.NewTranslationMatrix(cx, cy)
+			t.Rotate(a)
+			t.Translate(-cx, -cy)
+		case "skewX":
+			t = draw2d.Matrix{1, 0, math.Tan(arg(0, 0) * math.Pi / 180), 1, 0, 0}
+		case "skewY":
+			t = draw2d.Matrix{1, math.Tan(arg(0, 0) * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			continue
+		}
+
+		// Each transform applies before those to its left
+		m.Compose(t)
+	}
+}
@@ -0,0 +1,229 @@
+package util
+
+import (
+	"fmt"
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dkit"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"github.com/peter-mount/go-anim/util/font"
+	"image"
+	"image/color"
+	"math"
+)
+
+// TextEffects are drawn with text to keep it readable over busy backgrounds, like telemetry over a bright sky.
+// They are drawn in the order plate, glow, shadow, outline then the text itself.
+//
+// A nil *TextEffects draws plain text, so it can be used wherever effects are optional.
+type TextEffects struct {
+	outline          float64     // Width of the outline
+	outlineColour    color.Color // Colour of the outline
+	shadow           color.Color // Colour of the shadow, nil for none
+	shadowX, shadowY float64     // Offset of the shadow
+	shadowBlur       float64     // Blur of the shadow in pixels
+	glow             color.Color // Colour of the glow, nil for none
+	glowRadius       float64     // Size of the glow in pixels
+	plate            color.Color // Colour of the plate including opacity, nil for none
+	platePadding     float64     // Space between the text and the edge of the plate
+	plateRadius      float64     // Radius of the plate's corners
+}
+
+// NewTextEffects creates TextEffects with no effects set
+func NewTextEffects() *TextEffects {
+	return &TextEffects{}
+}
+
+// Outline strokes around each glyph with a line of width pixels. A width of 0 removes the outline
+func (e *TextEffects) Outline(width float64, c color.Color) *TextEffects {
+	e.outline, e.outlineColour = width, c
+	return e
+}
+
+// Shadow draws a drop shadow offset by dx, dy and blurred over blur pixels. A nil colour removes the shadow
+func (e *TextEffects) Shadow(c color.Color, dx, dy, blur float64) *TextEffects {
+	e.shadow, e.shadowX, e.shadowY, e.shadowBlur = c, dx, dy, blur
+	return e
+}
+
+// Glow draws a blurred halo extending radius pixels around the text. A nil colour removes the glow
+func (e *TextEffects) Glow(c color.Color, radius float64) *TextEffects {
+	e.glow, e.glowRadius = c, radius
+	return e
+}
+
+// Plate draws a rectangle behind the text, padding pixels larger than the text with corners rounded by radius.
+// The opacity, from 0 to 1, is applied to the colour. A nil colour removes the plate
+func (e *TextEffects) Plate(c color.Color, padding, radius, opacity float64) *TextEffects {
+	e.plate, e.platePadding, e.plateRadius = nil, padding, radius
+	if c != nil {
+		r, g, b, a := c.RGBA()
+		o := math.Max(0, math.Min(1, opacity))
+		e.plate = color.RGBA64{
+			R: uint16(float64(r) * o),
+			G: uint16(float64(g) * o),
+			B: uint16(float64(b) * o),
+			A: uint16(float64(a) * o),
+		}
+	}
+	return e
+}
+
+// HasPlate returns true if a plate will be drawn
+func (e *TextEffects) HasPlate() bool {
+	return e != nil && e.plate != nil
+}
+
+// WithoutPlate returns a copy of the effects without the plate,
+// used when a single plate is drawn behind several strings with DrawPlate
+func (e *TextEffects) WithoutPlate() *TextEffects {
+	if e == nil {
+		return nil
+	}
+	c := *e
+	c.plate = nil
+	return &c
+}
+
+// DrawPlate draws the plate behind text occupying r
+func (e *TextEffects) DrawPlate(gc draw2d2.GraphicContext, r Rectangle) {
+	if !e.HasPlate() {
+		return
+	}
+	r = r.Expand(e.platePadding, e.platePadding, e.platePadding, e.platePadding)
+
+	gc.Save()
+	defer gc.Restore()
+	gc.SetFillColor(e.plate)
+	gc.BeginPath()
+	if e.plateRadius > 0 {
+		draw2dkit.RoundedRectangle(gc, r.X1, r.Y1, r.X2, r.Y2, e.plateRadius*2, e.plateRadius*2)
+	} else {
+		draw2dkit.Rectangle(gc, r.X1, r.Y1, r.X2, r.Y2)
+	}
+	gc.Fill()
+}
+
+// FillStringAt draws s with the effects with its baseline starting at x, y, returning its width
+func (e *TextEffects) FillStringAt(gc draw2d2.GraphicContext, s string, x, y float64) float64 {
+	if e != nil {
+		if e.plate != nil {
+			l, t, r, b := font.StringBounds(gc, s)
+			e.DrawPlate(gc, Rect(x+l, y+t, x+r, y+b))
+		}
+
+		if e.glow != nil && e.glowRadius > 0 {
+			drawBlurred(gc, s, x, y, e.glow, e.glowRadius/2, e.glowRadius)
+		}
+
+		if e.shadow != nil {
+			drawBlurred(gc, s, x+e.shadowX, y+e.shadowY, e.shadow, 0, e.shadowBlur)
+		}
+
+		if e.outline > 0 {
+			gc.Save()
+			if e.outlineColour != nil {
+				gc.SetStrokeColor(e.outlineColour)
+			}
+			// The fill covers the inner half of the stroke
+			gc.SetLineWidth(e.outline * 2)
+			gc.SetLineJoin(draw2d.RoundJoin)
+			font.StrokeStringAt(gc, s, x, y)
+			gc.Restore()
+		}
+	}
+
+	return font.FillStringAt(gc, s, x, y)
+}
+
+// DrawStringLeft is the same as the DrawStringLeft function but with these effects
+func (e *TextEffects) DrawStringLeft(gc draw2d2.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
+	return e.drawString(gc, LeftAlignment, x, y, fmt.Sprintf(s, a...))
+}
+
+// DrawStringCenter is the same as the DrawStringCenter function but with these effects
+func (e *TextEffects) DrawStringCenter(gc draw2d2.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
+	return e.drawString(gc, CenterAlignment, x, y, fmt.Sprintf(s, a...))
+}
+
+// DrawStringRight is the same as the DrawStringRight function but with these effects
+func (e *TextEffects) DrawStringRight(gc draw2d2.GraphicContext, x, y float64, s string, a ...interface{}) float64 {
+	return e.drawString(gc, RightAlignment, x, y, fmt.Sprintf(s, a...))
+}
+
+// drawBlurred draws s in colour c, grown by spread pixels then blurred over radius pixels
+func drawBlurred(gc draw2d2.GraphicContext, s string, x, y float64, c color.Color, spread, radius float64) {
+	l, t, r, b := font.StringBounds(gc, s)
+	if r <= l {
+		return
+	}
+
+	// Three box blurs approximate a gaussian, each extending the image by k pixels
+	k := int(math.Ceil(radius / 3))
+	m := int(math.Ceil(spread)) + 3*k + 2
+	mask := image.NewRGBA(image.Rect(0, 0, int(math.Ceil(r-l))+2*m, int(math.Ceil(b-t))+2*m))
+
+	mgc := gc.NewGraphicContext(mask)
+	mgc.SetDPI(gc.GetDPI())
+	mgc.SetFontData(gc.GetFontData())
+	mgc.SetFontSize(gc.GetFontSize())
+	mgc.SetFillColor(c)
+	mgc.SetStrokeColor(c)
+
+	ox, oy := float64(m)-l, float64(m)-t
+	font.FillStringAt(mgc, s, ox, oy)
+	if spread > 0 {
+		mgc.SetLineWidth(spread * 2)
+		mgc.SetLineJoin(draw2d.RoundJoin)
+		font.StrokeStringAt(mgc, s, ox, oy)
+	}
+
+	for i := 0; i < 3; i++ {
+		boxBlur(mask, k)
+	}
+
+	gc.Save()
+	defer gc.Restore()
+	gc.Translate(x-ox, y-oy)
+	gc.DrawImage(mask)
+}
+
+// boxBlur blurs an image by averaging each pixel with the k pixels either side, horizontally then vertically
+func boxBlur(img *image.RGBA, k int) {
+	if k <= 0 {
+		return
+	}
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	n := 2*k + 1
+
+	line := make([]uint8, max(w, h)*4)
+	blur := func(get func(i int) int, length int) {
+		for i := 0; i < length; i++ {
+			copy(line[i*4:i*4+4], img.Pix[get(i):get(i)+4])
+		}
+		for c := 0; c < 4; c++ {
+			sum := 0
+			for i := -k; i <= k; i++ {
+				if i >= 0 && i < length {
+					sum += int(line[i*4+c])
+				}
+			}
+			for i := 0; i < length; i++ {
+				img.Pix[get(i)+c] = uint8(sum / n)
+				if o := i - k; o >= 0 {
+					sum -= int(line[o*4+c])
+				}
+				if o := i + k + 1; o < length {
+					sum += int(line[o*4+c])
+				}
+			}
+		}
+	}
+
+	for y := 0; y < h; y++ {
+		blur(func(i int) int { return y*img.Stride + i*4 }, w)
+	}
+	for x := 0; x < w; x++ {
+		blur(func(i int) int { return i*img.Stride + x*4 }, h)
+	}
+}
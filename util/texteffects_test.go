@@ -0,0 +1,46 @@
+package util
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestBoxBlur(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 9, 9))
+	img.Set(4, 4, color.RGBA{R: 255, A: 255})
+
+	boxBlur(img, 1)
+
+	// A single pixel spreads evenly over a 3x3 square
+	for y := 0; y < 9; y++ {
+		for x := 0; x < 9; x++ {
+			want := uint8(0)
+			if x >= 3 && x <= 5 && y >= 3 && y <= 5 {
+				want = 255 / 3 / 3
+			}
+			if got := img.RGBAAt(x, y).A; got != want {
+				t.Errorf("%d,%d got %d want %d", x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestTextEffects_Plate(t *testing.T) {
+	e := NewTextEffects().Plate(color.White, 4, 2, 0.5)
+	if !e.HasPlate() {
+		t.Fatal("no plate")
+	}
+	if r, _, _, a := e.plate.RGBA(); r != 0x7fff || a != 0x7fff {
+		t.Errorf("got r=%x a=%x want 7fff", r, a)
+	}
+
+	if e.WithoutPlate().HasPlate() || !e.HasPlate() {
+		t.Error("WithoutPlate should only remove the plate from the copy")
+	}
+
+	var none *TextEffects
+	if none.HasPlate() || none.WithoutPlate() != nil {
+		t.Error("nil effects should have no plate")
+	}
+}
@@ -0,0 +1,110 @@
+package unit
+
+import (
+	"encoding/json"
+	"fmt"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
+	"image"
+)
+
+// Context holds what is needed to resolve a Value to pixels, including the units which are relative to a layout.
+type Context struct {
+	GC       draw2d2.GraphicContext // For physical and font relative units, may be nil
+	Parent   float64                // Size of the parent in pixels, for Percent
+	Viewport image.Point            // Size of the viewport in pixels, for Vw, Vh, Vmin and Vmax
+}
+
+// Resolve returns the value in pixels within a Context
+func (v Value) Resolve(ctx Context) float64 {
+	switch v.U {
+	case Percent:
+		return v.F * ctx.Parent / 100
+	case Vw:
+		return v.F * float64(ctx.Viewport.X) / 100
+	case Vh:
+		return v.F * float64(ctx.Viewport.Y) / 100
+	case Vmin:
+		return v.F * float64(min(ctx.Viewport.X, ctx.Viewport.Y)) / 100
+	case Vmax:
+		return v.F * float64(max(ctx.Viewport.X, ctx.Viewport.Y)) / 100
+	default:
+		return v.Pixels(ctx.GC)
+	}
+}
+
+// IsRelative returns true if the unit is relative to the layout
+func (u Unit) IsRelative() bool {
+	return u >= Percent
+}
+
+// UnmarshalJSON accepts either a number, in pixels, or a string parsed by ParseValue
+func (v *Value) UnmarshalJSON(b []byte) error {
+	var a any
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	return v.unmarshal(a)
+}
+
+// UnmarshalYAML accepts either a number, in pixels, or a string parsed by ParseValue
+func (v *Value) UnmarshalYAML(unmarshal func(any) error) error {
+	var a any
+	if err := unmarshal(&a); err != nil {
+		return err
+	}
+	return v.unmarshal(a)
+}
+
+func (v *Value) unmarshal(a any) error {
+	switch a := a.(type) {
+	case string:
+		r, err := ParseValue(a)
+		if err != nil {
+			return err
+		}
+		*v = r
+	case float64:
+		*v = Pixels(a)
+	case int:
+		*v = Pixels(float64(a))
+	default:
+		return fmt.Errorf("invalid value %v", a)
+	}
+	return nil
+}
+
+// UnmarshalJSON accepts either a number, in pixels, or a string parsed by ParseDimension
+func (d *Dimension) UnmarshalJSON(b []byte) error {
+	var a any
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	return d.unmarshal(a)
+}
+
+// UnmarshalYAML accepts either a number, in pixels, or a string parsed by ParseDimension
+func (d *Dimension) UnmarshalYAML(unmarshal func(any) error) error {
+	var a any
+	if err := unmarshal(&a); err != nil {
+		return err
+	}
+	return d.unmarshal(a)
+}
+
+func (d *Dimension) unmarshal(a any) error {
+	switch a := a.(type) {
+	case string:
+		r, err := ParseDimension(a)
+		if err != nil {
+			return err
+		}
+		*d = r
+	case float64:
+		*d = SquareDimension(Pixels(a))
+	case int:
+		*d = SquareDimension(Pixels(float64(a)))
+	default:
+		return fmt.Errorf("invalid dimension %v", a)
+	}
+	return nil
+}
@@ -2,8 +2,8 @@ package unit
 
 import (
 	"fmt"
-	"github.com/llgcode/draw2d/draw2dimg"
 	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
 	"strings"
 )
 
@@ -30,7 +30,7 @@ func (d Dimension) IsZero() bool {
 	return d.Top.IsZero() && d.Bottom.IsZero() && d.Left.IsZero() && d.Right.IsZero()
 }
 
-func (d Dimension) Convert(gc *draw2dimg.GraphicContext, to Unit) Dimension {
+func (d Dimension) Convert(gc draw2d2.GraphicContext, to Unit) Dimension {
 	return Dimension{
 		Top:    d.Top.Convert(gc, to),
 		Bottom: d.Bottom.Convert(gc, to),
@@ -110,10 +110,10 @@ func ParseDimension(s string) (Dimension, error) {
 
 	case 4:
 		return Dimension{
-			Top:    a[1],
-			Right:  a[2],
-			Bottom: a[3],
-			Left:   a[4],
+			Top:    a[0],
+			Right:  a[1],
+			Bottom: a[2],
+			Left:   a[3],
 		}, nil
 
 	default:
@@ -123,7 +123,7 @@ func ParseDimension(s string) (Dimension, error) {
 
 // Add adds two dimensions, increasing the values for each side.
 // The result will be of the dimension on the left hand side
-func (d Dimension) Add(gc *draw2dimg.GraphicContext, b Dimension) Dimension {
+func (d Dimension) Add(gc draw2d2.GraphicContext, b Dimension) Dimension {
 	return Dimension{
 		Top:    d.Top.Add(gc, b.Top),
 		Bottom: d.Bottom.Add(gc, b.Bottom),
@@ -134,7 +134,7 @@ func (d Dimension) Add(gc *draw2dimg.GraphicContext, b Dimension) Dimension {
 
 // Sub subtracts two dimensions, increasing the values for each side.
 // The result will be of the dimension on the left hand side
-func (d Dimension) Sub(gc *draw2dimg.GraphicContext, b Dimension) Dimension {
+func (d Dimension) Sub(gc draw2d2.GraphicContext, b Dimension) Dimension {
 	return Dimension{
 		Top:    d.Top.Sub(gc, b.Top),
 		Bottom: d.Bottom.Sub(gc, b.Bottom),
@@ -144,11 +144,17 @@ func (d Dimension) Sub(gc *draw2dimg.GraphicContext, b Dimension) Dimension {
 }
 
 // ReduceRect reduces a rectangle by the dimension
-func (d Dimension) ReduceRect(gc *draw2dimg.GraphicContext, r util.Rectangle) util.Rectangle {
+func (d Dimension) ReduceRect(gc draw2d2.GraphicContext, r util.Rectangle) util.Rectangle {
 	return r.Reduce(d.Left.Pixels(gc), d.Top.Pixels(gc), d.Right.Pixels(gc), d.Bottom.Pixels(gc))
 }
 
 // ExpandRect expands a rectangle by the dimension
-func (d Dimension) ExpandRect(gc *draw2dimg.GraphicContext, r util.Rectangle) util.Rectangle {
+func (d Dimension) ExpandRect(gc draw2d2.GraphicContext, r util.Rectangle) util.Rectangle {
 	return r.Expand(d.Left.Pixels(gc), d.Top.Pixels(gc), d.Right.Pixels(gc), d.Bottom.Pixels(gc))
 }
+
+// Resolve returns the size of each side in pixels, clockwise from the top.
+// Like css, percentages are relative to the width of the parent, so ctx.Parent should be that width.
+func (d Dimension) Resolve(ctx Context) (top, right, bottom, left float64) {
+	return d.Top.Resolve(ctx), d.Right.Resolve(ctx), d.Bottom.Resolve(ctx), d.Left.Resolve(ctx)
+}
@@ -2,8 +2,8 @@ package unit
 
 import (
 	"fmt"
-	"github.com/llgcode/draw2d/draw2dimg"
 	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
 	"strings"
 )
 
@@ -17,7 +17,7 @@ type Rectangle struct {
 func (d Rectangle) IsZero() bool {
 	return d.X1.IsZero() && d.Y1.IsZero() && d.X2.IsZero() && d.Y2.IsZero()
 }
-func (d Rectangle) Convert(gc *draw2dimg.GraphicContext, to Unit) Rectangle {
+func (d Rectangle) Convert(gc draw2d2.GraphicContext, to Unit) Rectangle {
 	return Rectangle{
 		X1: d.X1.Convert(gc, to),
 		Y1: d.Y1.Convert(gc, to),
@@ -26,15 +26,15 @@ func (d Rectangle) Convert(gc *draw2dimg.GraphicContext, to Unit) Rectangle {
 	}
 }
 
-func (d Rectangle) Height(gc *draw2dimg.GraphicContext) Value {
+func (d Rectangle) Height(gc draw2d2.GraphicContext) Value {
 	return d.Y2.Sub(gc, d.Y1)
 }
 
-func (d Rectangle) Width(gc *draw2dimg.GraphicContext) Value {
+func (d Rectangle) Width(gc draw2d2.GraphicContext) Value {
 	return d.X2.Sub(gc, d.X1)
 }
 
-func (d Rectangle) Add(gc *draw2dimg.GraphicContext, b Rectangle) Rectangle {
+func (d Rectangle) Add(gc draw2d2.GraphicContext, b Rectangle) Rectangle {
 	return Rectangle{
 		X1: d.X1.Min(gc, b.X1),
 		Y1: d.Y1.Min(gc, b.Y1),
@@ -43,7 +43,7 @@ func (d Rectangle) Add(gc *draw2dimg.GraphicContext, b Rectangle) Rectangle {
 	}
 }
 
-func (d Rectangle) Expand(gc *draw2dimg.GraphicContext, dim Dimension) Rectangle {
+func (d Rectangle) Expand(gc draw2d2.GraphicContext, dim Dimension) Rectangle {
 	return Rectangle{
 		X1: d.X1.Sub(gc, dim.Left),
 		Y1: d.Y1.Sub(gc, dim.Top),
@@ -52,7 +52,7 @@ func (d Rectangle) Expand(gc *draw2dimg.GraphicContext, dim Dimension) Rectangle
 	}
 }
 
-func (d Rectangle) Reduce(gc *draw2dimg.GraphicContext, dim Dimension) Rectangle {
+func (d Rectangle) Reduce(gc draw2d2.GraphicContext, dim Dimension) Rectangle {
 	return Rectangle{
 		X1: d.X1.Add(gc, dim.Left),
 		Y1: d.Y1.Add(gc, dim.Top),
@@ -61,12 +61,12 @@ func (d Rectangle) Reduce(gc *draw2dimg.GraphicContext, dim Dimension) Rectangle
 	}
 }
 
-func (d Rectangle) Rectangle(gc *draw2dimg.GraphicContext) util.Rectangle {
+func (d Rectangle) Rectangle(gc draw2d2.GraphicContext) util.Rectangle {
 	return util.Rect(d.X1.Pixels(gc), d.Y1.Pixels(gc), d.X2.Pixels(gc), d.Y2.Pixels(gc))
 }
 
 // FromRectangle takes a plain rectangle and converts it into one with the specified units
-func FromRectangle(gc *draw2dimg.GraphicContext, r util.Rectangle, unit Unit) Rectangle {
+func FromRectangle(gc draw2d2.GraphicContext, r util.Rectangle, unit Unit) Rectangle {
 	d := Rectangle{X1: Pixels(r.X1), Y1: Pixels(r.Y1), X2: Pixels(r.X2), Y2: Pixels(r.Y2)}
 	return d.Convert(gc, unit)
 }
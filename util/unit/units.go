@@ -39,6 +39,12 @@ var names = [...]string{
 	Em: "em",
 	Ex: "ex",
 	Ch: "ch",
+
+	Percent: "%",
+	Vw:      "vw",
+	Vh:      "vh",
+	Vmin:    "vmin",
+	Vmax:    "vmax",
 }
 
 // Unit is a unit of length, such as inches or pixels.
@@ -77,4 +83,18 @@ const (
 	// If the context does not specify a '0' glyph, the recommended fallback
 	// value for conversion is 0.5em.
 	Ch
+
+	// Percent is a percentage of the size of the parent.
+	//
+	// This and the viewport units are relative to the layout, so they can only be
+	// converted to pixels with Value.Resolve. Convert treats them as pixels.
+	Percent
+	// Vw is 1% of the width of the viewport.
+	Vw
+	// Vh is 1% of the height of the viewport.
+	Vh
+	// Vmin is 1% of the smaller dimension of the viewport.
+	Vmin
+	// Vmax is 1% of the larger dimension of the viewport.
+	Vmax
 )
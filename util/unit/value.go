@@ -31,11 +31,9 @@
 package unit
 
 import (
-	"github.com/llgcode/draw2d"
-	"github.com/llgcode/draw2d/draw2dimg"
 	"github.com/peter-mount/go-anim/util"
+	draw2d2 "github.com/peter-mount/go-anim/util/draw2d"
 	"github.com/peter-mount/go-anim/util/font"
-	"golang.org/x/image/math/fixed"
 	"math"
 	"strconv"
 	"strings"
@@ -53,19 +51,19 @@ type Value struct {
 	U Unit
 }
 
-func (v Value) Convert(gc *draw2dimg.GraphicContext, to Unit) Value {
+func (v Value) Convert(gc draw2d2.GraphicContext, to Unit) Value {
 	if v.U == to {
 		return v
 	}
 
 	return Value{
-		F: v.F * to.PixelsPer(gc) / v.U.PixelsPer(gc),
+		F: v.F * v.U.PixelsPer(gc) / to.PixelsPer(gc),
 		U: to,
 	}
 }
 
 // Pixels returns the value in pixels as a float64
-func (v Value) Pixels(gc *draw2dimg.GraphicContext) float64 {
+func (v Value) Pixels(gc draw2d2.GraphicContext) float64 {
 	return v.Convert(gc, Px).F
 }
 
@@ -74,10 +72,10 @@ func Pixels(f float64) Value {
 }
 
 // PixelsPer returns the number of pixels in the unit u.
-func (u Unit) PixelsPer(gc *draw2dimg.GraphicContext) float64 {
+func (u Unit) PixelsPer(gc draw2d2.GraphicContext) float64 {
 	dpi := 92.0
 	if gc != nil {
-		dpi = float64(gc.DPI)
+		dpi = float64(gc.GetDPI())
 	}
 
 	switch u {
@@ -94,9 +92,8 @@ func (u Unit) PixelsPer(gc *draw2dimg.GraphicContext) float64 {
 	}
 
 	if gc != nil {
-		f := draw2d.GetFont(gc.GetFontData())
-		ext := draw2dimg.Extents(f, gc.GetFontSize())
-		h := ext.Height
+		fd, size := gc.GetFontData(), gc.GetFontSize()
+		h := font.New(fd.Name, size, fd.Family, fd.Style).Extents().Height
 
 		switch u {
 		case Em:
@@ -104,8 +101,9 @@ func (u Unit) PixelsPer(gc *draw2dimg.GraphicContext) float64 {
 		case Ex:
 			return h / 2
 		case Ch:
-			if i := f.Index('0'); i > 0 {
-				return font.FUnitsToFloat64(f.HMetric(fixed.Int26_6(gc.GetFontSize()), i).AdvanceWidth)
+			// Shaped at 72 DPI so the size is in pixels, as for Em
+			if w := font.DefaultShaper.Shape(fd, size, PointsPerInch, "0").Width; w > 0 {
+				return w
 			}
 			return h / 2
 		}
@@ -128,26 +126,24 @@ func (v Value) String() string {
 }
 
 func ParseValue(s string) (Value, error) {
+	// Use the longest matching suffix, so "vmin" is not mistaken for "in"
+	unit, suffix := Px, ""
 	for u, n := range names {
-		if strings.HasSuffix(s, n) {
-			f, err := strconv.ParseFloat(strings.TrimSuffix(s, n), 64)
-			if err != nil {
-				return Value{}, err
-			}
-			return Value{F: f, U: Unit(u)}, nil
+		if len(n) > len(suffix) && strings.HasSuffix(s, n) {
+			unit, suffix = Unit(u), n
 		}
 	}
 
 	// Default to Px
-	f, err := strconv.ParseFloat(s, 64)
+	f, err := strconv.ParseFloat(strings.TrimSuffix(s, suffix), 64)
 	if err != nil {
 		return Value{}, err
 	}
-	return Value{F: f, U: Px}, nil
+	return Value{F: f, U: unit}, nil
 }
 
 // Add adds two values. The result will be in the unit of the left hand side.
-func (v Value) Add(gc *draw2dimg.GraphicContext, b Value) Value {
+func (v Value) Add(gc draw2d2.GraphicContext, b Value) Value {
 	return Value{
 		F: v.F + b.Convert(gc, v.U).F,
 		U: v.U,
@@ -155,21 +151,21 @@ func (v Value) Add(gc *draw2dimg.GraphicContext, b Value) Value {
 }
 
 // Sub subtracts two values. The result will be in the unit of the left hand side.
-func (v Value) Sub(gc *draw2dimg.GraphicContext, b Value) Value {
+func (v Value) Sub(gc draw2d2.GraphicContext, b Value) Value {
 	return Value{
 		F: v.F - b.Convert(gc, v.U).F,
 		U: v.U,
 	}
 }
 
-func (v Value) Min(gc *draw2dimg.GraphicContext, b Value) Value {
+func (v Value) Min(gc draw2d2.GraphicContext, b Value) Value {
 	return Value{
 		F: math.Min(v.F, b.Convert(gc, v.U).F),
 		U: v.U,
 	}
 }
 
-func (v Value) Max(gc *draw2dimg.GraphicContext, b Value) Value {
+func (v Value) Max(gc draw2d2.GraphicContext, b Value) Value {
 	return Value{
 		F: math.Max(v.F, b.Convert(gc, v.U).F),
 		U: v.U,
@@ -0,0 +1,87 @@
+package unit
+
+import (
+	"image"
+	"testing"
+)
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Value
+	}{
+		{s: "12", want: Value{F: 12, U: Px}},
+		{s: "12px", want: Value{F: 12, U: Px}},
+		{s: "1.5em", want: Value{F: 1.5, U: Em}},
+		{s: "2in", want: Value{F: 2, U: In}},
+		{s: "50%", want: Value{F: 50, U: Percent}},
+		{s: "10vw", want: Value{F: 10, U: Vw}},
+		{s: "5vmin", want: Value{F: 5, U: Vmin}},
+		{s: "5vmax", want: Value{F: 5, U: Vmax}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseValue(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equals(tt.want) {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+			if got.String() != tt.want.String() {
+				t.Errorf("String got %q want %q", got.String(), tt.want.String())
+			}
+		})
+	}
+}
+
+func TestValue_Resolve(t *testing.T) {
+	ctx := Context{Parent: 200, Viewport: image.Pt(1920, 1080)}
+	tests := []struct {
+		s    string
+		want float64
+	}{
+		{s: "12", want: 12},
+		{s: "2in", want: 184},
+		{s: "36pt", want: 46},
+		{s: "25%", want: 50},
+		{s: "10vw", want: 192},
+		{s: "10vh", want: 108},
+		{s: "10vmin", want: 108},
+		{s: "10vmax", want: 192},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			v, err := ParseValue(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := v.Resolve(ctx); got != tt.want {
+				t.Errorf("got %f want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDimension(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Dimension
+	}{
+		{s: "1", want: SquareDimension(Pixels(1))},
+		{s: "1 2", want: NewDimension(Pixels(1), Pixels(1), Pixels(2), Pixels(2))},
+		{s: "1 2 3", want: NewDimension(Pixels(1), Pixels(3), Pixels(2), Pixels(2))},
+		{s: "1 2 3 4", want: NewDimension(Pixels(1), Pixels(3), Pixels(4), Pixels(2))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseDimension(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v want %v", got, tt.want)
+			}
+		})
+	}
+}